@@ -39,6 +39,43 @@ Feature:
             }
             """
 
+    Scenario: should run bell_stdgates.qasm
+        Given I set file "testdata/bell_stdgates.qasm"
+        Given I set "content-type" header with "application/json"
+        Given I set request body:
+            """
+            {
+                "code": "{{file:testdata/bell_stdgates.qasm}}"
+            }
+            """
+        When I send "POST" request to "/quasar.v1.QuasarService/Simulate"
+        Then the response code should be 200
+        Then the response should match json:
+            """
+            {
+                "states": [
+                    {
+                        "probability": 0.5,
+                        "amplitude": {
+                            "real": 0.707107
+                        },
+                        "binaryString": [
+                            "00"
+                        ]
+                    },
+                    {
+                        "probability": 0.5,
+                        "amplitude": {
+                            "real": 0.707107
+                        },
+                        "binaryString": [
+                            "11"
+                        ]
+                    }
+                ]
+            }
+            """
+
     Scenario: should run qft.qasm
         Given I set file "testdata/qft.qasm"
         Given I set "content-type" header with "application/json"
@@ -0,0 +1,414 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+const eps = 1e-12
+
+var (
+	ErrNotUnitary     = errors.New("not unitary")
+	ErrQubitsMismatch = errors.New("number of qubits mismatch")
+)
+
+type Kind int
+
+const (
+	Gate Kind = iota
+	GlobalPhase
+	Measure
+	Reset
+	Barrier
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Gate:
+		return "gate"
+	case GlobalPhase:
+		return "gphase"
+	case Measure:
+		return "measure"
+	case Reset:
+		return "reset"
+	case Barrier:
+		return "barrier"
+	default:
+		return "unknown"
+	}
+}
+
+// Call is the source statement an operation was expanded from.
+// For a gate call, Name includes the modifiers, e.g. "ctrl @ h", and Gate does not.
+// Controls and NegControls are the qubits of the ctrl and negctrl modifiers,
+// and Power is the exponent of the inv and pow modifiers, or 1 if there are none.
+type Call struct {
+	Name        string
+	Params      []float64
+	Qubits      []int
+	Line        int
+	Column      int
+	Gate        string
+	Controls    []int
+	NegControls []int
+	Power       float64
+}
+
+// Op is a primitive operation.
+// A Gate applies exp(i*Phase)*U(Theta, Phi, Lambda) to Target,
+// conditioned on Controls being |1> and NegControls being |0>.
+// A GlobalPhase multiplies the state by exp(i*Phase).
+// A Measure measures Target into the classical bit Clbit, or discards the result if Clbit is -1.
+// A Reset resets Target to |0>.
+// A Barrier has no effect on the state.
+// Line and Column are the position of the innermost statement the operation was emitted by,
+// e.g. a statement in the body of a gate, while Call is the outermost statement.
+// Condition is the branch on measured bits the operation is in, or nil if it is not conditional.
+type Op struct {
+	Kind        Kind
+	Theta       float64
+	Phi         float64
+	Lambda      float64
+	Phase       float64
+	Target      int
+	Controls    []int
+	NegControls []int
+	Qubits      []int
+	Clbit       int
+	Call        *Call
+	Line        int
+	Column      int
+	Condition   *Condition
+}
+
+// Condition is a branch of an if statement or the body of a while loop on measured bits,
+// whose operations are all in the circuit because the bits are not known at compile time.
+// Expr is the condition of the statement, e.g. "c==1", and Outer is the enclosing branch, or nil.
+// The branches of the same statement have the same Expr and Outer.
+type Condition struct {
+	Expr  string
+	Else  bool
+	Loop  bool
+	Outer *Condition
+}
+
+// String returns the condition with the enclosing conditions, e.g. "c[0] && !(c[1])".
+func (c *Condition) String() string {
+	s := c.Expr
+	if c.Else {
+		s = "!(" + s + ")"
+	}
+
+	if c.Outer != nil {
+		s = c.Outer.String() + " && " + s
+	}
+
+	return s
+}
+
+// Register is a named register. Index is the global index of each element.
+type Register struct {
+	Name  string
+	Index []int
+}
+
+type Circuit struct {
+	Qubits int
+	Clbits int
+	QRegs  []Register
+	CRegs  []Register
+	Ops    []Op
+}
+
+// Index returns the global qubit indices of each quantum register.
+func (c *Circuit) Index() [][]int {
+	index := make([][]int, len(c.QRegs))
+	for i, r := range c.QRegs {
+		index[i] = r.Index
+	}
+
+	return index
+}
+
+// Operands returns the qubits the operation acts on.
+func (op Op) Operands() []int {
+	switch op.Kind {
+	case Gate:
+		out := append([]int{}, op.Controls...)
+		out = append(out, op.NegControls...)
+		return append(out, op.Target)
+	case Measure, Reset:
+		return []int{op.Target}
+	case Barrier:
+		return op.Qubits
+	default:
+		return nil
+	}
+}
+
+// Matrix returns the 2x2 unitary exp(i*Phase)*U(Theta, Phi, Lambda).
+func (op Op) Matrix() [2][2]complex128 {
+	return U(op.Theta, op.Phi, op.Lambda, op.Phase)
+}
+
+// Inverse returns the inverse of the operation.
+func (op Op) Inverse() Op {
+	inv := op
+	switch op.Kind {
+	case Gate:
+		inv.Theta, inv.Phi, inv.Lambda, inv.Phase = -op.Theta, -op.Lambda, -op.Phi, -op.Phase
+	case GlobalPhase:
+		inv.Phase = -op.Phase
+	}
+
+	return inv
+}
+
+// U returns exp(i*gamma)*U(theta, phi, lambda).
+func U(theta, phi, lambda, gamma float64) [2][2]complex128 {
+	c, s := math.Cos(theta/2), math.Sin(theta/2)
+	g := cmplx.Exp(complex(0, gamma))
+	return [2][2]complex128{
+		{g * complex(c, 0), -g * cmplx.Exp(complex(0, lambda)) * complex(s, 0)},
+		{g * cmplx.Exp(complex(0, phi)) * complex(s, 0), g * cmplx.Exp(complex(0, phi+lambda)) * complex(c, 0)},
+	}
+}
+
+// ZYZ returns (theta, phi, lambda, gamma) such that m = exp(i*gamma)*U(theta, phi, lambda).
+func ZYZ(m [2][2]complex128) (theta, phi, lambda, gamma float64) {
+	a, b, c, d := m[0][0], m[0][1], m[1][0], m[1][1]
+	theta = 2 * math.Atan2(cmplx.Abs(c), cmplx.Abs(a))
+
+	switch {
+	case cmplx.Abs(c) < eps:
+		// diagonal
+		gamma = cmplx.Phase(a)
+		lambda = cmplx.Phase(d) - gamma
+	case cmplx.Abs(a) < eps:
+		// anti-diagonal
+		gamma = cmplx.Phase(-b)
+		phi = cmplx.Phase(c) - gamma
+	default:
+		gamma = cmplx.Phase(a)
+		phi = cmplx.Phase(c) - gamma
+		lambda = cmplx.Phase(-b) - gamma
+	}
+
+	return theta, phi, lambda, gamma
+}
+
+// Pow returns m^p using the eigendecomposition of the 2x2 unitary m.
+func Pow(m [2][2]complex128, p float64) [2][2]complex128 {
+	a, b, c, d := m[0][0], m[0][1], m[1][0], m[1][1]
+
+	// eigenvalues
+	tr, det := a+d, a*d-b*c
+	disc := cmplx.Sqrt(tr*tr - 4*det)
+	l1, l2 := (tr+disc)/2, (tr-disc)/2
+
+	pow := func(z complex128) complex128 {
+		return cmplx.Exp(complex(p, 0) * cmplx.Log(z))
+	}
+
+	if cmplx.Abs(l1-l2) < eps {
+		// m = l*I for a unitary with degenerate eigenvalues
+		return [2][2]complex128{{pow(l1), 0}, {0, pow(l1)}}
+	}
+
+	// m^p = f(l1)*(m - l2*I)/(l1 - l2) + f(l2)*(m - l1*I)/(l2 - l1)
+	p1, p2 := pow(l1)/(l1-l2), pow(l2)/(l2-l1)
+	return [2][2]complex128{
+		{p1*(a-l2) + p2*(a-l1), p1*b + p2*b},
+		{p1*c + p2*c, p1*(d-l2) + p2*(d-l1)},
+	}
+}
+
+// Apply applies the controlled 2x2 matrix m to the state vector of n qubits.
+// Qubit 0 is the most significant bit.
+func Apply(state []complex128, n int, m [2][2]complex128, target int, controls, negControls []int) {
+	var on, off int
+	for _, c := range controls {
+		on |= 1 << (n - 1 - c)
+	}
+
+	for _, c := range negControls {
+		off |= 1 << (n - 1 - c)
+	}
+
+	t := 1 << (n - 1 - target)
+	for i := range state {
+		if i&t != 0 || i&on != on || i&off != 0 {
+			continue
+		}
+
+		j := i | t
+		a0, a1 := state[i], state[j]
+		state[i] = m[0][0]*a0 + m[0][1]*a1
+		state[j] = m[1][0]*a0 + m[1][1]*a1
+	}
+}
+
+// Unitary returns the 2^n x 2^n unitary matrix of the circuit, row-major.
+// Column k is the state after the circuit is applied to |k>.
+func Unitary(c *Circuit) ([]complex128, error) {
+	for _, op := range c.Ops {
+		if op.Kind == Measure || op.Kind == Reset {
+			return nil, fmt.Errorf("%s: %w", op.Kind, ErrNotUnitary)
+		}
+	}
+
+	dim := 1 << c.Qubits
+	u := make([]complex128, dim*dim)
+	state := make([]complex128, dim)
+	for k := range dim {
+		clear(state)
+		state[k] = 1
+
+		for _, op := range c.Ops {
+			switch op.Kind {
+			case Gate:
+				Apply(state, c.Qubits, op.Matrix(), op.Target, op.Controls, op.NegControls)
+			case GlobalPhase:
+				p := cmplx.Exp(complex(0, op.Phase))
+				for i := range state {
+					state[i] *= p
+				}
+			}
+		}
+
+		for i, a := range state {
+			u[i*dim+k] = a
+		}
+	}
+
+	return u, nil
+}
+
+// Counterexample is an input state whose outputs of two circuits are different states.
+type Counterexample struct {
+	// Basis has the basis states of the input, which is their equal superposition.
+	Basis []int
+	// Fidelity is the state fidelity |<a|b>|^2 of the outputs, which is less than 1.
+	Fidelity float64
+}
+
+// Equivalent compares the unitaries of a and b up to global phase.
+// It returns the process fidelity |Tr(A^dagger B)|^2 / d^2, which is 1 if and only if they are equivalent,
+// and the input whose outputs have the lowest state fidelity, or nil if they are equivalent.
+// The input is a basis state, or the superposition of |0> and another basis state
+// if the outputs of every basis state differ only in phase.
+func Equivalent(a, b *Circuit) (fidelity float64, counterexample *Counterexample, err error) {
+	if a.Qubits != b.Qubits {
+		return 0, nil, fmt.Errorf("%d != %d: %w", a.Qubits, b.Qubits, ErrQubitsMismatch)
+	}
+
+	ua, err := Unitary(a)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ub, err := Unitary(b)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var tr complex128
+	for i := range ua {
+		tr += cmplx.Conj(ua[i]) * ub[i]
+	}
+
+	// inner returns <A e_j|B e_k>
+	dim := 1 << a.Qubits
+	inner := func(j, k int) complex128 {
+		var v complex128
+		for i := range dim {
+			v += cmplx.Conj(ua[i*dim+j]) * ub[i*dim+k]
+		}
+
+		return v
+	}
+
+	// basis states
+	for k := range dim {
+		v := inner(k, k)
+		f := real(v)*real(v) + imag(v)*imag(v)
+		if f < 1-eps && (counterexample == nil || f < counterexample.Fidelity-eps) {
+			counterexample = &Counterexample{Basis: []int{k}, Fidelity: f}
+		}
+	}
+
+	// superpositions of |0> and |k>, whose outputs differ if the phases of the basis states differ
+	if counterexample == nil {
+		for k := 1; k < dim; k++ {
+			v := (inner(0, 0) + inner(0, k) + inner(k, 0) + inner(k, k)) / 2
+			f := real(v)*real(v) + imag(v)*imag(v)
+			if f < 1-eps && (counterexample == nil || f < counterexample.Fidelity-eps) {
+				counterexample = &Counterexample{Basis: []int{0, k}, Fidelity: f}
+			}
+		}
+	}
+
+	abs := cmplx.Abs(tr) / float64(dim)
+	return abs * abs, counterexample, nil
+}
+
+// Name returns the name of the single-qubit gate up to global phase, e.g. "h" or "p" with the angle.
+// It returns "U" with theta, phi and lambda if the gate is not a standard gate.
+func Name(op Op) (string, []float64) {
+	s2 := complex(1/math.Sqrt2, 0)
+	for _, g := range []struct {
+		name string
+		m    [2][2]complex128
+	}{
+		{"x", [2][2]complex128{{0, 1}, {1, 0}}},
+		{"y", [2][2]complex128{{0, -1i}, {1i, 0}}},
+		{"z", [2][2]complex128{{1, 0}, {0, -1}}},
+		{"h", [2][2]complex128{{s2, s2}, {s2, -s2}}},
+		{"s", [2][2]complex128{{1, 0}, {0, 1i}}},
+		{"sdg", [2][2]complex128{{1, 0}, {0, -1i}}},
+		{"t", [2][2]complex128{{1, 0}, {0, cmplx.Exp(1i * math.Pi / 4)}}},
+		{"tdg", [2][2]complex128{{1, 0}, {0, cmplx.Exp(-1i * math.Pi / 4)}}},
+		{"id", [2][2]complex128{{1, 0}, {0, 1}}},
+	} {
+		if equalsUpToPhase(U(op.Theta, op.Phi, op.Lambda, 0), g.m) {
+			return g.name, nil
+		}
+	}
+
+	// diag(1, exp(i*lambda)) up to global phase
+	m := U(op.Theta, op.Phi, op.Lambda, 0)
+	if cmplx.Abs(m[0][1]) < 1e-9 && cmplx.Abs(m[1][0]) < 1e-9 {
+		return "p", []float64{cmplx.Phase(m[1][1] / m[0][0])}
+	}
+
+	return "U", []float64{op.Theta, op.Phi, op.Lambda}
+}
+
+// equalsUpToPhase returns true if a and b are equal up to global phase.
+func equalsUpToPhase(a, b [2][2]complex128) bool {
+	var phase complex128
+	for i := range 2 {
+		for j := range 2 {
+			if cmplx.Abs(b[i][j]) > 1e-9 {
+				phase = a[i][j] / b[i][j]
+			}
+		}
+	}
+
+	if math.Abs(cmplx.Abs(phase)-1) > 1e-9 {
+		return false
+	}
+
+	for i := range 2 {
+		for j := range 2 {
+			if cmplx.Abs(a[i][j]-phase*b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,427 @@
+package circuit_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+func equals(a, b [2][2]complex128) bool {
+	for i := range 2 {
+		for j := range 2 {
+			if cmplx.Abs(a[i][j]-b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func ExampleU() {
+	// T gate
+	t := circuit.U(0, 0, math.Pi/4, 0)
+	fmt.Printf("%.4f\n", t[0])
+	fmt.Printf("%.4f\n", t[1])
+
+	// Output:
+	// [(1.0000+0.0000i) (0.0000-0.0000i)]
+	// [(0.0000+0.0000i) (0.7071+0.7071i)]
+}
+
+func ExampleApply() {
+	// |00> -> h q[0] -> cx q[0], q[1]
+	state := []complex128{1, 0, 0, 0}
+	circuit.Apply(state, 2, circuit.U(math.Pi/2, 0, math.Pi, 0), 0, nil, nil)
+	circuit.Apply(state, 2, circuit.U(math.Pi, 0, math.Pi, 0), 1, []int{0}, nil)
+
+	for _, a := range state {
+		fmt.Printf("%.4f\n", real(a))
+	}
+
+	// Output:
+	// 0.7071
+	// 0.0000
+	// 0.0000
+	// 0.7071
+}
+
+func ExampleApply_negctrl() {
+	// negctrl @ x q[0], q[1]
+	state := []complex128{1, 0, 0, 0}
+	circuit.Apply(state, 2, circuit.U(math.Pi, 0, math.Pi, 0), 1, nil, []int{0})
+
+	for _, a := range state {
+		fmt.Printf("%.1f\n", cmplx.Abs(a))
+	}
+
+	// Output:
+	// 0.0
+	// 1.0
+	// 0.0
+	// 0.0
+}
+
+func ExampleUnitary() {
+	// cx
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Ops: []circuit.Op{
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, Controls: []int{0}, Clbit: -1},
+		},
+	}
+
+	u, err := circuit.Unitary(c)
+	if err != nil {
+		panic(err)
+	}
+
+	for i := range 4 {
+		row := make([]float64, 4)
+		for j := range row {
+			row[j] = cmplx.Abs(u[i*4+j])
+		}
+
+		fmt.Printf("%.1f\n", row)
+	}
+
+	// Output:
+	// [1.0 0.0 0.0 0.0]
+	// [0.0 1.0 0.0 0.0]
+	// [0.0 0.0 0.0 1.0]
+	// [0.0 0.0 1.0 0.0]
+}
+
+func TestUnitary(t *testing.T) {
+	cases := []struct {
+		ops  []circuit.Op
+		want []complex128
+		err  error
+	}{
+		{
+			[]circuit.Op{{Kind: circuit.Gate, Theta: math.Pi / 2, Lambda: math.Pi, Clbit: -1}},
+			[]complex128{complex(1/math.Sqrt2, 0), complex(1/math.Sqrt2, 0), complex(1/math.Sqrt2, 0), complex(-1/math.Sqrt2, 0)},
+			nil,
+		},
+		{
+			[]circuit.Op{{Kind: circuit.GlobalPhase, Phase: math.Pi / 2, Clbit: -1}, {Kind: circuit.Barrier, Qubits: []int{0}, Clbit: -1}},
+			[]complex128{1i, 0, 0, 1i},
+			nil,
+		},
+		{
+			[]circuit.Op{{Kind: circuit.Measure, Clbit: -1}},
+			nil,
+			circuit.ErrNotUnitary,
+		},
+		{
+			[]circuit.Op{{Kind: circuit.Reset, Clbit: -1}},
+			nil,
+			circuit.ErrNotUnitary,
+		},
+	}
+
+	for _, c := range cases {
+		got, err := circuit.Unitary(&circuit.Circuit{Qubits: 1, Ops: c.ops})
+		if !errors.Is(err, c.err) {
+			t.Fatalf("got=%v, want=%v", err, c.err)
+		}
+
+		for i := range c.want {
+			if cmplx.Abs(got[i]-c.want[i]) > 1e-12 {
+				t.Errorf("got=%v, want=%v", got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	x := circuit.Op{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Clbit: -1}
+	z := circuit.Op{Kind: circuit.Gate, Lambda: math.Pi, Clbit: -1}
+	h := circuit.Op{Kind: circuit.Gate, Theta: math.Pi / 2, Lambda: math.Pi, Clbit: -1}
+	cx01 := circuit.Op{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, Controls: []int{0}, Clbit: -1}
+	cx10 := circuit.Op{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 0, Controls: []int{1}, Clbit: -1}
+
+	cases := []struct {
+		a, b           *circuit.Circuit
+		fidelity       float64
+		counterexample *circuit.Counterexample
+		err            error
+	}{
+		{
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{h, z, h}},
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{x}},
+			1, nil, nil,
+		},
+		{
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{x, {Kind: circuit.GlobalPhase, Phase: math.Pi / 3}}},
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{x}},
+			1, nil, nil,
+		},
+		{
+			// the outputs of |0> and |1> differ only in phase
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{z}},
+			&circuit.Circuit{Qubits: 1},
+			0, &circuit.Counterexample{Basis: []int{0, 1}, Fidelity: 0}, nil,
+		},
+		{
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{h}},
+			&circuit.Circuit{Qubits: 1},
+			0, &circuit.Counterexample{Basis: []int{0}, Fidelity: 0.5}, nil,
+		},
+		{
+			&circuit.Circuit{Qubits: 2, Ops: []circuit.Op{cx01}},
+			&circuit.Circuit{Qubits: 2, Ops: []circuit.Op{cx10}},
+			1.0 / 16, &circuit.Counterexample{Basis: []int{1}, Fidelity: 0}, nil,
+		},
+		{
+			&circuit.Circuit{Qubits: 1},
+			&circuit.Circuit{Qubits: 2},
+			0, nil, circuit.ErrQubitsMismatch,
+		},
+		{
+			&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{{Kind: circuit.Reset}}},
+			&circuit.Circuit{Qubits: 1},
+			0, nil, circuit.ErrNotUnitary,
+		},
+	}
+
+	for _, c := range cases {
+		fidelity, counterexample, err := circuit.Equivalent(c.a, c.b)
+		if !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+
+		if math.Abs(fidelity-c.fidelity) > 1e-12 {
+			t.Errorf("got=%v, want=%v", fidelity, c.fidelity)
+		}
+
+		if (counterexample == nil) != (c.counterexample == nil) {
+			t.Errorf("got=%v, want=%v", counterexample, c.counterexample)
+			continue
+		}
+
+		if counterexample != nil && (!slices.Equal(counterexample.Basis, c.counterexample.Basis) || math.Abs(counterexample.Fidelity-c.counterexample.Fidelity) > 1e-12) {
+			t.Errorf("got=%v, want=%v", counterexample, c.counterexample)
+		}
+	}
+}
+
+func TestZYZ(t *testing.T) {
+	cases := [][4]float64{
+		{0, 0, 0, 0},
+		{math.Pi, 0, math.Pi, 0},
+		{math.Pi / 2, 0, math.Pi, 0},
+		{0, 0, math.Pi / 4, 0.3},
+		{1.2, 0.4, -0.7, 1.1},
+		{math.Pi, 0.5, 0, -0.2},
+	}
+
+	for _, c := range cases {
+		m := circuit.U(c[0], c[1], c[2], c[3])
+		theta, phi, lambda, gamma := circuit.ZYZ(m)
+		if got := circuit.U(theta, phi, lambda, gamma); !equals(got, m) {
+			t.Errorf("%v: got=%v, want=%v", c, got, m)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	cases := []struct {
+		m    [2][2]complex128
+		p    float64
+		want [2][2]complex128
+	}{
+		{
+			// sqrt(Z) = S
+			m:    circuit.U(0, 0, math.Pi, 0),
+			p:    0.5,
+			want: circuit.U(0, 0, math.Pi/2, 0),
+		},
+		{
+			// X^2 = I
+			m:    circuit.U(math.Pi, 0, math.Pi, 0),
+			p:    2,
+			want: circuit.U(0, 0, 0, 0),
+		},
+		{
+			// T^-1 = Tdg
+			m:    circuit.U(0, 0, math.Pi/4, 0),
+			p:    -1,
+			want: circuit.U(0, 0, -math.Pi/4, 0),
+		},
+		{
+			// I^0.3 = I
+			m:    circuit.U(0, 0, 0, 0),
+			p:    0.3,
+			want: circuit.U(0, 0, 0, 0),
+		},
+	}
+
+	for _, c := range cases {
+		if got := circuit.Pow(c.m, c.p); !equals(got, c.want) {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
+
+func TestOp_Inverse(t *testing.T) {
+	op := circuit.Op{
+		Kind:   circuit.Gate,
+		Theta:  1.2,
+		Phi:    0.4,
+		Lambda: -0.7,
+		Phase:  0.3,
+	}
+
+	m, inv := op.Matrix(), op.Inverse().Matrix()
+
+	var got [2][2]complex128
+	for i := range 2 {
+		for j := range 2 {
+			for k := range 2 {
+				got[i][j] += m[i][k] * inv[k][j]
+			}
+		}
+	}
+
+	if !equals(got, circuit.U(0, 0, 0, 0)) {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func ExampleCondition_String() {
+	outer := &circuit.Condition{Expr: "c[0]"}
+	fmt.Println(outer)
+	fmt.Println(&circuit.Condition{Expr: "c[1]", Else: true, Outer: outer})
+
+	// Output:
+	// c[0]
+	// c[0] && !(c[1])
+}
+
+func TestName(t *testing.T) {
+	cases := []struct {
+		theta, phi, lambda float64
+		want               string
+	}{
+		{math.Pi, 0, math.Pi, "x []"},
+		{-math.Pi, -math.Pi, 0, "x []"},
+		{math.Pi, math.Pi / 2, math.Pi / 2, "y []"},
+		{0, 0, math.Pi, "z []"},
+		{math.Pi / 2, 0, math.Pi, "h []"},
+		{0, 0, math.Pi / 2, "s []"},
+		{0, -math.Pi / 2, 0, "sdg []"},
+		{0, 0, math.Pi / 4, "t []"},
+		{0, 0, -math.Pi / 4, "tdg []"},
+		{0, 0, 0, "id []"},
+		{0, 0, 0.5, "p [0.5]"},
+		{0.1, 0.2, 0.3, "U [0.1 0.2 0.3]"},
+	}
+
+	for _, c := range cases {
+		name, params := circuit.Name(circuit.Op{Theta: c.theta, Phi: c.phi, Lambda: c.lambda})
+		if got := fmt.Sprint(name, " ", params); got != c.want {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
+
+func ExampleQASM() {
+	c := &circuit.Circuit{
+		Qubits: 3,
+		Clbits: 1,
+		QRegs:  []circuit.Register{{Name: "q", Index: []int{0, 1}}, {Name: "r", Index: []int{2}}},
+		CRegs:  []circuit.Register{{Name: "c", Index: []int{0}}},
+		Ops: []circuit.Op{
+			{Kind: circuit.Gate, Theta: math.Pi / 2, Lambda: math.Pi, Target: 0, Clbit: -1},
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 2, Controls: []int{1}, NegControls: []int{0}, Clbit: -1},
+			{Kind: circuit.Gate, Lambda: 0.25, Phase: -3 * math.Pi / 4, Target: 1, Controls: []int{0, 2}, Clbit: -1},
+			{Kind: circuit.GlobalPhase, Phase: math.Pi, Clbit: -1},
+			{Kind: circuit.Barrier, Qubits: []int{0, 1, 2}, Clbit: -1},
+			{Kind: circuit.Measure, Target: 2, Clbit: 0},
+			{Kind: circuit.Measure, Target: 0, Clbit: -1},
+			{Kind: circuit.Reset, Target: 1, Clbit: -1},
+		},
+	}
+
+	fmt.Print(circuit.QASM(c))
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit[2] q;
+	// qubit r;
+	// bit c;
+	//
+	// U(pi/2, 0, pi) q[0];
+	// negctrl @ ctrl @ U(pi, 0, pi) q[0], q[1], r;
+	// ctrl(2) @ U(0, 0, 0.25) q[0], r, q[1];
+	// ctrl(2) @ gphase(-3*pi/4) q[0], r;
+	// gphase(pi);
+	// barrier q[0], q[1], r;
+	// c = measure r;
+	// measure q[0];
+	// reset q[1];
+}
+
+func ExampleQASM_condition() {
+	outer := &circuit.Condition{Expr: "c[0]"}
+	inner := &circuit.Condition{Expr: "c[1]", Loop: true, Outer: outer}
+	c := &circuit.Circuit{
+		Qubits: 1,
+		Clbits: 2,
+		Ops: []circuit.Op{
+			{Kind: circuit.Measure, Target: 0, Clbit: 0},
+			{Kind: circuit.Reset, Target: 0, Clbit: -1, Condition: outer},
+			{Kind: circuit.Measure, Target: 0, Clbit: 1, Condition: inner},
+			{Kind: circuit.Reset, Target: 0, Clbit: -1, Condition: &circuit.Condition{Expr: "c[0]", Else: true}},
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 0, Clbit: -1},
+		},
+	}
+
+	fmt.Print(circuit.QASM(c))
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit q;
+	// bit[2] c;
+	//
+	// c[0] = measure q;
+	// if (c[0]) {
+	//     reset q;
+	//     while (c[1]) {
+	//         c[1] = measure q;
+	//     }
+	// } else {
+	//     reset q;
+	// }
+	// U(pi, 0, pi) q;
+}
+
+func TestAngle(t *testing.T) {
+	cases := []struct {
+		v    float64
+		want string
+	}{
+		{0, "0"},
+		{math.Pi, "pi"},
+		{-math.Pi / 2, "-pi/2"},
+		{3 * math.Pi / 4, "3*pi/4"},
+		{math.Pi / 16, "pi/16"},
+		{0.1, "0.1"},
+		{math.Pi/2 + 1e-15, "1.5707963267948977"},
+	}
+
+	for _, c := range cases {
+		if got := circuit.Angle(c.v); got != c.want {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package circuit
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const indent = "    "
+
+// QASM returns the circuit as an OpenQASM 3 program of U, gphase, measure, reset and barrier statements.
+// The phase of a controlled gate is written as a controlled gphase on its controls.
+// The operations in a branch on measured bits are written in the if or while statement of their condition.
+func QASM(c *Circuit) string {
+	return Format(c, "", nil)
+}
+
+// Format returns the circuit as an OpenQASM 3 program like QASM, with the definitions after the version.
+// Each gate is written as the statement returned by gate, e.g. "rz(pi/2) q[0];", if it is not empty.
+// The operands are the names of the controls, the negated controls and the target, e.g. "q[0], q[1]".
+func Format(c *Circuit, defs string, gate func(op Op, operands string) string) string {
+	qregs := c.QRegs
+	if len(qregs) == 0 && c.Qubits > 0 {
+		qregs = []Register{{Name: "q", Index: seq(c.Qubits)}}
+	}
+
+	cregs := c.CRegs
+	if len(cregs) == 0 && c.Clbits > 0 {
+		cregs = []Register{{Name: "c", Index: seq(c.Clbits)}}
+	}
+
+	qubits, clbits := names(qregs, c.Qubits), names(cregs, c.Clbits)
+
+	var sb strings.Builder
+	sb.WriteString("OPENQASM 3.0;\n\n")
+	if defs != "" {
+		sb.WriteString(defs)
+		sb.WriteByte('\n')
+	}
+
+	for _, r := range qregs {
+		sb.WriteString(declaration("qubit", r))
+	}
+
+	for _, r := range cregs {
+		sb.WriteString(declaration("bit", r))
+	}
+
+	if len(qregs)+len(cregs) > 0 {
+		sb.WriteByte('\n')
+	}
+
+	operands := func(q ...int) string {
+		out := make([]string, len(q))
+		for i, v := range q {
+			out[i] = qubits[v]
+		}
+
+		return strings.Join(out, ", ")
+	}
+
+	statement := func(sb *strings.Builder, op Op) {
+		switch op.Kind {
+		case Gate:
+			if gate != nil {
+				if stmt := gate(op, operands(op.Operands()...)); stmt != "" {
+					sb.WriteString(stmt + "\n")
+					return
+				}
+			}
+
+			mods := modifiers(len(op.Controls), len(op.NegControls))
+			ctrl := append(append([]int{}, op.NegControls...), op.Controls...)
+			fmt.Fprintf(sb, "%sU(%s, %s, %s) %s;\n", mods, Angle(op.Theta), Angle(op.Phi), Angle(op.Lambda), operands(append(ctrl, op.Target)...))
+
+			if math.Abs(op.Phase) < eps {
+				return
+			}
+
+			if len(ctrl) == 0 {
+				fmt.Fprintf(sb, "gphase(%s);\n", Angle(op.Phase))
+				return
+			}
+
+			// the phase on the subspace selected by the controls
+			fmt.Fprintf(sb, "%sgphase(%s) %s;\n", mods, Angle(op.Phase), operands(ctrl...))
+		case GlobalPhase:
+			fmt.Fprintf(sb, "gphase(%s);\n", Angle(op.Phase))
+		case Measure:
+			if op.Clbit < 0 {
+				fmt.Fprintf(sb, "measure %s;\n", qubits[op.Target])
+				return
+			}
+
+			fmt.Fprintf(sb, "%s = measure %s;\n", clbits[op.Clbit], qubits[op.Target])
+		case Reset:
+			fmt.Fprintf(sb, "reset %s;\n", qubits[op.Target])
+		case Barrier:
+			if len(op.Qubits) == 0 {
+				sb.WriteString("barrier;\n")
+				return
+			}
+
+			fmt.Fprintf(sb, "barrier %s;\n", operands(op.Qubits...))
+		}
+	}
+
+	var open []*Condition
+	for _, op := range c.Ops {
+		open = branch(&sb, open, op.Condition)
+
+		var stmt strings.Builder
+		statement(&stmt, op)
+		for line := range strings.Lines(stmt.String()) {
+			sb.WriteString(strings.Repeat(indent, len(open)) + line)
+		}
+	}
+
+	branch(&sb, open, nil)
+	return sb.String()
+}
+
+// Angle returns the angle as a multiple of pi if it is exact, e.g. "pi/2" or "-3*pi/4".
+// Otherwise it returns the shortest representation of the float.
+func Angle(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+
+	for _, d := range []int{1, 2, 3, 4, 6, 8, 16} {
+		n := v * float64(d) / math.Pi
+		r := math.Round(n)
+		if r == 0 || math.Abs(n-r) > eps || r*math.Pi/float64(d) != v {
+			continue
+		}
+
+		var s string
+		switch r {
+		case 1:
+			s = "pi"
+		case -1:
+			s = "-pi"
+		default:
+			s = fmt.Sprintf("%d*pi", int(r))
+		}
+
+		if d > 1 {
+			s = fmt.Sprintf("%s/%d", s, d)
+		}
+
+		return s
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// branch closes the blocks of the open conditions that do not enclose cond, and opens the blocks of cond.
+// The else branch of the if statement closed last continues it, e.g. "} else {".
+func branch(sb *strings.Builder, open []*Condition, cond *Condition) []*Condition {
+	var chain []*Condition
+	for c := cond; c != nil; c = c.Outer {
+		chain = append(chain, c)
+	}
+
+	slices.Reverse(chain)
+
+	var k int
+	for k < len(open) && k < len(chain) && open[k] == chain[k] {
+		k++
+	}
+
+	for len(open) > k {
+		last := open[len(open)-1]
+		open = open[:len(open)-1]
+
+		pad := strings.Repeat(indent, len(open))
+		if len(open) == k && k < len(chain) && sibling(last, chain[k]) {
+			sb.WriteString(pad + "} else {\n")
+			open = append(open, chain[k])
+			break
+		}
+
+		sb.WriteString(pad + "}\n")
+	}
+
+	for _, c := range chain[len(open):] {
+		pad := strings.Repeat(indent, len(open))
+		switch {
+		case c.Loop:
+			fmt.Fprintf(sb, "%swhile (%s) {\n", pad, c.Expr)
+		case c.Else:
+			fmt.Fprintf(sb, "%sif (!(%s)) {\n", pad, c.Expr)
+		default:
+			fmt.Fprintf(sb, "%sif (%s) {\n", pad, c.Expr)
+		}
+
+		open = append(open, c)
+	}
+
+	return open
+}
+
+// sibling returns true if b is the else branch of the if branch a.
+func sibling(a, b *Condition) bool {
+	return !a.Else && !a.Loop && b.Else && a.Expr == b.Expr && a.Outer == b.Outer
+}
+
+func modifiers(ctrl, negctrl int) string {
+	var sb strings.Builder
+	switch {
+	case negctrl == 1:
+		sb.WriteString("negctrl @ ")
+	case negctrl > 1:
+		fmt.Fprintf(&sb, "negctrl(%d) @ ", negctrl)
+	}
+
+	switch {
+	case ctrl == 1:
+		sb.WriteString("ctrl @ ")
+	case ctrl > 1:
+		fmt.Fprintf(&sb, "ctrl(%d) @ ", ctrl)
+	}
+
+	return sb.String()
+}
+
+func declaration(kind string, r Register) string {
+	if len(r.Index) == 1 {
+		return fmt.Sprintf("%s %s;\n", kind, r.Name)
+	}
+
+	return fmt.Sprintf("%s[%d] %s;\n", kind, len(r.Index), r.Name)
+}
+
+// names returns the name of each element of the registers, e.g. "q[0]", or "q" for a single element register.
+func names(regs []Register, n int) []string {
+	out := make([]string, n)
+	for _, r := range regs {
+		for i, k := range r.Index {
+			out[k] = fmt.Sprintf("%s[%d]", r.Name, i)
+			if len(r.Index) == 1 {
+				out[k] = r.Name
+			}
+		}
+	}
+
+	return out
+}
+
+func seq(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+
+	return out
+}
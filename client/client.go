@@ -3,16 +3,57 @@ package client
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
 	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 	"github.com/itsubaki/quasar/gen/quasar/v1/quasarv1connect"
+	"github.com/itsubaki/quasar/packed"
 )
 
 type States struct {
-	States []State `json:"states"`
+	States          []State              `json:"states"`
+	Counts          map[string]int32     `json:"counts,omitempty"`
+	Classical       map[string]Classical `json:"classical,omitempty"`
+	Sweep           []Result             `json:"sweep,omitempty"`
+	Expectations    []float64            `json:"expectations,omitempty"`
+	Amplitudes      []Amplitude          `json:"amplitudes,omitempty"`
+	Diagonal        []float64            `json:"diagonal,omitempty"`
+	DensityMatrix   [][]Amplitude        `json:"density_matrix,omitempty"`
+	Stabilizers     []string             `json:"stabilizers,omitempty"`
+	TruncationError float64              `json:"truncation_error,omitempty"`
+	BondDimension   int32                `json:"bond_dimension,omitempty"`
+	Analysis        *Analysis            `json:"analysis,omitempty"`
+}
+
+type Result struct {
+	Inputs          map[string]float64   `json:"inputs"`
+	States          []State              `json:"states"`
+	Counts          map[string]int32     `json:"counts,omitempty"`
+	Classical       map[string]Classical `json:"classical,omitempty"`
+	Expectations    []float64            `json:"expectations,omitempty"`
+	Amplitudes      []Amplitude          `json:"amplitudes,omitempty"`
+	Diagonal        []float64            `json:"diagonal,omitempty"`
+	DensityMatrix   [][]Amplitude        `json:"density_matrix,omitempty"`
+	Stabilizers     []string             `json:"stabilizers,omitempty"`
+	TruncationError float64              `json:"truncation_error,omitempty"`
+	BondDimension   int32                `json:"bond_dimension,omitempty"`
+	Analysis        *Analysis            `json:"analysis,omitempty"`
+}
+
+type Analysis struct {
+	Bloch                []Bloch       `json:"bloch"`
+	ReducedDensityMatrix [][]Amplitude `json:"reduced_density_matrix,omitempty"`
+	Entropy              *float64      `json:"entropy,omitempty"`
+}
+
+type Bloch struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
 }
 
 type State struct {
@@ -26,6 +67,133 @@ type Amplitude struct {
 	Imag float64 `json:"imag"`
 }
 
+type Classical struct {
+	Bits  []int32  `json:"bits,omitempty"`
+	Int   *int64   `json:"int,omitempty"`
+	Float *float64 `json:"float,omitempty"`
+	Angle *float64 `json:"angle,omitempty"`
+	Bool  *bool    `json:"bool,omitempty"`
+}
+
+type Noise struct {
+	Depolarizing     float64 `json:"depolarizing,omitempty"`
+	AmplitudeDamping float64 `json:"amplitude_damping,omitempty"`
+	PhaseDamping     float64 `json:"phase_damping,omitempty"`
+	BitFlip          float64 `json:"bit_flip,omitempty"`
+	ReadoutError     float64 `json:"readout_error,omitempty"`
+}
+
+type Snapshot struct {
+	Step   int32     `json:"step"`
+	Line   int32     `json:"line"`
+	Column int32     `json:"column"`
+	Gate   string    `json:"gate"`
+	Params []float64 `json:"params,omitempty"`
+	Qubits []int32   `json:"qubits,omitempty"`
+	States []State   `json:"states"`
+}
+
+// StreamFilter limits the snapshots of SimulateStream.
+// Zero MaxSnapshots uses the server default, and empty Lines sends every statement.
+type StreamFilter struct {
+	MaxSnapshots int32
+	Lines        []int32
+}
+
+type Unitary struct {
+	Qubits int32         `json:"qubits"`
+	Matrix [][]Amplitude `json:"matrix"`
+}
+
+// Program is the code, or the shared snippet if Code is empty.
+type Program struct {
+	Code      string             `json:"code,omitempty"`
+	SnippetID string             `json:"snippet_id,omitempty"`
+	Inputs    map[string]float64 `json:"inputs,omitempty"`
+	Includes  map[string]string  `json:"includes,omitempty"`
+	Imports   []string           `json:"imports,omitempty"`
+}
+
+func program(p Program) *quasarv1.Program {
+	return &quasarv1.Program{
+		Code:      p.Code,
+		SnippetId: p.SnippetID,
+		Inputs:    p.Inputs,
+		Includes:  p.Includes,
+		Imports:   p.Imports,
+	}
+}
+
+type Equivalence struct {
+	Equivalent             bool     `json:"equivalent"`
+	Qubits                 int32    `json:"qubits"`
+	Fidelity               float64  `json:"fidelity"`
+	Counterexample         *string  `json:"counterexample,omitempty"`
+	CounterexampleFidelity *float64 `json:"counterexample_fidelity,omitempty"`
+}
+
+type Comparison struct {
+	Qubits        int32        `json:"qubits"`
+	Fidelity      float64      `json:"fidelity"`
+	TraceDistance float64      `json:"trace_distance"`
+	Differences   []Difference `json:"differences"`
+}
+
+type Difference struct {
+	BinaryString []string `json:"binary_string"`
+	A            float64  `json:"a"`
+	B            float64  `json:"b"`
+	Diff         float64  `json:"diff"`
+}
+
+type Metrics struct {
+	Qubits        int32            `json:"qubits"`
+	Depth         int32            `json:"depth"`
+	Gates         map[string]int32 `json:"gates"`
+	TwoQubitGates int32            `json:"two_qubit_gates"`
+	TCount        int32            `json:"t_count"`
+	Clifford      bool             `json:"clifford"`
+}
+
+type Optimization struct {
+	Code   string   `json:"code"`
+	Before *Metrics `json:"before"`
+	After  *Metrics `json:"after"`
+}
+
+type Transpilation struct {
+	Code    string   `json:"code"`
+	Metrics *Metrics `json:"metrics"`
+	Routing *Routing `json:"routing,omitempty"`
+}
+
+type Session struct {
+	ID        string               `json:"id"`
+	Step      int32                `json:"step"`
+	Line      *int32               `json:"line,omitempty"`
+	Column    *int32               `json:"column,omitempty"`
+	Done      bool                 `json:"done"`
+	States    []State              `json:"states"`
+	Classical map[string]Classical `json:"classical,omitempty"`
+	ExpiresAt time.Time            `json:"expires_at"`
+}
+
+type Register struct {
+	Name      string     `json:"name"`
+	States    []State    `json:"states,omitempty"`
+	Classical *Classical `json:"classical,omitempty"`
+}
+
+type Job struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Result     *States    `json:"result,omitempty"`
+	Error      *string    `json:"error,omitempty"`
+}
+
 type Snippet struct {
 	ID        string    `json:"id"`
 	Code      string    `json:"code"`
@@ -33,10 +201,131 @@ type Snippet struct {
 }
 
 type ValidationResult struct {
-	Valid   bool    `json:"valid"`
-	Line    *int32  `json:"line,omitempty"`
-	Column  *int32  `json:"column,omitempty"`
-	Message *string `json:"message,omitempty"`
+	Valid   bool     `json:"valid"`
+	Line    *int32   `json:"line,omitempty"`
+	Column  *int32   `json:"column,omitempty"`
+	Message *string  `json:"message,omitempty"`
+	Routing *Routing `json:"routing,omitempty"`
+	Version string   `json:"version,omitempty"`
+}
+
+type Routing struct {
+	Layout      []int32 `json:"layout"`
+	FinalLayout []int32 `json:"final_layout"`
+	Swaps       int32   `json:"swaps"`
+}
+
+type SimulateOption func(*quasarv1.SimulateRequest)
+
+func WithShots(shots int32) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Shots = &shots
+	}
+}
+
+func WithSeed(seed uint64) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Seed = &seed
+	}
+}
+
+func WithInputs(inputs map[string]float64) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Inputs = inputs
+	}
+}
+
+// WithIncludes sets the contents of the files to include by name, e.g. {"mygates.inc": "gate ..."}.
+func WithIncludes(files map[string]string) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Includes = files
+	}
+}
+
+// WithImports includes the gate and def definitions of the shared snippets by ID.
+func WithImports(ids ...string) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Imports = ids
+	}
+}
+
+func WithSweep(sweep ...map[string]float64) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		for _, inputs := range sweep {
+			req.Sweep = append(req.Sweep, &quasarv1.SimulateRequest_Inputs{
+				Values: inputs,
+			})
+		}
+	}
+}
+
+func WithObservables(observables ...string) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Observables = append(req.Observables, observables...)
+	}
+}
+
+func WithPrecision(precision int32) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Precision = &precision
+	}
+}
+
+func WithEpsilon(epsilon float64) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Epsilon = &epsilon
+	}
+}
+
+func WithDense() SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Dense = true
+	}
+}
+
+func WithPacked() SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Packed = true
+	}
+}
+
+func WithBackend(backend quasarv1.Backend) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Backend = backend
+	}
+}
+
+// WithMPS selects the matrix product state backend.
+func WithMPS(maxBondDimension int32, truncationThreshold float64) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Backend = quasarv1.Backend_BACKEND_MPS
+		req.MaxBondDimension = &maxBondDimension
+		req.TruncationThreshold = &truncationThreshold
+	}
+}
+
+// WithAnalysis requests the Bloch vector of each qubit,
+// and the reduced density matrix and the entanglement entropy of the subsystem if it is not empty.
+func WithAnalysis(subsystem ...int32) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Analysis = &quasarv1.SimulateRequest_Analysis{
+			Subsystem: subsystem,
+		}
+	}
+}
+
+// WithNoise sets the noise model and selects the density matrix backend.
+func WithNoise(noise Noise) SimulateOption {
+	return func(req *quasarv1.SimulateRequest) {
+		req.Backend = quasarv1.Backend_BACKEND_DENSITY_MATRIX
+		req.Noise = &quasarv1.NoiseModel{
+			Depolarizing:     noise.Depolarizing,
+			AmplitudeDamping: noise.AmplitudeDamping,
+			PhaseDamping:     noise.PhaseDamping,
+			BitFlip:          noise.BitFlip,
+			ReadoutError:     noise.ReadoutError,
+		}
+	}
 }
 
 type Client struct {
@@ -52,31 +341,400 @@ func New(targetURL string, client *http.Client) *Client {
 	}
 }
 
-func (c *Client) Simulate(ctx context.Context, code string) (*States, error) {
-	resp, err := c.quasarClient.Simulate(ctx, connect.NewRequest(&quasarv1.SimulateRequest{
+func (c *Client) Simulate(ctx context.Context, code string, opts ...SimulateOption) (*States, error) {
+	req := &quasarv1.SimulateRequest{
 		Code: code,
-	}))
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.quasarClient.Simulate(ctx, connect.NewRequest(req))
 	if err != nil {
 		return nil, fmt.Errorf("simulate: %w", err)
 	}
 
-	states := make([]State, len(resp.Msg.States))
-	for i, s := range resp.Msg.States {
-		states[i] = State{
-			Probability: s.Probability,
-			Amplitude: Amplitude{
-				Real: s.Amplitude.Real,
-				Imag: s.Amplitude.Imag,
-			},
-			BinaryString: s.BinaryString,
+	return toResults(resp.Msg)
+}
+
+// SimulateStream returns the state after each executed statement.
+func (c *Client) SimulateStream(ctx context.Context, code string, filter StreamFilter, opts ...SimulateOption) iter.Seq2[*Snapshot, error] {
+	return func(yield func(*Snapshot, error) bool) {
+		req := &quasarv1.SimulateRequest{
+			Code: code,
+		}
+
+		for _, opt := range opts {
+			opt(req)
+		}
+
+		sreq := &quasarv1.SimulateStreamRequest{
+			Request: req,
+			Lines:   filter.Lines,
+		}
+
+		if filter.MaxSnapshots > 0 {
+			sreq.MaxSnapshots = &filter.MaxSnapshots
+		}
+
+		stream, err := c.quasarClient.SimulateStream(ctx, connect.NewRequest(sreq))
+		if err != nil {
+			yield(nil, fmt.Errorf("simulate stream: %w", err))
+			return
+		}
+		defer stream.Close()
+
+		for stream.Receive() {
+			msg := stream.Msg()
+			if !yield(&Snapshot{
+				Step:   msg.Step,
+				Line:   msg.Line,
+				Column: msg.Column,
+				Gate:   msg.Gate,
+				Params: msg.Params,
+				Qubits: msg.Qubits,
+				States: toStates(msg.States),
+			}, nil) {
+				return
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			yield(nil, fmt.Errorf("simulate stream: %w", err))
 		}
 	}
+}
 
-	return &States{
-		States: states,
+// Unitary returns the unitary matrix of the code, or of the user-defined gate if gate is not empty.
+func (c *Client) Unitary(ctx context.Context, code, gate string, params ...float64) (*Unitary, error) {
+	req := &quasarv1.UnitaryRequest{
+		Code:   code,
+		Params: params,
+	}
+
+	if gate != "" {
+		req.Gate = &gate
+	}
+
+	resp, err := c.quasarClient.Unitary(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("unitary: %w", err)
+	}
+
+	matrix, err := toMatrix(resp.Msg.Matrix)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return &Unitary{
+		Qubits: resp.Msg.Qubits,
+		Matrix: matrix,
+	}, nil
+}
+
+// Equivalent reports whether a and b implement the same unitary up to global phase.
+func (c *Client) Equivalent(ctx context.Context, a, b Program) (*Equivalence, error) {
+	resp, err := c.quasarClient.Equivalent(ctx, connect.NewRequest(&quasarv1.EquivalentRequest{
+		A: program(a),
+		B: program(b),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("equivalent: %w", err)
+	}
+
+	return &Equivalence{
+		Equivalent:             resp.Msg.Equivalent,
+		Qubits:                 resp.Msg.Qubits,
+		Fidelity:               resp.Msg.Fidelity,
+		Counterexample:         resp.Msg.Counterexample,
+		CounterexampleFidelity: resp.Msg.CounterexampleFidelity,
+	}, nil
+}
+
+// Compare compares the final states of a and b.
+func (c *Client) Compare(ctx context.Context, a, b Program) (*Comparison, error) {
+	return c.compare(ctx, &quasarv1.CompareRequest{
+		A:     program(a),
+		Other: &quasarv1.CompareRequest_B{B: program(b)},
+	})
+}
+
+// CompareState compares the final state of a with the target state vector.
+func (c *Client) CompareState(ctx context.Context, a Program, target []complex128) (*Comparison, error) {
+	return c.compare(ctx, &quasarv1.CompareRequest{
+		A:     program(a),
+		Other: &quasarv1.CompareRequest_Target{Target: packed.Encode(target)},
+	})
+}
+
+func (c *Client) compare(ctx context.Context, req *quasarv1.CompareRequest) (*Comparison, error) {
+	resp, err := c.quasarClient.Compare(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("compare: %w", err)
+	}
+
+	diffs := make([]Difference, len(resp.Msg.Differences))
+	for i, d := range resp.Msg.Differences {
+		diffs[i] = Difference{
+			BinaryString: d.BinaryString,
+			A:            d.A,
+			B:            d.B,
+			Diff:         d.Diff,
+		}
+	}
+
+	return &Comparison{
+		Qubits:        resp.Msg.Qubits,
+		Fidelity:      resp.Msg.Fidelity,
+		TraceDistance: resp.Msg.TraceDistance,
+		Differences:   diffs,
+	}, nil
+}
+
+// Draw returns the circuit diagram of p in the format.
+// User-defined gates are drawn as boxes unless expand is true.
+func (c *Client) Draw(ctx context.Context, p Program, format quasarv1.DrawFormat, expand bool) (string, error) {
+	resp, err := c.quasarClient.Draw(ctx, connect.NewRequest(&quasarv1.DrawRequest{
+		Program: program(p),
+		Format:  format,
+		Expand:  expand,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("draw: %w", err)
+	}
+
+	return resp.Msg.Diagram, nil
+}
+
+// Analyze returns the metrics of p without simulating it.
+func (c *Client) Analyze(ctx context.Context, p Program) (*Metrics, error) {
+	resp, err := c.quasarClient.Analyze(ctx, connect.NewRequest(&quasarv1.AnalyzeRequest{
+		Program: program(p),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("analyze: %w", err)
+	}
+
+	return metrics(resp.Msg), nil
+}
+
+// Optimize returns p rewritten into an equivalent OpenQASM 3 program with fewer gates,
+// and the metrics before and after the optimization.
+func (c *Client) Optimize(ctx context.Context, p Program) (*Optimization, error) {
+	resp, err := c.quasarClient.Optimize(ctx, connect.NewRequest(&quasarv1.OptimizeRequest{
+		Program: program(p),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("optimize: %w", err)
+	}
+
+	return &Optimization{
+		Code:   resp.Msg.Code,
+		Before: metrics(resp.Msg.Before),
+		After:  metrics(resp.Msg.After),
+	}, nil
+}
+
+// Transpile returns p decomposed into the basis gates, e.g. []string{"rz", "sx", "x", "cx"}, and its metrics.
+// If the coupling map is given, e.g. {0, 1}, {1, 2}, swaps are inserted so that every two-qubit gate acts on connected qubits.
+func (c *Client) Transpile(ctx context.Context, p Program, basis []string, coupling ...[2]int32) (*Transpilation, error) {
+	resp, err := c.quasarClient.Transpile(ctx, connect.NewRequest(&quasarv1.TranspileRequest{
+		Program:     program(p),
+		Basis:       basis,
+		CouplingMap: edges(coupling),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("transpile: %w", err)
+	}
+
+	return &Transpilation{
+		Code:    resp.Msg.Code,
+		Metrics: metrics(resp.Msg.Metrics),
+		Routing: routing(resp.Msg.Routing),
+	}, nil
+}
+
+func edges(coupling [][2]int32) []*quasarv1.Edge {
+	out := make([]*quasarv1.Edge, len(coupling))
+	for i, e := range coupling {
+		out[i] = &quasarv1.Edge{A: e[0], B: e[1]}
+	}
+
+	return out
+}
+
+func routing(r *quasarv1.Routing) *Routing {
+	if r == nil {
+		return nil
+	}
+
+	return &Routing{
+		Layout:      r.Layout,
+		FinalLayout: r.FinalLayout,
+		Swaps:       r.Swaps,
+	}
+}
+
+func metrics(m *quasarv1.AnalyzeResponse) *Metrics {
+	return &Metrics{
+		Qubits:        m.GetQubits(),
+		Depth:         m.GetDepth(),
+		Gates:         m.GetGates(),
+		TwoQubitGates: m.GetTwoQubitGates(),
+		TCount:        m.GetTCount(),
+		Clifford:      m.GetClifford(),
+	}
+}
+
+// StartSession starts a debug session of the code.
+func (c *Client) StartSession(ctx context.Context, code string, inputs map[string]float64) (*Session, error) {
+	resp, err := c.quasarClient.StartSession(ctx, connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code:   code,
+		Inputs: inputs,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("start session: %w", err)
+	}
+
+	return toSession(resp.Msg.Session), nil
+}
+
+func (c *Client) Step(ctx context.Context, id string, count int32) (*Session, error) {
+	resp, err := c.quasarClient.Step(ctx, connect.NewRequest(&quasarv1.StepRequest{
+		Id:    id,
+		Count: &count,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("step: %w", err)
+	}
+
+	return toSession(resp.Msg.Session), nil
+}
+
+// Continue runs the debug session until the next statement on the line, or to the end if line is 0.
+func (c *Client) Continue(ctx context.Context, id string, line int32) (*Session, error) {
+	req := &quasarv1.ContinueRequest{
+		Id: id,
+	}
+
+	if line > 0 {
+		req.Line = &line
+	}
+
+	resp, err := c.quasarClient.Continue(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("continue: %w", err)
+	}
+
+	return toSession(resp.Msg.Session), nil
+}
+
+func (c *Client) Inspect(ctx context.Context, id, register string) (*Register, error) {
+	resp, err := c.quasarClient.Inspect(ctx, connect.NewRequest(&quasarv1.InspectRequest{
+		Id:       id,
+		Register: register,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	out := &Register{
+		Name:   resp.Msg.Register,
+		States: toStates(resp.Msg.States),
+	}
+
+	if resp.Msg.Classical != nil {
+		classical := toClassical(map[string]*quasarv1.SimulateResponse_Classical{
+			register: resp.Msg.Classical,
+		})[register]
+		out.Classical = &classical
+	}
+
+	return out, nil
+}
+
+func (c *Client) End(ctx context.Context, id string) error {
+	if _, err := c.quasarClient.End(ctx, connect.NewRequest(&quasarv1.EndRequest{
+		Id: id,
+	})); err != nil {
+		return fmt.Errorf("end: %w", err)
+	}
+
+	return nil
+}
+
+// Submit submits the simulation as an asynchronous job of the owner.
+// The owner is a secret key of the caller, and ListJobs returns the jobs of the same owner.
+func (c *Client) Submit(ctx context.Context, owner, code string, opts ...SimulateOption) (*Job, error) {
+	req := &quasarv1.SimulateRequest{
+		Code: code,
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.quasarClient.Submit(ctx, connect.NewRequest(&quasarv1.SubmitRequest{
+		Request: req,
+		Owner:   owner,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("submit: %w", err)
+	}
+
+	return &Job{
+		ID:        resp.Msg.Id,
+		Status:    toStatus(quasarv1.JobStatus_JOB_STATUS_QUEUED),
+		CreatedAt: resp.Msg.CreatedAt.AsTime(),
 	}, nil
 }
 
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	resp, err := c.quasarClient.GetJob(ctx, connect.NewRequest(&quasarv1.GetJobRequest{
+		Id: id,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+
+	return toJob(resp.Msg.Job)
+}
+
+func (c *Client) CancelJob(ctx context.Context, id string) (*Job, error) {
+	resp, err := c.quasarClient.CancelJob(ctx, connect.NewRequest(&quasarv1.CancelJobRequest{
+		Id: id,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("cancel job: %w", err)
+	}
+
+	return toJob(resp.Msg.Job)
+}
+
+// ListJobs returns the most recently submitted jobs of the owner, up to limit.
+func (c *Client) ListJobs(ctx context.Context, owner string, limit int32) ([]Job, error) {
+	resp, err := c.quasarClient.ListJobs(ctx, connect.NewRequest(&quasarv1.ListJobsRequest{
+		Owner: owner,
+		Limit: &limit,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+
+	jobs := make([]Job, len(resp.Msg.Jobs))
+	for i, j := range resp.Msg.Jobs {
+		job, err := toJob(j)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs[i] = *job
+	}
+
+	return jobs, nil
+}
+
 func (c *Client) Share(ctx context.Context, code string) (*Snippet, error) {
 	resp, err := c.quasarClient.Share(ctx, connect.NewRequest(&quasarv1.ShareRequest{
 		Code: code,
@@ -107,9 +765,11 @@ func (c *Client) Edit(ctx context.Context, id string) (*Snippet, error) {
 	}, nil
 }
 
-func (c *Client) Validate(ctx context.Context, code string) (*ValidationResult, error) {
+// Validate validates the OpenQASM 2 or 3 code. If the coupling map is given, the code is also compiled and routed onto it.
+func (c *Client) Validate(ctx context.Context, code string, coupling ...[2]int32) (*ValidationResult, error) {
 	resp, err := c.quasarClient.Validate(ctx, connect.NewRequest(&quasarv1.ValidateRequest{
-		Code: code,
+		Code:        code,
+		CouplingMap: edges(coupling),
 	}))
 	if err != nil {
 		return nil, fmt.Errorf("validate: %w", err)
@@ -120,5 +780,229 @@ func (c *Client) Validate(ctx context.Context, code string) (*ValidationResult,
 		Line:    resp.Msg.Line,
 		Column:  resp.Msg.Column,
 		Message: resp.Msg.Message,
+		Routing: routing(resp.Msg.Routing),
+		Version: resp.Msg.Version,
+	}, nil
+}
+
+func toResults(msg *quasarv1.SimulateResponse) (*States, error) {
+	sweep := make([]Result, len(msg.Sweep))
+	for i, r := range msg.Sweep {
+		amplitudes, err := toAmplitudes(r.Packed)
+		if err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		matrix, err := toMatrix(r.DensityMatrix)
+		if err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		analysis, err := toAnalysis(r.Analysis)
+		if err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		sweep[i] = Result{
+			Inputs:          r.Inputs,
+			States:          toStates(r.States),
+			Counts:          r.Counts,
+			Classical:       toClassical(r.Classical),
+			Expectations:    r.Expectations,
+			Amplitudes:      amplitudes,
+			Diagonal:        r.Diagonal,
+			DensityMatrix:   matrix,
+			Stabilizers:     r.Stabilizers,
+			TruncationError: r.TruncationError,
+			BondDimension:   r.BondDimension,
+			Analysis:        analysis,
+		}
+	}
+
+	amplitudes, err := toAmplitudes(msg.Packed)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	matrix, err := toMatrix(msg.DensityMatrix)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	analysis, err := toAnalysis(msg.Analysis)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return &States{
+		States:          toStates(msg.States),
+		Counts:          msg.Counts,
+		Classical:       toClassical(msg.Classical),
+		Sweep:           sweep,
+		Expectations:    msg.Expectations,
+		Amplitudes:      amplitudes,
+		Diagonal:        msg.Diagonal,
+		DensityMatrix:   matrix,
+		Stabilizers:     msg.Stabilizers,
+		TruncationError: msg.TruncationError,
+		BondDimension:   msg.BondDimension,
+		Analysis:        analysis,
+	}, nil
+}
+
+func toAnalysis(in *quasarv1.SimulateResponse_Analysis) (*Analysis, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	matrix, err := toMatrix(in.ReducedDensityMatrix)
+	if err != nil {
+		return nil, err
+	}
+
+	bloch := make([]Bloch, len(in.Bloch))
+	for i, b := range in.Bloch {
+		bloch[i] = Bloch{
+			X: b.X,
+			Y: b.Y,
+			Z: b.Z,
+		}
+	}
+
+	return &Analysis{
+		Bloch:                bloch,
+		ReducedDensityMatrix: matrix,
+		Entropy:              in.Entropy,
 	}, nil
 }
+
+func toSession(in *quasarv1.Session) *Session {
+	return &Session{
+		ID:        in.GetId(),
+		Step:      in.GetStep(),
+		Line:      in.Line,
+		Column:    in.Column,
+		Done:      in.GetDone(),
+		States:    toStates(in.GetStates()),
+		Classical: toClassical(in.GetClassical()),
+		ExpiresAt: in.GetExpiresAt().AsTime(),
+	}
+}
+
+func toJob(in *quasarv1.Job) (*Job, error) {
+	job := &Job{
+		ID:        in.GetId(),
+		Status:    toStatus(in.GetStatus()),
+		CreatedAt: in.GetCreatedAt().AsTime(),
+		Error:     in.Error,
+	}
+
+	if in.StartedAt != nil {
+		job.StartedAt = new(in.StartedAt.AsTime())
+	}
+
+	if in.FinishedAt != nil {
+		job.FinishedAt = new(in.FinishedAt.AsTime())
+	}
+
+	if in.Result != nil {
+		result, err := toResults(in.Result)
+		if err != nil {
+			return nil, err
+		}
+
+		job.Result = result
+	}
+
+	return job, nil
+}
+
+// toStatus returns the lower case status name, e.g. "succeeded".
+func toStatus(status quasarv1.JobStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "JOB_STATUS_"))
+}
+
+func toStates(in []*quasarv1.SimulateResponse_State) []State {
+	states := make([]State, len(in))
+	for i, s := range in {
+		states[i] = State{
+			Probability: s.Probability,
+			Amplitude: Amplitude{
+				Real: s.GetAmplitude().GetReal(),
+				Imag: s.GetAmplitude().GetImag(),
+			},
+			BinaryString: s.BinaryString,
+		}
+	}
+
+	return states
+}
+
+func toAmplitudes(b []byte) ([]Amplitude, error) {
+	amp, err := packed.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(amp) == 0 {
+		return nil, nil
+	}
+
+	amplitudes := make([]Amplitude, len(amp))
+	for i, a := range amp {
+		amplitudes[i] = Amplitude{
+			Real: real(a),
+			Imag: imag(a),
+		}
+	}
+
+	return amplitudes, nil
+}
+
+// toMatrix decodes the row-major density matrix.
+func toMatrix(b []byte) ([][]Amplitude, error) {
+	elems, err := toAmplitudes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var n int
+	for n*n < len(elems) {
+		n++
+	}
+
+	if n*n != len(elems) {
+		return nil, fmt.Errorf("size=%d: %w", len(elems), packed.ErrInvalidLength)
+	}
+
+	matrix := make([][]Amplitude, n)
+	for i := range matrix {
+		matrix[i] = elems[i*n : (i+1)*n]
+	}
+
+	return matrix, nil
+}
+
+func toClassical(in map[string]*quasarv1.SimulateResponse_Classical) map[string]Classical {
+	if len(in) == 0 {
+		return nil
+	}
+
+	classical := make(map[string]Classical, len(in))
+	for name, c := range in {
+		switch v := c.Value.(type) {
+		case *quasarv1.SimulateResponse_Classical_Bits:
+			classical[name] = Classical{Bits: v.Bits.Values}
+		case *quasarv1.SimulateResponse_Classical_Int:
+			classical[name] = Classical{Int: &v.Int}
+		case *quasarv1.SimulateResponse_Classical_Float:
+			classical[name] = Classical{Float: &v.Float}
+		case *quasarv1.SimulateResponse_Classical_Angle:
+			classical[name] = Classical{Angle: &v.Angle}
+		case *quasarv1.SimulateResponse_Classical_Bool:
+			classical[name] = Classical{Bool: &v.Bool}
+		}
+	}
+
+	return classical
+}
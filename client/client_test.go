@@ -10,6 +10,7 @@ import (
 	"github.com/itsubaki/quasar/client"
 	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 	"github.com/itsubaki/quasar/gen/quasar/v1/quasarv1connect"
+	"github.com/itsubaki/quasar/packed"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -27,6 +28,56 @@ func (m *mock) Simulate(
 	ctx context.Context,
 	req *connect.Request[quasarv1.SimulateRequest],
 ) (*connect.Response[quasarv1.SimulateResponse], error) {
+	var sweep []*quasarv1.SimulateResponse_Result
+	for _, in := range req.Msg.Sweep {
+		sweep = append(sweep, &quasarv1.SimulateResponse_Result{
+			Inputs: in.Values,
+		})
+	}
+
+	var amplitudes []byte
+	if req.Msg.Packed {
+		amplitudes = packed.Encode([]complex128{0, 1})
+	}
+
+	var diagonal []float64
+	var matrix []byte
+	if req.Msg.Backend == quasarv1.Backend_BACKEND_DENSITY_MATRIX {
+		p := 1 - req.Msg.GetNoise().GetBitFlip()
+		diagonal = []float64{1 - p, p}
+		matrix = packed.Encode([]complex128{complex(1-p, 0), 0, 0, complex(p, 0)})
+	}
+
+	var stabilizers []string
+	if req.Msg.Backend == quasarv1.Backend_BACKEND_STABILIZER {
+		stabilizers = []string{"+XX", "+ZZ"}
+	}
+
+	var truncation float64
+	var bond int32
+	if req.Msg.Backend == quasarv1.Backend_BACKEND_MPS {
+		truncation, bond = req.Msg.GetTruncationThreshold(), req.Msg.GetMaxBondDimension()
+	}
+
+	var analysis *quasarv1.SimulateResponse_Analysis
+	if req.Msg.Analysis != nil {
+		analysis = &quasarv1.SimulateResponse_Analysis{
+			Bloch: []*quasarv1.SimulateResponse_Analysis_Bloch{{}, {}},
+		}
+
+		if len(req.Msg.Analysis.Subsystem) > 0 {
+			analysis.ReducedDensityMatrix = packed.Encode([]complex128{0.5, 0, 0, 0.5})
+			analysis.Entropy = new(1.0)
+		}
+	}
+
+	var counts map[string]int32
+	if req.Msg.Shots != nil {
+		counts = map[string]int32{
+			"101": req.Msg.GetShots(),
+		}
+	}
+
 	return connect.NewResponse(&quasarv1.SimulateResponse{
 		States: []*quasarv1.SimulateResponse_State{
 			{
@@ -38,6 +89,30 @@ func (m *mock) Simulate(
 				BinaryString: []string{"101"},
 			},
 		},
+		Counts:          counts,
+		Sweep:           sweep,
+		Packed:          amplitudes,
+		Diagonal:        diagonal,
+		DensityMatrix:   matrix,
+		Stabilizers:     stabilizers,
+		TruncationError: truncation,
+		BondDimension:   bond,
+		Analysis:        analysis,
+		Expectations:    make([]float64, len(req.Msg.Observables)),
+		Classical: map[string]*quasarv1.SimulateResponse_Classical{
+			"c": {
+				Value: &quasarv1.SimulateResponse_Classical_Bits{
+					Bits: &quasarv1.SimulateResponse_Bits{
+						Values: []int32{1, 0, 1},
+					},
+				},
+			},
+			"n": {
+				Value: &quasarv1.SimulateResponse_Classical_Int{
+					Int: 5,
+				},
+			},
+		},
 	}), nil
 }
 
@@ -66,11 +141,269 @@ func (m *mock) Validate(
 	ctx context.Context,
 	req *connect.Request[quasarv1.ValidateRequest],
 ) (*connect.Response[quasarv1.ValidateResponse], error) {
+	if len(req.Msg.CouplingMap) > 0 {
+		return connect.NewResponse(&quasarv1.ValidateResponse{
+			Valid: true,
+			Routing: &quasarv1.Routing{
+				Layout:      []int32{0, 1, 2},
+				FinalLayout: []int32{1, 0, 2},
+				Swaps:       1,
+			},
+		}), nil
+	}
+
 	return connect.NewResponse(&quasarv1.ValidateResponse{
 		Valid:   false,
 		Line:    new(int32(10)),
 		Column:  new(int32(5)),
 		Message: new("syntax error"),
+		Version: "3.0",
+	}), nil
+}
+
+func (m *mock) SimulateStream(
+	ctx context.Context,
+	req *connect.Request[quasarv1.SimulateStreamRequest],
+	stream *connect.ServerStream[quasarv1.SimulateStreamResponse],
+) error {
+	for i, line := range req.Msg.Lines {
+		if err := stream.Send(&quasarv1.SimulateStreamResponse{
+			Step:   int32(i + 1),
+			Line:   line,
+			Gate:   "h",
+			Qubits: []int32{int32(i)},
+			States: []*quasarv1.SimulateResponse_State{
+				{
+					BinaryString: []string{"0"},
+					Probability:  0.5,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *mock) Unitary(
+	ctx context.Context,
+	req *connect.Request[quasarv1.UnitaryRequest],
+) (*connect.Response[quasarv1.UnitaryResponse], error) {
+	return connect.NewResponse(&quasarv1.UnitaryResponse{
+		Qubits: 1,
+		Matrix: packed.Encode([]complex128{0, 1, 1, 0}),
+	}), nil
+}
+
+func (m *mock) Equivalent(
+	ctx context.Context,
+	req *connect.Request[quasarv1.EquivalentRequest],
+) (*connect.Response[quasarv1.EquivalentResponse], error) {
+	if req.Msg.A.Code == req.Msg.B.Code {
+		return connect.NewResponse(&quasarv1.EquivalentResponse{
+			Equivalent: true,
+			Qubits:     1,
+			Fidelity:   1,
+		}), nil
+	}
+
+	return connect.NewResponse(&quasarv1.EquivalentResponse{
+		Qubits:                 1,
+		Counterexample:         new("0+1"),
+		CounterexampleFidelity: new(0.0),
+	}), nil
+}
+
+func (m *mock) Compare(
+	ctx context.Context,
+	req *connect.Request[quasarv1.CompareRequest],
+) (*connect.Response[quasarv1.CompareResponse], error) {
+	return connect.NewResponse(&quasarv1.CompareResponse{
+		Qubits:        1,
+		Fidelity:      0.5,
+		TraceDistance: 0.707107,
+		Differences: []*quasarv1.CompareResponse_Difference{
+			{BinaryString: []string{"0"}, A: 0.5, B: 1, Diff: 0.5},
+			{BinaryString: []string{"1"}, A: 0.5, B: 0, Diff: -0.5},
+		},
+	}), nil
+}
+
+func (m *mock) Draw(
+	ctx context.Context,
+	req *connect.Request[quasarv1.DrawRequest],
+) (*connect.Response[quasarv1.DrawResponse], error) {
+	return connect.NewResponse(&quasarv1.DrawResponse{
+		Diagram: "q: -[h]--\n",
+	}), nil
+}
+
+func (m *mock) Analyze(
+	ctx context.Context,
+	req *connect.Request[quasarv1.AnalyzeRequest],
+) (*connect.Response[quasarv1.AnalyzeResponse], error) {
+	return connect.NewResponse(&quasarv1.AnalyzeResponse{
+		Qubits:        2,
+		Depth:         2,
+		Gates:         map[string]int32{"h": 1, "cx": 1},
+		TwoQubitGates: 1,
+		Clifford:      true,
+	}), nil
+}
+
+func (m *mock) Optimize(
+	ctx context.Context,
+	req *connect.Request[quasarv1.OptimizeRequest],
+) (*connect.Response[quasarv1.OptimizeResponse], error) {
+	return connect.NewResponse(&quasarv1.OptimizeResponse{
+		Code: "OPENQASM 3.0;\n\nqubit q;\n\nU(pi/2, 0, pi) q;\n",
+		Before: &quasarv1.AnalyzeResponse{
+			Qubits: 1,
+			Depth:  3,
+			Gates:  map[string]int32{"h": 3},
+		},
+		After: &quasarv1.AnalyzeResponse{
+			Qubits: 1,
+			Depth:  1,
+			Gates:  map[string]int32{"h": 1},
+		},
+	}), nil
+}
+
+func (m *mock) Transpile(
+	ctx context.Context,
+	req *connect.Request[quasarv1.TranspileRequest],
+) (*connect.Response[quasarv1.TranspileResponse], error) {
+	return connect.NewResponse(&quasarv1.TranspileResponse{
+		Code: "OPENQASM 3.0;\n\nqubit q;\n\nu3(pi/2, 0, pi) q;\n",
+		Metrics: &quasarv1.AnalyzeResponse{
+			Qubits: 1,
+			Depth:  1,
+			Gates:  map[string]int32{"h": 1},
+		},
+	}), nil
+}
+
+func (m *mock) StartSession(
+	ctx context.Context,
+	req *connect.Request[quasarv1.StartSessionRequest],
+) (*connect.Response[quasarv1.StartSessionResponse], error) {
+	return connect.NewResponse(&quasarv1.StartSessionResponse{
+		Session: &quasarv1.Session{
+			Id:        "session1234",
+			Line:      new(int32(1)),
+			Column:    new(int32(0)),
+			States:    []*quasarv1.SimulateResponse_State{},
+			ExpiresAt: &timestamppb.Timestamp{Seconds: 1234},
+		},
+	}), nil
+}
+
+func (m *mock) Continue(
+	ctx context.Context,
+	req *connect.Request[quasarv1.ContinueRequest],
+) (*connect.Response[quasarv1.ContinueResponse], error) {
+	return connect.NewResponse(&quasarv1.ContinueResponse{
+		Session: &quasarv1.Session{
+			Id:   req.Msg.Id,
+			Step: 3,
+			Line: req.Msg.Line,
+			States: []*quasarv1.SimulateResponse_State{
+				{
+					BinaryString: []string{"01"},
+					Probability:  1,
+					Amplitude:    &quasarv1.SimulateResponse_Amplitude{Real: 1},
+				},
+			},
+		},
+	}), nil
+}
+
+func (m *mock) Inspect(
+	ctx context.Context,
+	req *connect.Request[quasarv1.InspectRequest],
+) (*connect.Response[quasarv1.InspectResponse], error) {
+	return connect.NewResponse(&quasarv1.InspectResponse{
+		Register: req.Msg.Register,
+		Classical: &quasarv1.SimulateResponse_Classical{
+			Value: &quasarv1.SimulateResponse_Classical_Bits{
+				Bits: &quasarv1.SimulateResponse_Bits{Values: []int32{0, 1}},
+			},
+		},
+	}), nil
+}
+
+func (m *mock) End(
+	ctx context.Context,
+	req *connect.Request[quasarv1.EndRequest],
+) (*connect.Response[quasarv1.EndResponse], error) {
+	return connect.NewResponse(&quasarv1.EndResponse{}), nil
+}
+
+func (m *mock) Submit(
+	ctx context.Context,
+	req *connect.Request[quasarv1.SubmitRequest],
+) (*connect.Response[quasarv1.SubmitResponse], error) {
+	return connect.NewResponse(&quasarv1.SubmitResponse{
+		Id:        "job1234",
+		CreatedAt: &timestamppb.Timestamp{Seconds: 1234},
+	}), nil
+}
+
+func (m *mock) GetJob(
+	ctx context.Context,
+	req *connect.Request[quasarv1.GetJobRequest],
+) (*connect.Response[quasarv1.GetJobResponse], error) {
+	return connect.NewResponse(&quasarv1.GetJobResponse{
+		Job: &quasarv1.Job{
+			Id:         req.Msg.Id,
+			Status:     quasarv1.JobStatus_JOB_STATUS_SUCCEEDED,
+			CreatedAt:  &timestamppb.Timestamp{Seconds: 1234},
+			StartedAt:  &timestamppb.Timestamp{Seconds: 1235},
+			FinishedAt: &timestamppb.Timestamp{Seconds: 1236},
+			Result: &quasarv1.SimulateResponse{
+				Counts: map[string]int32{
+					"101": 1024,
+				},
+			},
+		},
+	}), nil
+}
+
+func (m *mock) CancelJob(
+	ctx context.Context,
+	req *connect.Request[quasarv1.CancelJobRequest],
+) (*connect.Response[quasarv1.CancelJobResponse], error) {
+	return connect.NewResponse(&quasarv1.CancelJobResponse{
+		Job: &quasarv1.Job{
+			Id:        req.Msg.Id,
+			Status:    quasarv1.JobStatus_JOB_STATUS_RUNNING,
+			CreatedAt: &timestamppb.Timestamp{Seconds: 1234},
+		},
+	}), nil
+}
+
+func (m *mock) ListJobs(
+	ctx context.Context,
+	req *connect.Request[quasarv1.ListJobsRequest],
+) (*connect.Response[quasarv1.ListJobsResponse], error) {
+	if req.Msg.Owner != "owner1234" {
+		return connect.NewResponse(&quasarv1.ListJobsResponse{}), nil
+	}
+
+	jobs := make([]*quasarv1.Job, req.Msg.GetLimit())
+	for i := range jobs {
+		jobs[i] = &quasarv1.Job{
+			Id:        fmt.Sprintf("job%d", i),
+			Status:    quasarv1.JobStatus_JOB_STATUS_FAILED,
+			CreatedAt: &timestamppb.Timestamp{Seconds: 1234},
+			Error:     new("qubits not found"),
+		}
+	}
+
+	return connect.NewResponse(&quasarv1.ListJobsResponse{
+		Jobs: jobs,
 	}), nil
 }
 
@@ -90,8 +423,469 @@ func ExampleClient_Simulate() {
 		fmt.Println(state.BinaryString, state.Probability, state.Amplitude.Real, state.Amplitude.Imag)
 	}
 
+	fmt.Println(states.Classical["c"].Bits)
+	fmt.Println(*states.Classical["n"].Int)
+
 	// Output:
 	// [101] 0.5 1 -1
+	// [1 0 1]
+	// 5
+}
+
+func ExampleWithShots() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit[3] q;",
+		client.WithShots(1024),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Counts)
+
+	// Output:
+	// map[101:1024]
+}
+
+func ExampleWithSweep() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"input angle theta; qubit q;",
+		client.WithSweep(
+			map[string]float64{"theta": 0.5},
+			map[string]float64{"theta": 1.5},
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, r := range states.Sweep {
+		fmt.Println(r.Inputs)
+	}
+
+	// Output:
+	// map[theta:0.5]
+	// map[theta:1.5]
+}
+
+func ExampleWithObservables() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit[2] q;",
+		client.WithObservables("ZZ", "0.5*XI + 0.5*IX"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Expectations)
+
+	// Output:
+	// [0 0]
+}
+
+func ExampleWithPacked() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit q;",
+		client.WithPacked(),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Amplitudes)
+
+	// Output:
+	// [{0 0} {1 0}]
+}
+
+func ExampleWithNoise() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit q; U(pi, 0, pi) q;",
+		client.WithNoise(client.Noise{
+			BitFlip: 0.25,
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Diagonal)
+	fmt.Println(states.DensityMatrix)
+
+	// Output:
+	// [0.25 0.75]
+	// [[{0.25 0} {0 0}] [{0 0} {0.75 0}]]
+}
+
+func ExampleWithBackend() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit[2] q;",
+		client.WithBackend(quasarv1.Backend_BACKEND_STABILIZER),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Stabilizers)
+
+	// Output:
+	// [+XX +ZZ]
+}
+
+func ExampleWithMPS() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit[40] q;",
+		client.WithMPS(16, 1e-8),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.BondDimension, states.TruncationError)
+
+	// Output:
+	// 16 1e-08
+}
+
+func ExampleWithAnalysis() {
+	srv := newMock()
+	defer srv.Close()
+
+	states, err := client.New(srv.URL, srv.Client()).Simulate(
+		context.Background(),
+		"qubit[2] q; h q[0]; cx q[0], q[1];",
+		client.WithAnalysis(0),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(states.Analysis.Bloch)
+	fmt.Println(states.Analysis.ReducedDensityMatrix)
+	fmt.Println(*states.Analysis.Entropy)
+
+	// Output:
+	// [{0 0 0} {0 0 0}]
+	// [[{0.5 0} {0 0}] [{0 0} {0.5 0}]]
+	// 1
+}
+
+func ExampleClient_SimulateStream() {
+	srv := newMock()
+	defer srv.Close()
+
+	for snapshot, err := range client.New(srv.URL, srv.Client()).SimulateStream(
+		context.Background(),
+		"qubit[2] q; h q;",
+		client.StreamFilter{Lines: []int32{3, 4}},
+	) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(snapshot.Step, snapshot.Line, snapshot.Gate, snapshot.Qubits, snapshot.States[0].Probability)
+	}
+
+	// Output:
+	// 1 3 h [0] 0.5
+	// 2 4 h [1] 0.5
+}
+
+func ExampleClient_Unitary() {
+	srv := newMock()
+	defer srv.Close()
+
+	u, err := client.New(srv.URL, srv.Client()).Unitary(
+		context.Background(),
+		"gate x q { U(pi, 0, pi) q; }",
+		"x",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(u.Qubits)
+	for _, row := range u.Matrix {
+		fmt.Println(row)
+	}
+
+	// Output:
+	// 1
+	// [{0 0} {1 0}]
+	// [{1 0} {0 0}]
+}
+
+func ExampleClient_Equivalent() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).Equivalent(
+		context.Background(),
+		client.Program{Code: "qubit q; U(0, 0, pi) q;"},
+		client.Program{Code: "qubit q;"},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result.Equivalent, result.Fidelity, *result.Counterexample, *result.CounterexampleFidelity)
+
+	// Output:
+	// false 0 0+1 0
+}
+
+func ExampleClient_CompareState() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).CompareState(
+		context.Background(),
+		client.Program{Code: "qubit q; U(pi/2, 0, pi) q;"},
+		[]complex128{1, 0},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result.Fidelity, result.TraceDistance)
+	for _, d := range result.Differences {
+		fmt.Println(d.BinaryString, d.A, d.B, d.Diff)
+	}
+
+	// Output:
+	// 0.5 0.707107
+	// [0] 0.5 1 0.5
+	// [1] 0.5 0 -0.5
+}
+
+func ExampleClient_Draw() {
+	srv := newMock()
+	defer srv.Close()
+
+	diagram, err := client.New(srv.URL, srv.Client()).Draw(
+		context.Background(),
+		client.Program{Code: "qubit q; h q;"},
+		quasarv1.DrawFormat_DRAW_FORMAT_ASCII,
+		false,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(diagram)
+
+	// Output:
+	// q: -[h]--
+}
+
+func ExampleClient_Analyze() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).Analyze(
+		context.Background(),
+		client.Program{Code: "qubit[2] q; h q[0]; cx q[0], q[1];"},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result.Qubits, result.Depth, result.Gates, result.TwoQubitGates, result.TCount, result.Clifford)
+
+	// Output:
+	// 2 2 map[cx:1 h:1] 1 0 true
+}
+
+func ExampleClient_Optimize() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).Optimize(
+		context.Background(),
+		client.Program{Code: "qubit q; h q; h q; h q;"},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(result.Code)
+	fmt.Println(result.Before.Depth, result.Before.Gates)
+	fmt.Println(result.After.Depth, result.After.Gates)
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit q;
+	//
+	// U(pi/2, 0, pi) q;
+	// 3 map[h:3]
+	// 1 map[h:1]
+}
+
+func ExampleClient_Transpile() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).Transpile(
+		context.Background(),
+		client.Program{Code: "qubit q; h q;"},
+		[]string{"u3", "cx"},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(result.Code)
+	fmt.Println(result.Metrics.Depth, result.Metrics.Gates)
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit q;
+	//
+	// u3(pi/2, 0, pi) q;
+	// 1 map[h:1]
+}
+
+func ExampleClient_StartSession() {
+	srv := newMock()
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	session, err := c.StartSession(context.Background(), "qubit[2] q; x q[1];", nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(session.ID, *session.Line, session.Done)
+
+	session, err = c.Continue(context.Background(), session.ID, 4)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(session.Step, *session.Line, session.States[0].BinaryString)
+
+	register, err := c.Inspect(context.Background(), session.ID, "c")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(register.Name, register.Classical.Bits)
+
+	if err := c.End(context.Background(), session.ID); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// session1234 1 false
+	// 3 4 [01]
+	// c [0 1]
+}
+
+func ExampleClient_Submit() {
+	srv := newMock()
+	defer srv.Close()
+
+	job, err := client.New(srv.URL, srv.Client()).Submit(
+		context.Background(),
+		"owner1234",
+		"qubit[3] q;",
+		client.WithShots(1024),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(job.ID, job.Status, job.CreatedAt.Unix())
+
+	// Output:
+	// job1234 queued 1234
+}
+
+func ExampleClient_GetJob() {
+	srv := newMock()
+	defer srv.Close()
+
+	job, err := client.New(srv.URL, srv.Client()).GetJob(
+		context.Background(),
+		"job1234",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(job.ID, job.Status, job.StartedAt.Unix(), job.FinishedAt.Unix())
+	fmt.Println(job.Result.Counts)
+
+	// Output:
+	// job1234 succeeded 1235 1236
+	// map[101:1024]
+}
+
+func ExampleClient_CancelJob() {
+	srv := newMock()
+	defer srv.Close()
+
+	job, err := client.New(srv.URL, srv.Client()).CancelJob(
+		context.Background(),
+		"job1234",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(job.ID, job.Status, job.Result == nil)
+
+	// Output:
+	// job1234 running true
+}
+
+func ExampleClient_ListJobs() {
+	srv := newMock()
+	defer srv.Close()
+
+	jobs, err := client.New(srv.URL, srv.Client()).ListJobs(
+		context.Background(),
+		"owner1234",
+		2,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, job := range jobs {
+		fmt.Println(job.ID, job.Status, *job.Error)
+	}
+
+	// Output:
+	// job0 failed qubits not found
+	// job1 failed qubits not found
 }
 
 func ExampleClient_Share() {
@@ -152,10 +946,34 @@ func ExampleClient_Validate() {
 	fmt.Println(*result.Line)
 	fmt.Println(*result.Column)
 	fmt.Println(*result.Message)
+	fmt.Println(result.Version)
 
 	// Output:
 	// false
 	// 10
 	// 5
 	// syntax error
+	// 3.0
+}
+
+func ExampleClient_Validate_couplingMap() {
+	srv := newMock()
+	defer srv.Close()
+
+	result, err := client.New(srv.URL, srv.Client()).Validate(
+		context.Background(),
+		"qubit[3] q; cx q[0], q[2];",
+		[2]int32{0, 1},
+		[2]int32{1, 2},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result.Valid)
+	fmt.Println(result.Routing.Layout, result.Routing.FinalLayout, result.Routing.Swaps)
+
+	// Output:
+	// true
+	// [0 1 2] [1 0 2] 1
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itsubaki/quasar/client"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+)
+
+var (
+	TargetURL     = os.Getenv("TARGET_URL")
+	IdentityToken = os.Getenv("IDENTITY_TOKEN")
+)
+
+func main() {
+	var filepath, format string
+	var expand bool
+	flag.StringVar(&filepath, "f", "", "filepath")
+	flag.StringVar(&format, "format", "ascii", "ascii, svg or latex")
+	flag.BoolVar(&expand, "expand", false, "expand user-defined gates")
+	flag.Parse()
+
+	if filepath == "" {
+		fmt.Printf("Usage: %s -f filepath [-format ascii|svg|latex] [-expand]\n", os.Args[0])
+		return
+	}
+
+	f, ok := quasarv1.DrawFormat_value["DRAW_FORMAT_"+strings.ToUpper(format)]
+	if !ok {
+		fmt.Printf("invalid format: %s\n", format)
+		return
+	}
+
+	contents, err := os.ReadFile(filepath)
+	if err != nil {
+		panic(err)
+	}
+
+	// draw
+	diagram, err := client.
+		New(TargetURL, client.NewWithIdentityToken(IdentityToken)).
+		Draw(context.Background(), client.Program{Code: string(contents)}, quasarv1.DrawFormat(f), expand)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(diagram)
+}
@@ -6,8 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/itsubaki/quasar/client"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 )
 
 var (
@@ -17,7 +20,48 @@ var (
 
 func main() {
 	var filepath string
+	var shots int
+	var seed uint64
+	inputs := make(map[string]float64)
+	var observables []string
+	var precision int
+	var dense, pack bool
+	var backend string
+	var noise client.Noise
+	var bond int
+	var threshold float64
 	flag.StringVar(&filepath, "f", "", "filepath")
+	flag.IntVar(&shots, "shots", 0, "number of shots")
+	flag.Uint64Var(&seed, "seed", 0, "random seed (0: random)")
+	flag.Func("i", "input binding name=value (repeatable)", func(s string) error {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("invalid input: %s", s)
+		}
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid input: %s: %w", s, err)
+		}
+
+		inputs[name] = v
+		return nil
+	})
+	flag.IntVar(&precision, "precision", -1, "rounding precision (0: full precision, -1: server default)")
+	flag.BoolVar(&dense, "dense", false, "return the full state vector including zero entries")
+	flag.BoolVar(&pack, "packed", false, "return the packed state vector")
+	flag.StringVar(&backend, "backend", "", "simulation backend (statevector, density, stabilizer, mps; empty: auto)")
+	flag.IntVar(&bond, "bond", 64, "maximum bond dimension (mps backend)")
+	flag.Float64Var(&threshold, "threshold", 0, "truncation threshold (mps backend)")
+	flag.Float64Var(&noise.Depolarizing, "depolarizing", 0, "depolarizing probability (density backend)")
+	flag.Float64Var(&noise.AmplitudeDamping, "amplitude-damping", 0, "amplitude damping probability (density backend)")
+	flag.Float64Var(&noise.PhaseDamping, "phase-damping", 0, "phase damping probability (density backend)")
+	flag.Float64Var(&noise.BitFlip, "bit-flip", 0, "bit flip probability (density backend)")
+	flag.Float64Var(&noise.ReadoutError, "readout-error", 0, "readout error probability (density backend)")
+	flag.Func("o", "observable e.g. 0.5*ZZ+0.3*XI (repeatable)", func(s string) error {
+		observables = append(observables, s)
+		return nil
+	})
 	flag.Parse()
 
 	if filepath == "" {
@@ -30,9 +74,57 @@ func main() {
 		panic(err)
 	}
 
+	var opts []client.SimulateOption
+	if shots > 0 {
+		opts = append(opts, client.WithShots(int32(shots)))
+	}
+
+	if seed > 0 {
+		opts = append(opts, client.WithSeed(seed))
+	}
+
+	if len(inputs) > 0 {
+		opts = append(opts, client.WithInputs(inputs))
+	}
+
+	if len(observables) > 0 {
+		opts = append(opts, client.WithObservables(observables...))
+	}
+
+	if precision >= 0 {
+		opts = append(opts, client.WithPrecision(int32(precision)))
+	}
+
+	if dense {
+		opts = append(opts, client.WithDense())
+	}
+
+	if pack {
+		opts = append(opts, client.WithPacked())
+	}
+
+	switch backend {
+	case "":
+	case "statevector":
+		opts = append(opts, client.WithBackend(quasarv1.Backend_BACKEND_STATEVECTOR))
+	case "density":
+		opts = append(opts, client.WithBackend(quasarv1.Backend_BACKEND_DENSITY_MATRIX))
+	case "stabilizer":
+		opts = append(opts, client.WithBackend(quasarv1.Backend_BACKEND_STABILIZER))
+	case "mps":
+		opts = append(opts, client.WithMPS(int32(bond), threshold))
+	default:
+		fmt.Printf("invalid backend: %s\n", backend)
+		return
+	}
+
+	if noise != (client.Noise{}) {
+		opts = append(opts, client.WithNoise(noise))
+	}
+
 	resp, err := client.
 		New(TargetURL, client.NewWithIdentityToken(IdentityToken)).
-		Simulate(context.Background(), string(code))
+		Simulate(context.Background(), string(code), opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -6,6 +6,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/itsubaki/quasar/client"
 )
@@ -16,15 +18,21 @@ var (
 )
 
 func main() {
-	var filepath string
+	var filepath, coupling string
 	flag.StringVar(&filepath, "f", "", "filepath")
+	flag.StringVar(&coupling, "coupling", "", "coupling map, e.g. 0-1,1-2")
 	flag.Parse()
 
 	if filepath == "" {
-		fmt.Printf("Usage: %s -f filepath\n", os.Args[0])
+		fmt.Printf("Usage: %s -f filepath [-coupling 0-1,1-2]\n", os.Args[0])
 		return
 	}
 
+	edges, err := parse(coupling)
+	if err != nil {
+		panic(err)
+	}
+
 	contents, err := os.ReadFile(filepath)
 	if err != nil {
 		panic(err)
@@ -33,7 +41,7 @@ func main() {
 	// validate
 	resp, err := client.
 		New(TargetURL, client.NewWithIdentityToken(IdentityToken)).
-		Validate(context.Background(), string(contents))
+		Validate(context.Background(), string(contents), edges...)
 	if err != nil {
 		panic(err)
 	}
@@ -45,3 +53,32 @@ func main() {
 
 	fmt.Println(string(bytes))
 }
+
+// parse returns the edges of the coupling map, e.g. "0-1,1-2".
+func parse(coupling string) ([][2]int32, error) {
+	if coupling == "" {
+		return nil, nil
+	}
+
+	var edges [][2]int32
+	for e := range strings.SplitSeq(coupling, ",") {
+		a, b, ok := strings.Cut(strings.TrimSpace(e), "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid edge: %q", e)
+		}
+
+		qa, err := strconv.ParseInt(a, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edge: %q: %w", e, err)
+		}
+
+		qb, err := strconv.ParseInt(b, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edge: %q: %w", e, err)
+		}
+
+		edges = append(edges, [2]int32{int32(qa), int32(qb)})
+	}
+
+	return edges, nil
+}
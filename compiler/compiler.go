@@ -0,0 +1,1830 @@
+package compiler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	gen "github.com/itsubaki/qasm/gen/parser"
+	"github.com/itsubaki/quasar/circuit"
+)
+
+var (
+	ErrUnsupported      = errors.New("unsupported")
+	ErrGateNotFound     = errors.New("gate not found")
+	ErrNotFound         = errors.New("identifier not found")
+	ErrInvalidOperand   = errors.New("invalid operand")
+	ErrInvalidParams    = errors.New("invalid params")
+	ErrTooManyQubits    = errors.New("too many qubits")
+	ErrTooManyOps       = errors.New("too many operations")
+	ErrAlreadyDeclared  = errors.New("already declared")
+	ErrClassicalControl = errors.New("classical control is not supported")
+)
+
+const (
+	maxDepth = 256     // the maximum depth of the nested gate and subroutine calls
+	maxPower = 1 << 30 // the maximum integer exponent of the pow modifier
+)
+
+var (
+	errBreak    = errors.New("break")
+	errContinue = errors.New("continue")
+	errEnd      = errors.New("end")
+)
+
+// returned is the return statement of a subroutine with the value, or nil.
+// measured is true if the value is the outcome of "return measure q;".
+type returned struct {
+	value    any
+	measured bool
+}
+
+func (r *returned) Error() string {
+	return "return"
+}
+
+// Machine runs the operations as the compiler emits them, so that the program can read the measured bits.
+// e.g. the state vector, the density matrix, the stabilizer tableau or the matrix product state.
+type Machine interface {
+	// Grow adds n qubits in |0> after the last qubit.
+	Grow(n int) error
+
+	// Run runs the operation and returns the measured bit, or 0 if the operation is not a measurement.
+	Run(op circuit.Op) (int, error)
+}
+
+type Compiler struct {
+	circuit   *circuit.Circuit
+	global    *scope
+	gates     map[string]*gen.GateStatementContext
+	defs      map[string]*gen.DefStatementContext
+	inputs    map[string]any
+	maxQubits int
+	maxOps    int
+	call      *circuit.Call
+	ctx       context.Context
+	machine   Machine
+	step      func(line, column int) error
+	values    []int  // the values of the classical bits
+	measured  []bool // the classical bits written by a measurement
+	feedback  bool   // a measured bit has been read
+	branches  bool   // emit both branches on measured bits without a machine
+	reading   bool   // the condition of an if or while statement is being evaluated
+	unknown   bool   // the condition has read a measured bit that is not known
+	cond      *circuit.Condition
+	root      *scope // the body of the outermost branch on measured bits
+	expanding int    // the depth of the gate bodies being expanded
+	depth     int    // the depth of the gate and subroutine calls
+	last      int    // the outcome of the last measurement
+	loops     int    // the iterations of the loops
+	line      int    // the position of the innermost statement
+	column    int
+}
+
+type Option func(*Compiler)
+
+func WithInputs(inputs map[string]any) Option {
+	return func(c *Compiler) {
+		c.inputs = inputs
+	}
+}
+
+func WithMaxQubits(n int) Option {
+	return func(c *Compiler) {
+		c.maxQubits = n
+	}
+}
+
+// WithMaxOps limits the operations and the iterations of the loops, or 0 for no limit.
+func WithMaxOps(n int) Option {
+	return func(c *Compiler) {
+		c.maxOps = n
+	}
+}
+
+// WithContext stops the compilation when the context is done.
+func WithContext(ctx context.Context) Option {
+	return func(c *Compiler) {
+		c.ctx = ctx
+	}
+}
+
+// WithMachine runs the operations on the machine as they are emitted.
+// Without a machine, reading a measured bit returns ErrClassicalControl.
+func WithMachine(m Machine) Option {
+	return func(c *Compiler) {
+		c.machine = m
+	}
+}
+
+// WithBranches emits the operations of both branches of an if statement,
+// and of the body of a while loop once, if the condition reads a measured bit without a machine.
+// The operations have the condition of the branch. A branch cannot assign the classical variables outside it,
+// or break, continue, return or end. Reading a measured bit elsewhere still returns ErrClassicalControl.
+func WithBranches() Option {
+	return func(c *Compiler) {
+		c.branches = true
+	}
+}
+
+// WithStep calls f with the position of each statement before it is run, and of each loop before each iteration.
+// The statements in the body of a gate are expanded, not run. The compilation stops if f returns an error.
+func WithStep(f func(line, column int) error) Option {
+	return func(c *Compiler) {
+		c.step = f
+	}
+}
+
+// Compile lowers the parsed program into a circuit of primitive operations.
+// User-defined gates, subroutines and loops are expanded.
+func Compile(program antlr.Tree, opts ...Option) (*circuit.Circuit, error) {
+	return New(opts...).Compile(program)
+}
+
+// New returns a compiler with the options.
+func New(opts ...Option) *Compiler {
+	c := &Compiler{
+		circuit: &circuit.Circuit{},
+		global:  newScope(nil),
+		gates:   make(map[string]*gen.GateStatementContext),
+		defs:    make(map[string]*gen.DefStatementContext),
+		inputs:  make(map[string]any),
+		ctx:     context.Background(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Compile lowers the parsed program into a circuit of primitive operations.
+// With a machine, the circuit is the operations that have been run.
+func (c *Compiler) Compile(program antlr.Tree) (*circuit.Circuit, error) {
+	if err := c.exec(program, c.global); err != nil && !errors.Is(err, errEnd) {
+		return nil, err
+	}
+
+	return c.circuit, nil
+}
+
+// Circuit returns the circuit compiled so far.
+func (c *Compiler) Circuit() *circuit.Circuit {
+	return c.circuit
+}
+
+// Env is the classical state of a program.
+type Env struct {
+	Bits      []int          // the values of the classical bits
+	Variables map[string]any // the variables at the top level, int64, float64 or bool
+	Feedback  bool           // the program has read a measured bit
+}
+
+// Env returns the classical state of the program compiled so far.
+func (c *Compiler) Env() *Env {
+	return &Env{
+		Bits:      slices.Clone(c.values),
+		Variables: maps.Clone(c.global.vars),
+		Feedback:  c.feedback,
+	}
+}
+
+type scope struct {
+	vars   map[string]any
+	qubits map[string][]int
+	bits   map[string][]int
+	outer  *scope
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{
+		vars:   make(map[string]any),
+		qubits: make(map[string][]int),
+		bits:   make(map[string][]int),
+		outer:  outer,
+	}
+}
+
+func (s *scope) variable(name string) (any, *scope, bool) {
+	for sc := s; sc != nil; sc = sc.outer {
+		if v, ok := sc.vars[name]; ok {
+			return v, sc, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func (s *scope) qubit(name string) ([]int, bool) {
+	for sc := s; sc != nil; sc = sc.outer {
+		if q, ok := sc.qubits[name]; ok {
+			return q, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *scope) bit(name string) ([]int, bool) {
+	for sc := s; sc != nil; sc = sc.outer {
+		if b, ok := sc.bits[name]; ok {
+			return b, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *scope) declared(name string) bool {
+	_, v := s.vars[name]
+	_, q := s.qubits[name]
+	_, b := s.bits[name]
+	return v || q || b
+}
+
+// CompileGate lowers the user-defined gate applied to qubits 0, ..., n-1 with the params,
+// where n is the number of qubit arguments of the gate.
+// Only the gate and constant declarations at the top level of the program are executed.
+func CompileGate(program antlr.Tree, name string, params []float64, opts ...Option) (*circuit.Circuit, error) {
+	c := New(opts...)
+	if err := c.declarations(program); err != nil {
+		return nil, err
+	}
+
+	g, ok := c.gates[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrGateNotFound)
+	}
+
+	n := len(g.GetQubits().AllIdentifier())
+	if c.maxQubits > 0 && n > c.maxQubits {
+		return nil, fmt.Errorf("need=%d, max=%d: %w", n, c.maxQubits, ErrTooManyQubits)
+	}
+
+	qubits := make([]int, n)
+	for i := range qubits {
+		qubits[i] = i
+	}
+
+	c.circuit.Qubits = n
+	c.circuit.QRegs = []circuit.Register{{Name: name, Index: qubits}}
+	c.call = &circuit.Call{
+		Name:   name,
+		Params: params,
+		Qubits: qubits,
+		Line:   g.GetStart().GetLine(),
+		Column: g.GetStart().GetColumn(),
+		Gate:   name,
+		Power:  1,
+	}
+
+	ops, err := c.apply(name, params, nil, qubits)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.emit(ops...); err != nil {
+		return nil, err
+	}
+
+	return c.circuit, nil
+}
+
+// declarations executes the gate and constant declarations at the top level.
+func (c *Compiler) declarations(tree antlr.Tree) error {
+	switch tree.(type) {
+	case *gen.GateStatementContext, *gen.ConstDeclarationStatementContext:
+		return c.exec(tree, c.global)
+	case *gen.ScopeContext:
+		return nil
+	}
+
+	for _, child := range tree.GetChildren() {
+		if err := c.declarations(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emit appends the operations to the circuit, and runs them on the machine unless a gate body is being expanded.
+func (c *Compiler) emit(ops ...circuit.Op) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	if c.maxOps > 0 && len(c.circuit.Ops)+len(ops) > c.maxOps {
+		return fmt.Errorf("max=%d: %w", c.maxOps, ErrTooManyOps)
+	}
+
+	for i := range ops {
+		if ops[i].Line == 0 {
+			ops[i].Line, ops[i].Column = c.line, c.column
+		}
+
+		if ops[i].Condition == nil {
+			ops[i].Condition = c.cond
+		}
+	}
+
+	c.circuit.Ops = append(c.circuit.Ops, ops...)
+	if c.expanding > 0 {
+		return nil
+	}
+
+	for _, op := range ops {
+		var bit int
+		if c.machine != nil {
+			v, err := c.machine.Run(op)
+			if err != nil {
+				return err
+			}
+
+			bit = v
+		}
+
+		if op.Kind != circuit.Measure {
+			continue
+		}
+
+		c.last = bit
+		if op.Clbit >= 0 {
+			c.values[op.Clbit], c.measured[op.Clbit] = bit, true
+		}
+	}
+
+	return nil
+}
+
+// tick counts an iteration of a loop at the statement.
+func (c *Compiler) tick(ctx antlr.ParserRuleContext) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	if c.loops++; c.maxOps > 0 && c.loops > c.maxOps {
+		return fmt.Errorf("max=%d: %w", c.maxOps, ErrTooManyOps)
+	}
+
+	if c.step == nil || c.expanding > 0 {
+		return nil
+	}
+
+	return c.step(ctx.GetStart().GetLine(), ctx.GetStart().GetColumn())
+}
+
+// statement runs the statement as the innermost position of the emitted operations.
+func (c *Compiler) statement(ctx *gen.StatementContext, sc *scope) error {
+	line, column := c.line, c.column
+	c.line, c.column = ctx.GetStart().GetLine(), ctx.GetStart().GetColumn()
+	defer func() { c.line, c.column = line, column }()
+
+	if c.step != nil && c.expanding == 0 {
+		if err := c.step(c.line, c.column); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range ctx.GetChildren() {
+		if err := c.exec(child, sc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Compiler) exec(tree antlr.Tree, sc *scope) error {
+	switch ctx := tree.(type) {
+	case *gen.ProgramContext, *gen.StatementOrScopeContext:
+		for _, child := range tree.GetChildren() {
+			if err := c.exec(child, sc); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *gen.StatementContext:
+		return c.statement(ctx, sc)
+	case *gen.ScopeContext:
+		return c.scope(ctx, newScope(sc))
+	case antlr.TerminalNode,
+		*gen.VersionContext,
+		*gen.PragmaContext,
+		*gen.AnnotationContext,
+		*gen.DelayStatementContext,
+		*gen.CalibrationGrammarStatementContext:
+		return nil
+	case *gen.QuantumDeclarationStatementContext:
+		return c.declareQubits(ctx.Identifier().GetText(), ctx.QubitType().Designator(), sc)
+	case *gen.OldStyleDeclarationStatementContext:
+		if ctx.QREG() != nil {
+			return c.declareQubits(ctx.Identifier().GetText(), ctx.Designator(), sc)
+		}
+
+		return c.declareBits(ctx.Identifier().GetText(), ctx.Designator(), sc)
+	case *gen.ClassicalDeclarationStatementContext:
+		return c.classicalDeclaration(ctx, sc)
+	case *gen.ConstDeclarationStatementContext:
+		return c.constDeclaration(ctx, sc)
+	case *gen.IoDeclarationStatementContext:
+		return c.ioDeclaration(ctx, sc)
+	case *gen.GateStatementContext:
+		c.gates[ctx.Identifier().GetText()] = ctx
+		return nil
+	case *gen.DefStatementContext:
+		c.defs[ctx.Identifier().GetText()] = ctx
+		return nil
+	case *gen.GateCallStatementContext:
+		return c.gateCall(ctx, sc)
+	case *gen.MeasureArrowAssignmentStatementContext:
+		return c.at(ctx, "measure", func() error {
+			return c.measure(ctx.MeasureExpression(), ctx.IndexedIdentifier(), sc)
+		})
+	case *gen.AssignmentStatementContext:
+		return c.assignment(ctx, sc)
+	case *gen.ResetStatementContext:
+		return c.at(ctx, "reset", func() error {
+			return c.reset(ctx, sc)
+		})
+	case *gen.BarrierStatementContext:
+		return c.at(ctx, "barrier", func() error {
+			return c.barrier(ctx, sc)
+		})
+	case *gen.ForStatementContext:
+		return c.forStatement(ctx, sc)
+	case *gen.WhileStatementContext:
+		return c.whileStatement(ctx, sc)
+	case *gen.IfStatementContext:
+		return c.ifStatement(ctx, sc)
+	case *gen.BoxStatementContext:
+		return c.scope(ctx.Scope(), newScope(sc))
+	case *gen.AliasDeclarationStatementContext:
+		return c.alias(ctx, sc)
+	case *gen.ExpressionStatementContext:
+		return c.expressionStatement(ctx, sc)
+	case *gen.ReturnStatementContext:
+		return c.returnStatement(ctx, sc)
+	case *gen.BreakStatementContext:
+		return errBreak
+	case *gen.ContinueStatementContext:
+		return errContinue
+	case *gen.EndStatementContext:
+		return errEnd
+	default:
+		if rule, ok := tree.(antlr.ParserRuleContext); ok {
+			return fmt.Errorf("%d:%d: %s: %w", rule.GetStart().GetLine(), rule.GetStart().GetColumn(), rule.GetText(), ErrUnsupported)
+		}
+
+		return fmt.Errorf("%T: %w", tree, ErrUnsupported)
+	}
+}
+
+// at runs f with the statement as the source of the emitted operations,
+// unless the statement is inside a gate call or subroutine.
+func (c *Compiler) at(ctx antlr.ParserRuleContext, name string, f func() error) error {
+	if c.call != nil {
+		return f()
+	}
+
+	c.call = &circuit.Call{
+		Name:   name,
+		Line:   ctx.GetStart().GetLine(),
+		Column: ctx.GetStart().GetColumn(),
+	}
+	defer func() { c.call = nil }()
+
+	return f()
+}
+
+func (c *Compiler) scope(ctx gen.IScopeContext, sc *scope) error {
+	for _, s := range ctx.AllStatementOrScope() {
+		if err := c.exec(s, sc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Compiler) size(designator gen.IDesignatorContext, sc *scope) (int, error) {
+	if designator == nil {
+		return 1, nil
+	}
+
+	v, err := c.eval(designator.Expression(), sc)
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := v.(int64)
+	if !ok || n < 1 {
+		return 0, fmt.Errorf("size=%v: %w", v, ErrInvalidOperand)
+	}
+
+	return int(n), nil
+}
+
+func (c *Compiler) declareQubits(name string, designator gen.IDesignatorContext, sc *scope) error {
+	if sc.declared(name) {
+		return fmt.Errorf("%s: %w", name, ErrAlreadyDeclared)
+	}
+
+	n, err := c.size(designator, sc)
+	if err != nil {
+		return err
+	}
+
+	if c.maxQubits > 0 && c.circuit.Qubits+n > c.maxQubits {
+		return fmt.Errorf("need=%d, max=%d: %w", c.circuit.Qubits+n, c.maxQubits, ErrTooManyQubits)
+	}
+
+	if c.machine != nil {
+		if err := c.machine.Grow(n); err != nil {
+			return err
+		}
+	}
+
+	index := make([]int, n)
+	for i := range index {
+		index[i] = c.circuit.Qubits + i
+	}
+
+	c.circuit.Qubits += n
+	c.circuit.QRegs = append(c.circuit.QRegs, circuit.Register{Name: name, Index: index})
+	sc.qubits[name] = index
+	return nil
+}
+
+func (c *Compiler) declareBits(name string, designator gen.IDesignatorContext, sc *scope) error {
+	if sc.declared(name) {
+		return fmt.Errorf("%s: %w", name, ErrAlreadyDeclared)
+	}
+
+	n, err := c.size(designator, sc)
+	if err != nil {
+		return err
+	}
+
+	index := make([]int, n)
+	for i := range index {
+		index[i] = c.circuit.Clbits + i
+	}
+
+	c.circuit.Clbits += n
+	c.circuit.CRegs = append(c.circuit.CRegs, circuit.Register{Name: name, Index: index})
+	c.values = append(c.values, make([]int, n)...)
+	c.measured = append(c.measured, make([]bool, n)...)
+	sc.bits[name] = index
+	return nil
+}
+
+func (c *Compiler) classicalDeclaration(ctx *gen.ClassicalDeclarationStatementContext, sc *scope) error {
+	name := ctx.Identifier().GetText()
+	if ctx.ScalarType() != nil && ctx.ScalarType().BIT() != nil {
+		if err := c.declareBits(name, ctx.ScalarType().Designator(), sc); err != nil {
+			return err
+		}
+
+		decl := ctx.DeclarationExpression()
+		if decl == nil {
+			return nil
+		}
+
+		bits, _ := sc.bit(name)
+		if decl.MeasureExpression() == nil {
+			// bit[2] c = "01";
+			v, err := c.declarationExpression(decl, sc)
+			if err != nil {
+				return err
+			}
+
+			c.write(bits, toInt(v))
+			return nil
+		}
+
+		qubits, err := c.operand(decl.MeasureExpression().GateOperand(), sc)
+		if err != nil {
+			return err
+		}
+
+		return c.measureInto(qubits, bits)
+	}
+
+	if sc.declared(name) {
+		return fmt.Errorf("%s: %w", name, ErrAlreadyDeclared)
+	}
+
+	if ctx.DeclarationExpression() == nil {
+		sc.vars[name] = zero(ctx.ScalarType())
+		return nil
+	}
+
+	v, err := c.declarationExpression(ctx.DeclarationExpression(), sc)
+	if err != nil {
+		return err
+	}
+
+	sc.vars[name] = cast(ctx.ScalarType(), v)
+	return nil
+}
+
+func (c *Compiler) constDeclaration(ctx *gen.ConstDeclarationStatementContext, sc *scope) error {
+	name := ctx.Identifier().GetText()
+	if sc.declared(name) {
+		return fmt.Errorf("%s: %w", name, ErrAlreadyDeclared)
+	}
+
+	v, err := c.declarationExpression(ctx.DeclarationExpression(), sc)
+	if err != nil {
+		return err
+	}
+
+	sc.vars[name] = cast(ctx.ScalarType(), v)
+	return nil
+}
+
+func (c *Compiler) ioDeclaration(ctx *gen.IoDeclarationStatementContext, sc *scope) error {
+	name := ctx.Identifier().GetText()
+	if ctx.ScalarType() != nil && ctx.ScalarType().BIT() != nil {
+		if err := c.declareBits(name, ctx.ScalarType().Designator(), sc); err != nil {
+			return err
+		}
+
+		if ctx.INPUT() == nil {
+			return nil
+		}
+
+		// input bit[2] b; the value is written to the bits, the first bit is the least significant
+		v, ok := c.inputs[name]
+		if !ok {
+			return fmt.Errorf("input %s: %w", name, ErrNotFound)
+		}
+
+		bits, _ := sc.bit(name)
+		c.write(bits, toInt(v))
+		return nil
+	}
+
+	if ctx.INPUT() != nil {
+		v, ok := c.inputs[name]
+		if !ok {
+			return fmt.Errorf("input %s: %w", name, ErrNotFound)
+		}
+
+		sc.vars[name] = cast(ctx.ScalarType(), v)
+		return nil
+	}
+
+	sc.vars[name] = zero(ctx.ScalarType())
+	return nil
+}
+
+func (c *Compiler) declarationExpression(ctx gen.IDeclarationExpressionContext, sc *scope) (any, error) {
+	if ctx.Expression() == nil {
+		return nil, fmt.Errorf("%s: %w", ctx.GetText(), ErrUnsupported)
+	}
+
+	return c.eval(ctx.Expression(), sc)
+}
+
+type modifier struct {
+	kind string // inv, pow, ctrl, negctrl
+	pow  float64
+	n    int
+}
+
+func (c *Compiler) modifiers(mods []gen.IGateModifierContext, sc *scope) ([]modifier, error) {
+	out := make([]modifier, len(mods))
+	for i, m := range mods {
+		switch {
+		case m.INV() != nil:
+			out[i] = modifier{kind: "inv"}
+		case m.POW() != nil:
+			v, err := c.eval(m.Expression(), sc)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = modifier{kind: "pow", pow: toFloat(v)}
+		default:
+			n := int64(1)
+			if m.Expression() != nil {
+				v, err := c.eval(m.Expression(), sc)
+				if err != nil {
+					return nil, err
+				}
+
+				n = toInt(v)
+			}
+
+			kind := "ctrl"
+			if m.NEGCTRL() != nil {
+				kind = "negctrl"
+			}
+
+			if n < 1 || n > math.MaxInt32 {
+				return nil, fmt.Errorf("%s(%d): %w", kind, n, ErrInvalidOperand)
+			}
+
+			out[i] = modifier{kind: kind, n: int(n)}
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Compiler) gateCall(ctx *gen.GateCallStatementContext, sc *scope) error {
+	mods, err := c.modifiers(ctx.AllGateModifier(), sc)
+	if err != nil {
+		return err
+	}
+
+	name := "gphase"
+	if ctx.Identifier() != nil {
+		name = ctx.Identifier().GetText()
+	}
+
+	var params []float64
+	if ctx.ExpressionList() != nil {
+		for _, e := range ctx.ExpressionList().AllExpression() {
+			v, err := c.eval(e, sc)
+			if err != nil {
+				return err
+			}
+
+			params = append(params, toFloat(v))
+		}
+	}
+
+	var operands [][]int
+	if ctx.GateOperandList() != nil {
+		for _, o := range ctx.GateOperandList().AllGateOperand() {
+			q, err := c.operand(o, sc)
+			if err != nil {
+				return err
+			}
+
+			operands = append(operands, q)
+		}
+	}
+
+	// label. e.g. ctrl @ h
+	label := name
+	for i := len(ctx.AllGateModifier()) - 1; i >= 0; i-- {
+		label = fmt.Sprintf("%s @ %s", strings.TrimSuffix(ctx.GateModifier(i).GetText(), "@"), label)
+	}
+
+	// broadcast
+	n := 1
+	for _, o := range operands {
+		if len(o) == 1 {
+			continue
+		}
+
+		if n > 1 && len(o) != n {
+			return fmt.Errorf("%s: %w", ctx.GetText(), ErrInvalidOperand)
+		}
+
+		n = len(o)
+	}
+
+	for k := range n {
+		qubits := make([]int, len(operands))
+		for i, o := range operands {
+			qubits[i] = o[0]
+			if len(o) > 1 {
+				qubits[i] = o[k]
+			}
+		}
+
+		top := c.call == nil
+		if top {
+			c.call = &circuit.Call{
+				Name:   label,
+				Params: params,
+				Qubits: qubits,
+				Line:   ctx.GetStart().GetLine(),
+				Column: ctx.GetStart().GetColumn(),
+				Gate:   name,
+			}
+
+			c.call.Controls, c.call.NegControls, c.call.Power = split(mods, qubits)
+		}
+
+		ops, err := c.apply(name, params, mods, qubits)
+		if top {
+			c.call = nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := c.emit(ops...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// split returns the qubits of the ctrl and negctrl modifiers, and the exponent of the inv and pow modifiers.
+// The leftmost control modifier takes the first qubits.
+func split(mods []modifier, qubits []int) (ctrl, negctrl []int, power float64) {
+	power = 1
+
+	var offset int
+	for _, m := range mods {
+		switch m.kind {
+		case "inv":
+			power = -power
+		case "pow":
+			power *= m.pow
+		case "ctrl", "negctrl":
+			if m.n < 0 || offset+m.n > len(qubits) {
+				// invalid operands are reported by apply
+				continue
+			}
+
+			if m.kind == "ctrl" {
+				ctrl = append(ctrl, qubits[offset:offset+m.n]...)
+			} else {
+				negctrl = append(negctrl, qubits[offset:offset+m.n]...)
+			}
+
+			offset += m.n
+		}
+	}
+
+	return ctrl, negctrl, power
+}
+
+// apply returns the primitive operations of the modified gate applied to the qubits.
+func (c *Compiler) apply(name string, params []float64, mods []modifier, qubits []int) ([]circuit.Op, error) {
+	var nctrl int
+	for _, m := range mods {
+		nctrl = add(nctrl, m.n)
+	}
+
+	if len(qubits) < nctrl {
+		return nil, fmt.Errorf("%s: %w", name, ErrInvalidOperand)
+	}
+
+	for i := range qubits {
+		if slices.Contains(qubits[i+1:], qubits[i]) {
+			return nil, fmt.Errorf("%s: duplicate qubit: %w", name, ErrInvalidOperand)
+		}
+	}
+
+	ops, err := c.base(name, params, qubits[nctrl:])
+	if err != nil {
+		return nil, err
+	}
+
+	// the leftmost modifier is the outermost
+	offset := nctrl
+	for i := len(mods) - 1; i >= 0; i-- {
+		m := mods[i]
+		switch m.kind {
+		case "inv":
+			ops = inverse(ops)
+		case "pow":
+			ops, err = c.pow(ops, m.pow)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		case "ctrl", "negctrl":
+			offset -= m.n
+			ops = control(ops, qubits[offset:offset+m.n], m.kind == "negctrl")
+		}
+	}
+
+	return ops, nil
+}
+
+func (c *Compiler) base(name string, params []float64, qubits []int) ([]circuit.Op, error) {
+	switch name {
+	case "U":
+		if len(params) != 3 || len(qubits) != 1 {
+			return nil, fmt.Errorf("%s: %w", name, ErrInvalidParams)
+		}
+
+		return []circuit.Op{{
+			Kind:   circuit.Gate,
+			Theta:  params[0],
+			Phi:    params[1],
+			Lambda: params[2],
+			Target: qubits[0],
+			Clbit:  -1,
+			Call:   c.call,
+		}}, nil
+	case "gphase":
+		if len(params) != 1 {
+			return nil, fmt.Errorf("%s: %w", name, ErrInvalidParams)
+		}
+
+		return []circuit.Op{{
+			Kind:  circuit.GlobalPhase,
+			Phase: params[0],
+			Clbit: -1,
+			Call:  c.call,
+		}}, nil
+	}
+
+	g, ok := c.gates[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrGateNotFound)
+	}
+
+	var pnames []string
+	if g.GetParams() != nil {
+		for _, id := range g.GetParams().AllIdentifier() {
+			pnames = append(pnames, id.GetText())
+		}
+	}
+
+	var qnames []string
+	for _, id := range g.GetQubits().AllIdentifier() {
+		qnames = append(qnames, id.GetText())
+	}
+
+	if len(pnames) != len(params) || len(qnames) != len(qubits) {
+		return nil, fmt.Errorf("%s: %w", name, ErrInvalidParams)
+	}
+
+	sc := newScope(c.global)
+	for i, p := range pnames {
+		sc.vars[p] = params[i]
+	}
+
+	for i, q := range qnames {
+		sc.qubits[q] = []int{qubits[i]}
+	}
+
+	if c.depth >= maxDepth {
+		return nil, fmt.Errorf("%s: depth=%d: %w", name, maxDepth, ErrUnsupported)
+	}
+
+	// expand the gate body into a separate list
+	saved := c.circuit.Ops
+	c.circuit.Ops = nil
+	c.expanding++
+	c.depth++
+	defer func() {
+		c.circuit.Ops = saved
+		c.expanding--
+		c.depth--
+	}()
+
+	if err := c.scope(g.Scope(), sc); err != nil {
+		return nil, err
+	}
+
+	if c.maxOps > 0 && len(saved)+len(c.circuit.Ops) > c.maxOps {
+		return nil, fmt.Errorf("max=%d: %w", c.maxOps, ErrTooManyOps)
+	}
+
+	return c.circuit.Ops, nil
+}
+
+func inverse(ops []circuit.Op) []circuit.Op {
+	out := make([]circuit.Op, len(ops))
+	for i, op := range ops {
+		out[len(ops)-1-i] = op.Inverse()
+	}
+
+	return out
+}
+
+// pow returns the operations raised to the power of p.
+// An integer power repeats the operations, and is checked against the limit before they are allocated.
+func (c *Compiler) pow(ops []circuit.Op, p float64) ([]circuit.Op, error) {
+	if math.IsNaN(p) || math.IsInf(p, 0) {
+		return nil, fmt.Errorf("pow(%v): %w", p, ErrInvalidOperand)
+	}
+
+	if p == math.Trunc(p) {
+		if p < 0 {
+			ops, p = inverse(ops), -p
+		}
+
+		if p > maxPower {
+			return nil, fmt.Errorf("pow(%v): max=%d: %w", p, maxPower, ErrInvalidOperand)
+		}
+
+		if n := Mul(len(ops), int(p)); c.maxOps > 0 && n > c.maxOps {
+			return nil, fmt.Errorf("max=%d: %w", c.maxOps, ErrTooManyOps)
+		}
+
+		out := make([]circuit.Op, 0, len(ops)*int(p))
+		for range int(p) {
+			out = append(out, ops...)
+		}
+
+		return out, nil
+	}
+
+	if len(ops) == 1 && ops[0].Kind == circuit.GlobalPhase {
+		op := ops[0]
+		op.Phase *= p
+		return []circuit.Op{op}, nil
+	}
+
+	if len(ops) == 1 && ops[0].Kind == circuit.Gate && len(ops[0].Controls)+len(ops[0].NegControls) == 0 {
+		op := ops[0]
+		op.Theta, op.Phi, op.Lambda, op.Phase = circuit.ZYZ(circuit.Pow(op.Matrix(), p))
+		return []circuit.Op{op}, nil
+	}
+
+	return nil, fmt.Errorf("non-integer power of a multi-qubit gate: %w", ErrUnsupported)
+}
+
+func control(ops []circuit.Op, qubits []int, neg bool) []circuit.Op {
+	out := make([]circuit.Op, len(ops))
+	for i, op := range ops {
+		switch op.Kind {
+		case circuit.Gate:
+			if neg {
+				op.NegControls = append(slices.Clone(op.NegControls), qubits...)
+			} else {
+				op.Controls = append(slices.Clone(op.Controls), qubits...)
+			}
+		case circuit.GlobalPhase:
+			// controlled global phase is a phase gate on the last control
+			last, rest := qubits[len(qubits)-1], qubits[:len(qubits)-1]
+			theta := op.Phase
+			op = circuit.Op{
+				Kind:   circuit.Gate,
+				Lambda: theta,
+				Target: last,
+				Clbit:  -1,
+				Call:   op.Call,
+				Line:   op.Line,
+				Column: op.Column,
+			}
+
+			if neg {
+				// diag(exp(i*theta), 1)
+				op.Lambda, op.Phase = -theta, theta
+				op.NegControls = slices.Clone(rest)
+			} else {
+				// diag(1, exp(i*theta))
+				op.Controls = slices.Clone(rest)
+			}
+		}
+
+		out[i] = op
+	}
+
+	return out
+}
+
+func (c *Compiler) measure(ctx gen.IMeasureExpressionContext, target gen.IIndexedIdentifierContext, sc *scope) error {
+	qubits, err := c.operand(ctx.GateOperand(), sc)
+	if err != nil {
+		return err
+	}
+
+	if target == nil {
+		bits := make([]int, len(qubits))
+		for i := range bits {
+			bits[i] = -1
+		}
+
+		return c.measureInto(qubits, bits)
+	}
+
+	bits, err := c.bits(target, sc)
+	if err != nil {
+		return err
+	}
+
+	return c.measureInto(qubits, bits)
+}
+
+func (c *Compiler) measureInto(qubits, bits []int) error {
+	if len(qubits) != len(bits) {
+		return fmt.Errorf("qubits=%d, bits=%d: %w", len(qubits), len(bits), ErrInvalidOperand)
+	}
+
+	for i := range qubits {
+		if err := c.emit(circuit.Op{
+			Kind:   circuit.Measure,
+			Target: qubits[i],
+			Clbit:  bits[i],
+			Call:   c.call,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Compiler) assignment(ctx *gen.AssignmentStatementContext, sc *scope) error {
+	if ctx.MeasureExpression() != nil {
+		return c.at(ctx, "measure", func() error {
+			return c.measure(ctx.MeasureExpression(), ctx.IndexedIdentifier(), sc)
+		})
+	}
+
+	name := ctx.IndexedIdentifier().Identifier().GetText()
+	if _, ok := sc.bit(name); ok {
+		return c.assignBits(ctx, sc)
+	}
+
+	if len(ctx.IndexedIdentifier().AllIndexOperator()) > 0 {
+		return fmt.Errorf("%s: %w", ctx.GetText(), ErrUnsupported)
+	}
+
+	old, owner, ok := sc.variable(name)
+	if !ok {
+		return fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	if c.outside(owner) {
+		// the value depends on the branch taken
+		return fmt.Errorf("%s: %w", name, ErrClassicalControl)
+	}
+
+	v, err := c.eval(ctx.Expression(), sc)
+	if err != nil {
+		return err
+	}
+
+	op := ctx.GetOp().GetText()
+	if op != "=" {
+		v, err = binary(strings.TrimSuffix(op, "="), old, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	owner.vars[name] = v
+	return nil
+}
+
+// assignBits assigns the value to the classical bits. e.g. c[0] = 1; c ^= 3;
+func (c *Compiler) assignBits(ctx *gen.AssignmentStatementContext, sc *scope) error {
+	bits, err := c.bits(ctx.IndexedIdentifier(), sc)
+	if err != nil {
+		return err
+	}
+
+	v, err := c.eval(ctx.Expression(), sc)
+	if err != nil {
+		return err
+	}
+
+	op := ctx.GetOp().GetText()
+	if op != "=" {
+		old, err := c.read(ctx.IndexedIdentifier().GetText(), bits)
+		if err != nil {
+			return err
+		}
+
+		v, err = binary(strings.TrimSuffix(op, "="), old, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.write(bits, toInt(v))
+	return nil
+}
+
+func (c *Compiler) reset(ctx *gen.ResetStatementContext, sc *scope) error {
+	qubits, err := c.operand(ctx.GateOperand(), sc)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range qubits {
+		if err := c.emit(circuit.Op{
+			Kind:   circuit.Reset,
+			Target: q,
+			Clbit:  -1,
+			Call:   c.call,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Compiler) barrier(ctx *gen.BarrierStatementContext, sc *scope) error {
+	var qubits []int
+	if ctx.GateOperandList() == nil {
+		for i := range c.circuit.Qubits {
+			qubits = append(qubits, i)
+		}
+	} else {
+		for _, o := range ctx.GateOperandList().AllGateOperand() {
+			q, err := c.operand(o, sc)
+			if err != nil {
+				return err
+			}
+
+			qubits = append(qubits, q...)
+		}
+	}
+
+	return c.emit(circuit.Op{
+		Kind:   circuit.Barrier,
+		Qubits: qubits,
+		Clbit:  -1,
+		Call:   c.call,
+	})
+}
+
+func (c *Compiler) forStatement(ctx *gen.ForStatementContext, sc *scope) error {
+	var values []any
+	switch {
+	case ctx.RangeExpression() != nil:
+		r, err := c.rangeValues(ctx.RangeExpression(), -1, sc)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range r {
+			values = append(values, int64(v))
+		}
+	case ctx.SetExpression() != nil:
+		for _, e := range ctx.SetExpression().AllExpression() {
+			v, err := c.eval(e, sc)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, v)
+		}
+	default:
+		return fmt.Errorf("%s: %w", ctx.GetText(), ErrUnsupported)
+	}
+
+	name := ctx.Identifier().GetText()
+	for _, v := range values {
+		if err := c.tick(ctx); err != nil {
+			return err
+		}
+
+		inner := newScope(sc)
+		inner.vars[name] = v
+		if err := c.exec(ctx.GetBody(), inner); err != nil {
+			if errors.Is(err, errBreak) {
+				return nil
+			}
+
+			if !errors.Is(err, errContinue) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Compiler) whileStatement(ctx *gen.WhileStatementContext, sc *scope) error {
+	for {
+		v, known, err := c.condition(ctx.Expression(), sc)
+		if err != nil {
+			return err
+		}
+
+		if known && !v {
+			return nil
+		}
+
+		if err := c.tick(ctx); err != nil {
+			return err
+		}
+
+		if !known {
+			// the body is emitted once
+			return c.branch(&circuit.Condition{
+				Expr:  ctx.Expression().GetText(),
+				Loop:  true,
+				Outer: c.cond,
+			}, ctx.GetBody(), sc)
+		}
+
+		if err := c.exec(ctx.GetBody(), newScope(sc)); err != nil {
+			if errors.Is(err, errBreak) {
+				return nil
+			}
+
+			if !errors.Is(err, errContinue) {
+				return err
+			}
+		}
+	}
+}
+
+func (c *Compiler) ifStatement(ctx *gen.IfStatementContext, sc *scope) error {
+	v, known, err := c.condition(ctx.Expression(), sc)
+	if err != nil {
+		return err
+	}
+
+	if !known {
+		outer, expr := c.cond, ctx.Expression().GetText()
+		if err := c.branch(&circuit.Condition{Expr: expr, Outer: outer}, ctx.GetIf_body(), sc); err != nil {
+			return err
+		}
+
+		if ctx.GetElse_body() == nil {
+			return nil
+		}
+
+		return c.branch(&circuit.Condition{Expr: expr, Else: true, Outer: outer}, ctx.GetElse_body(), sc)
+	}
+
+	if v {
+		return c.exec(ctx.GetIf_body(), newScope(sc))
+	}
+
+	if ctx.GetElse_body() != nil {
+		return c.exec(ctx.GetElse_body(), newScope(sc))
+	}
+
+	return nil
+}
+
+// condition evaluates the condition of an if or while statement.
+// The value is not known if the condition reads a measured bit without a machine, see WithBranches.
+func (c *Compiler) condition(ctx gen.IExpressionContext, sc *scope) (v, known bool, err error) {
+	c.reading, c.unknown = c.branches && c.machine == nil, false
+	defer func() { c.reading = false }()
+
+	x, err := c.eval(ctx, sc)
+	if err != nil {
+		return false, false, err
+	}
+
+	return toBool(x), !c.unknown, nil
+}
+
+// branch emits the operations of the body with the condition.
+func (c *Compiler) branch(cond *circuit.Condition, body antlr.Tree, sc *scope) error {
+	inner := newScope(sc)
+	outer, root := c.cond, c.root
+	c.cond = cond
+	if root == nil {
+		c.root = inner
+	}
+	defer func() { c.cond, c.root = outer, root }()
+
+	err := c.exec(body, inner)
+	if _, ok := errors.AsType[*returned](err); ok || errors.Is(err, errBreak) || errors.Is(err, errContinue) || errors.Is(err, errEnd) {
+		return fmt.Errorf("%s: %w", cond.Expr, ErrClassicalControl)
+	}
+
+	return err
+}
+
+// outside returns true if the scope is outside the branch on measured bits being emitted.
+func (c *Compiler) outside(s *scope) bool {
+	if c.root == nil {
+		return false
+	}
+
+	for sc := c.root.outer; sc != nil; sc = sc.outer {
+		if sc == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Compiler) alias(ctx *gen.AliasDeclarationStatementContext, sc *scope) error {
+	var qubits []int
+	for _, e := range ctx.AliasExpression().AllExpression() {
+		q, err := c.qubits(e, sc)
+		if err != nil {
+			return err
+		}
+
+		qubits = append(qubits, q...)
+	}
+
+	sc.qubits[ctx.Identifier().GetText()] = qubits
+	return nil
+}
+
+func (c *Compiler) expressionStatement(ctx *gen.ExpressionStatementContext, sc *scope) error {
+	call, ok := ctx.Expression().(*gen.CallExpressionContext)
+	if !ok {
+		_, err := c.eval(ctx.Expression(), sc)
+		return err
+	}
+
+	if _, ok := c.defs[call.Identifier().GetText()]; !ok {
+		_, err := c.eval(call, sc)
+		return err
+	}
+
+	// the return value is discarded
+	_, err := c.callDef(call, sc)
+	return err
+}
+
+// callDef runs the subroutine and returns the return value, or nil if the subroutine returns nothing.
+func (c *Compiler) callDef(call *gen.CallExpressionContext, sc *scope) (*returned, error) {
+	name := call.Identifier().GetText()
+	def := c.defs[name]
+
+	// the arguments and the body are not part of the condition
+	reading, unknown := c.reading, c.unknown
+	c.reading = false
+	defer func() { c.reading, c.unknown = reading, unknown }()
+
+	var args []gen.IExpressionContext
+	if call.ExpressionList() != nil {
+		args = call.ExpressionList().AllExpression()
+	}
+
+	var params []gen.IArgumentDefinitionContext
+	if def.ArgumentDefinitionList() != nil {
+		params = def.ArgumentDefinitionList().AllArgumentDefinition()
+	}
+
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("%s: %w", name, ErrInvalidParams)
+	}
+
+	if c.depth >= maxDepth {
+		return nil, fmt.Errorf("%s: depth=%d: %w", name, maxDepth, ErrUnsupported)
+	}
+
+	inner := newScope(c.global)
+	for i, p := range params {
+		pname := p.Identifier().GetText()
+		switch {
+		case p.QubitType() != nil || p.QREG() != nil:
+			q, err := c.qubits(args[i], sc)
+			if err != nil {
+				return nil, err
+			}
+
+			inner.qubits[pname] = q
+		case p.CREG() != nil || (p.ScalarType() != nil && p.ScalarType().BIT() != nil):
+			// bits are passed by value
+			v, err := c.eval(args[i], sc)
+			if err != nil {
+				return nil, err
+			}
+
+			inner.vars[pname] = toInt(v)
+		default:
+			v, err := c.eval(args[i], sc)
+			if err != nil {
+				return nil, err
+			}
+
+			inner.vars[pname] = cast(p.ScalarType(), v)
+		}
+	}
+
+	top := c.call == nil
+	if top {
+		c.call = &circuit.Call{
+			Name:   name,
+			Line:   call.GetStart().GetLine(),
+			Column: call.GetStart().GetColumn(),
+		}
+
+		defer func() { c.call = nil }()
+	}
+
+	c.depth++
+	defer func() { c.depth-- }()
+
+	err := c.scope(def.Scope(), inner)
+	if err == nil {
+		return &returned{}, nil
+	}
+
+	r, ok := errors.AsType[*returned](err)
+	if !ok {
+		return nil, err
+	}
+
+	if r.value != nil && def.ReturnSignature() != nil {
+		r.value = cast(def.ReturnSignature().ScalarType(), r.value)
+	}
+
+	return r, nil
+}
+
+// returnStatement returns the value of the subroutine as an error, so that the caller stops running the body.
+func (c *Compiler) returnStatement(ctx *gen.ReturnStatementContext, sc *scope) error {
+	switch {
+	case ctx.Expression() != nil:
+		v, err := c.eval(ctx.Expression(), sc)
+		if err != nil {
+			return err
+		}
+
+		return &returned{value: v}
+	case ctx.MeasureExpression() != nil:
+		qubits, err := c.operand(ctx.MeasureExpression().GateOperand(), sc)
+		if err != nil {
+			return err
+		}
+
+		var v int64
+		for i, q := range qubits {
+			if err := c.measureInto([]int{q}, []int{-1}); err != nil {
+				return err
+			}
+
+			v |= int64(c.last) << i
+		}
+
+		return &returned{value: v, measured: true}
+	}
+
+	return &returned{}
+}
+
+// operand returns the qubits of the gate operand.
+func (c *Compiler) operand(ctx gen.IGateOperandContext, sc *scope) ([]int, error) {
+	if ctx.IndexedIdentifier() == nil {
+		return nil, fmt.Errorf("%s: hardware qubit: %w", ctx.GetText(), ErrUnsupported)
+	}
+
+	name := ctx.IndexedIdentifier().Identifier().GetText()
+	qubits, ok := sc.qubit(name)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	for _, index := range ctx.IndexedIdentifier().AllIndexOperator() {
+		selected, err := c.index(index, qubits, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		qubits = selected
+	}
+
+	return qubits, nil
+}
+
+// bits returns the classical bits of the indexed identifier.
+func (c *Compiler) bits(ctx gen.IIndexedIdentifierContext, sc *scope) ([]int, error) {
+	name := ctx.Identifier().GetText()
+	bits, ok := sc.bit(name)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	for _, index := range ctx.AllIndexOperator() {
+		selected, err := c.index(index, bits, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		bits = selected
+	}
+
+	return bits, nil
+}
+
+// register returns the classical bits referenced by the indexed expression without the last index. e.g. c in c[0]
+func (c *Compiler) register(e *gen.IndexExpressionContext, sc *scope) ([]int, bool) {
+	switch x := e.Expression().(type) {
+	case *gen.LiteralExpressionContext:
+		if x.Identifier() == nil {
+			return nil, false
+		}
+
+		return sc.bit(x.Identifier().GetText())
+	case *gen.IndexExpressionContext:
+		bits, ok := c.register(x, sc)
+		if !ok {
+			return nil, false
+		}
+
+		selected, err := c.index(x.IndexOperator(), bits, sc)
+		return selected, err == nil
+	}
+
+	return nil, false
+}
+
+// read returns the value of the classical bits.
+// A measured bit is known only if the compiler runs the operations on a machine.
+func (c *Compiler) read(name string, bits []int) (int64, error) {
+	var v int64
+	for i, b := range bits {
+		if c.measured[b] {
+			switch {
+			case c.machine != nil:
+				c.feedback = true
+			case c.reading:
+				c.unknown = true
+			default:
+				return 0, fmt.Errorf("%s: %w", name, ErrClassicalControl)
+			}
+		}
+
+		v |= int64(c.values[b]) << i
+	}
+
+	return v, nil
+}
+
+// write assigns the value to the classical bits.
+// In a branch on measured bits, the value depends on the branch taken, so the bits are not known.
+func (c *Compiler) write(bits []int, v int64) {
+	for i, b := range bits {
+		c.values[b], c.measured[b] = int(v>>i&1), c.cond != nil
+	}
+}
+
+// qubits returns the qubits referenced by the expression. e.g. q, q[0], q[1:2]
+func (c *Compiler) qubits(ctx gen.IExpressionContext, sc *scope) ([]int, error) {
+	switch e := ctx.(type) {
+	case *gen.LiteralExpressionContext:
+		if e.Identifier() == nil {
+			return nil, fmt.Errorf("%s: %w", e.GetText(), ErrInvalidOperand)
+		}
+
+		q, ok := sc.qubit(e.Identifier().GetText())
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", e.GetText(), ErrNotFound)
+		}
+
+		return q, nil
+	case *gen.IndexExpressionContext:
+		q, err := c.qubits(e.Expression(), sc)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.index(e.IndexOperator(), q, sc)
+	case *gen.ParenthesisExpressionContext:
+		return c.qubits(e.Expression(), sc)
+	default:
+		return nil, fmt.Errorf("%s: %w", ctx.GetText(), ErrInvalidOperand)
+	}
+}
+
+// index returns the elements selected by the index operator.
+func (c *Compiler) index(ctx gen.IIndexOperatorContext, elems []int, sc *scope) ([]int, error) {
+	at := func(v int64) (int, error) {
+		i := int(v)
+		if i < 0 {
+			i += len(elems)
+		}
+
+		if i < 0 || i >= len(elems) {
+			return 0, fmt.Errorf("index=%d, size=%d: %w", v, len(elems), ErrInvalidOperand)
+		}
+
+		return elems[i], nil
+	}
+
+	if ctx.SetExpression() != nil {
+		var out []int
+		for _, e := range ctx.SetExpression().AllExpression() {
+			v, err := c.eval(e, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			q, err := at(toInt(v))
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, q)
+		}
+
+		return out, nil
+	}
+
+	var out []int
+	for _, child := range ctx.GetChildren() {
+		switch e := child.(type) {
+		case gen.IRangeExpressionContext:
+			r, err := c.rangeValues(e, len(elems), sc)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range r {
+				q, err := at(int64(i))
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, q)
+			}
+		case gen.IExpressionContext:
+			v, err := c.eval(e, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			q, err := at(toInt(v))
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, q)
+		}
+	}
+
+	return out, nil
+}
+
+// rangeValues returns the values of start:stop or start:step:stop. The stop is inclusive.
+// size is used for the omitted bounds, or -1 if the bounds are required.
+func (c *Compiler) rangeValues(ctx gen.IRangeExpressionContext, size int, sc *scope) ([]int, error) {
+	start, step, stop, err := c.rangeBounds(ctx, size, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []int
+	for i := start; (step > 0 && i <= stop) || (step < 0 && i >= stop); i += step {
+		out = append(out, i)
+		if c.maxOps > 0 && len(out) > c.maxOps {
+			return nil, fmt.Errorf("max=%d: %w", c.maxOps, ErrTooManyOps)
+		}
+	}
+
+	return out, nil
+}
+
+// rangeBounds returns the start, step and stop of the range expression.
+func (c *Compiler) rangeBounds(ctx gen.IRangeExpressionContext, size int, sc *scope) (start, step, stop int, err error) {
+	// collect the expressions separated by colons
+	parts := []gen.IExpressionContext{nil}
+	for _, child := range ctx.GetChildren() {
+		switch e := child.(type) {
+		case antlr.TerminalNode:
+			parts = append(parts, nil)
+		case gen.IExpressionContext:
+			parts[len(parts)-1] = e
+		}
+	}
+
+	value := func(e gen.IExpressionContext, def int) (int, error) {
+		if e == nil {
+			if size < 0 {
+				return 0, fmt.Errorf("%s: %w", ctx.GetText(), ErrInvalidOperand)
+			}
+
+			return def, nil
+		}
+
+		v, err := c.eval(e, sc)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(toInt(v)), nil
+	}
+
+	startExpr, stepExpr, stopExpr := parts[0], gen.IExpressionContext(nil), parts[len(parts)-1]
+	if len(parts) == 3 {
+		stepExpr = parts[1]
+	}
+
+	start, err = value(startExpr, 0)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	stop, err = value(stopExpr, size-1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	step = 1
+	if stepExpr != nil {
+		step, err = value(stepExpr, 1)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	if step == 0 {
+		return 0, 0, 0, fmt.Errorf("%s: step=0: %w", ctx.GetText(), ErrInvalidOperand)
+	}
+
+	return start, step, stop, nil
+}
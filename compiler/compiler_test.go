@@ -0,0 +1,475 @@
+package compiler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	"github.com/itsubaki/quasar/include"
+	"github.com/itsubaki/quasar/statevector"
+)
+
+func ExampleCompile() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+bit[2] c;
+h q[0];
+cx q[0], q[1];
+c = measure q;
+	`
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := compiler.Compile(program)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, op := range c.Ops {
+		fmt.Println(op.Kind, op.Operands(), op.Clbit)
+	}
+
+	// Output:
+	// gate [0] -1
+	// gate [0 1] -1
+	// measure [0] 0
+	// measure [1] 1
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		code string
+		ops  int
+	}{
+		{"qubit[3] q; U(pi, 0, pi) q;", 3},
+		{"qubit[2] q; for int i in [0:1] { U(pi, 0, pi) q[i]; }", 2},
+		{"qubit q; pow(3) @ U(pi, 0, pi) q;", 3},
+		{"qubit q; inv @ pow(2) @ U(0, 0, pi/4) q;", 2},
+		{"qubit[2] q; ctrl @ gphase(pi) q[0];", 1},
+		{"gate g a, b { U(0, 0, 0) a; U(0, 0, 0) b; } qubit[4] q; g q[0:1], q[2:3];", 4},
+		{"def f(qubit a) { reset a; } qubit q; f(q);", 1},
+		{"qubit[2] q; barrier q;", 1},
+		{"const int n = 2; qubit[n] q; if (n == 2) { U(0, 0, 0) q[0]; } else { U(0, 0, 0) q[1]; }", 1},
+	}
+
+	for _, c := range cases {
+		program, err := parser.Parse(c.code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		got, err := compiler.Compile(program)
+		if err != nil {
+			t.Errorf("%s: %v", c.code, err)
+			continue
+		}
+
+		if len(got.Ops) != c.ops {
+			t.Errorf("%s: got=%d, want=%d", c.code, len(got.Ops), c.ops)
+		}
+	}
+}
+
+func TestCompile_modifier(t *testing.T) {
+	program, err := parser.Parse("qubit[3] q; negctrl @ ctrl @ inv @ U(0.1, 0.2, 0.3) q[0], q[1], q[2];")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	c, err := compiler.Compile(program)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	op := c.Ops[0]
+	if op.Target != 2 || len(op.Controls) != 1 || op.Controls[0] != 1 || len(op.NegControls) != 1 || op.NegControls[0] != 0 {
+		t.Errorf("got=%+v", op)
+	}
+
+	if math.Abs(op.Theta+0.1) > 1e-12 || math.Abs(op.Phi+0.3) > 1e-12 || math.Abs(op.Lambda+0.2) > 1e-12 {
+		t.Errorf("got=%+v", op)
+	}
+
+	if op.Call == nil || op.Call.Name != "negctrl @ ctrl @ inv @ U" {
+		t.Errorf("got=%+v", op.Call)
+	}
+
+	if op.Call.Gate != "U" || op.Call.Power != -1 || fmt.Sprint(op.Call.NegControls, op.Call.Controls) != "[0] [1]" {
+		t.Errorf("got=%+v", op.Call)
+	}
+}
+
+func TestCompile_call(t *testing.T) {
+	program, err := parser.Parse("qubit[2] q;\nbit[2] c;\nU(pi/2, 0, pi) q[0];\nc = measure q;\nreset q;")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	c, err := compiler.Compile(program)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	want := []struct {
+		name string
+		line int
+	}{
+		{"U", 3},
+		{"measure", 4},
+		{"measure", 4},
+		{"reset", 5},
+		{"reset", 5},
+	}
+
+	if len(c.Ops) != len(want) {
+		t.Fatalf("got=%d, want=%d", len(c.Ops), len(want))
+	}
+
+	for i, op := range c.Ops {
+		if op.Call == nil || op.Call.Name != want[i].name || op.Call.Line != want[i].line || op.Call.Column != 0 {
+			t.Errorf("%d: got=%+v", i, op.Call)
+		}
+	}
+
+	// one statement
+	if c.Ops[1].Call != c.Ops[2].Call {
+		t.Errorf("got=%p, %p", c.Ops[1].Call, c.Ops[2].Call)
+	}
+}
+
+func TestCompiler_machine(t *testing.T) {
+	cases := []struct {
+		code     string
+		want     string
+		feedback bool
+	}{
+		{"qubit q; bit c; U(pi, 0, pi) q; c = measure q; if (c == 1) { U(pi, 0, pi) q; }", "[1.0000 0.0000]", true},
+		{"qubit q; bit[2] c = \"10\"; if (c[1] == 1) { U(pi, 0, pi) q; }", "[0.0000 1.0000]", false},
+		{"def f(qubit a) -> bit { U(pi, 0, pi) a; return measure a; } qubit q; if (f(q) == 1) { reset q; }", "[1.0000 0.0000]", true},
+		{"qubit q; int n = 0; while (true) { n += 1; if (n == 3) { break; } U(pi, 0, pi) q; }", "[1.0000 0.0000]", false},
+		{"qubit q; for int i in [0:2] { if (i % 2 == 0) { continue; } U(pi, 0, pi) q; }", "[0.0000 1.0000]", false},
+		{"qubit q; U(pi, 0, pi) q; end; U(pi, 0, pi) q;", "[0.0000 1.0000]", false},
+		{"input bit[2] b; qubit q; if (b[1] == 1) { U(pi, 0, pi) q; }", "[0.0000 1.0000]", false},
+	}
+
+	for _, c := range cases {
+		program, err := parser.Parse(c.code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		m := &statevector.Machine{
+			State: statevector.New(0),
+			Rand:  func() float64 { return 0.5 },
+		}
+
+		cc := compiler.New(
+			compiler.WithInputs(map[string]any{"b": int64(2)}),
+			compiler.WithMachine(m),
+		)
+		if _, err := cc.Compile(program); err != nil {
+			t.Errorf("%s: %v", c.code, err)
+			continue
+		}
+
+		if got := fmt.Sprintf("%.4f", m.Probabilities()); got != c.want {
+			t.Errorf("%s: got=%s, want=%s", c.code, got, c.want)
+		}
+
+		if env := cc.Env(); env.Feedback != c.feedback {
+			t.Errorf("%s: got=%v, want=%v", c.code, env.Feedback, c.feedback)
+		}
+	}
+}
+
+func TestCompiler_branches(t *testing.T) {
+	cases := []struct {
+		code string
+		want []string
+	}{
+		{
+			"qubit q; bit c; c = measure q; if (c == 1) { U(pi, 0, pi) q; }",
+			[]string{"", "c==1"},
+		},
+		{
+			"qubit[2] q; bit c; c = measure q[0]; if (c) { U(pi, 0, pi) q[1]; } else { U(0, 0, pi) q[1]; U(0, 0, pi) q[0]; }",
+			[]string{"", "c", "!(c)", "!(c)"},
+		},
+		{
+			"qubit q; bit[2] c; c[0] = measure q; if (c[0]) { c[1] = measure q; if (c[1]) { reset q; } }",
+			[]string{"", "c[0]", "c[0] && c[1]"},
+		},
+		{
+			"qubit q; bit c = measure q; while (c) { reset q; c = measure q; } U(pi, 0, pi) q;",
+			[]string{"", "c", "c", ""},
+		},
+		{
+			"qubit q; bit c; if (c) { U(pi, 0, pi) q; }",
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		program, err := parser.Parse(c.code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		got, err := compiler.Compile(program, compiler.WithBranches())
+		if err != nil {
+			t.Fatalf("%s: compile: %v", c.code, err)
+		}
+
+		conds := make([]string, len(got.Ops))
+		for i, op := range got.Ops {
+			if op.Condition != nil {
+				conds[i] = op.Condition.String()
+			}
+		}
+
+		if len(conds) != len(c.want) {
+			t.Fatalf("%s: got=%q, want=%q", c.code, conds, c.want)
+		}
+
+		for i := range conds {
+			if conds[i] != c.want[i] {
+				t.Errorf("%s: got=%q, want=%q", c.code, conds, c.want)
+			}
+		}
+	}
+}
+
+func TestCompiler_branches_error(t *testing.T) {
+	cases := []string{
+		"qubit q; bit c = measure q; int n = c;",
+		"qubit q; bit c = measure q; int n = 0; if (c) { n = 1; }",
+		"qubit q; bit c; for int i in [0:1] { c = measure q; if (c) { break; } }",
+		"def f(bit b) -> bit { return b; } qubit q; bit c = measure q; if (f(c)) { reset q; }",
+	}
+
+	for _, code := range cases {
+		program, err := parser.Parse(code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		if _, err := compiler.Compile(program, compiler.WithBranches()); !errors.Is(err, compiler.ErrClassicalControl) {
+			t.Errorf("%s: got=%v", code, err)
+		}
+	}
+}
+
+func TestCompiler_step(t *testing.T) {
+	program, err := parser.Parse("qubit q;\nfor int i in [0:1] {\n  U(0, 0, 0) q;\n}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	var lines []int
+	if _, err := compiler.Compile(program, compiler.WithStep(func(line, column int) error {
+		lines = append(lines, line)
+		return nil
+	})); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	if fmt.Sprint(lines) != "[1 2 2 3 2 3]" {
+		t.Errorf("got=%v", lines)
+	}
+}
+
+type canceling struct {
+	cancel context.CancelFunc
+	runs   int
+}
+
+func (m *canceling) Grow(n int) error {
+	return nil
+}
+
+func (m *canceling) Run(op circuit.Op) (int, error) {
+	m.runs++
+	m.cancel()
+	return 0, nil
+}
+
+func TestCompiler_context(t *testing.T) {
+	program, err := parser.Parse("qubit q; U(0, 0, 0) q; U(0, 0, 0) q; U(0, 0, 0) q;")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// the operations stop as soon as the context is canceled
+	ctx, cancel := context.WithCancel(t.Context())
+	m := &canceling{cancel: cancel}
+	if _, err := compiler.Compile(program, compiler.WithContext(ctx), compiler.WithMachine(m)); !errors.Is(err, context.Canceled) || m.runs != 1 {
+		t.Errorf("got=%v, runs=%d", err, m.runs)
+	}
+}
+
+func TestCompileGate(t *testing.T) {
+	code, err := os.ReadFile("../testdata/qft.qasm")
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+
+	program, err := parser.Parse(string(code))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	c, err := compiler.CompileGate(program, "cr", []float64{math.Pi / 2})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	if c.Qubits != 2 || len(c.Ops) != 1 {
+		t.Fatalf("got=%+v", c)
+	}
+
+	op := c.Ops[0]
+	if op.Target != 1 || len(op.Controls) != 1 || op.Controls[0] != 0 || math.Abs(op.Lambda-math.Pi/2) > 1e-12 {
+		t.Errorf("got=%+v", op)
+	}
+
+	cases := []struct {
+		name      string
+		params    []float64
+		maxQubits int
+		err       error
+	}{
+		{"qft", nil, 0, compiler.ErrGateNotFound},
+		{"foo", nil, 0, compiler.ErrGateNotFound},
+		{"cr", nil, 0, compiler.ErrInvalidParams},
+		{"cx", nil, 1, compiler.ErrTooManyQubits},
+	}
+
+	for _, c := range cases {
+		if _, err := compiler.CompileGate(program, c.name, c.params, compiler.WithMaxQubits(c.maxQubits)); !errors.Is(err, c.err) {
+			t.Errorf("%s: got=%v, want=%v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestCompileGate_stdgates(t *testing.T) {
+	code, err := os.ReadFile("../testdata/qft_stdgates.qasm")
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+
+	resolved, err := include.Resolve(string(code))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	program, err := parser.Parse(resolved)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		params []float64
+		ops    int
+	}{
+		{"cx", nil, 1},
+		{"cr", []float64{math.Pi / 2}, 1},
+		{"h", nil, 1},
+	}
+
+	for _, c := range cases {
+		got, err := compiler.CompileGate(program, c.name, c.params)
+		if err != nil {
+			t.Fatalf("%s: compile: %v", c.name, err)
+		}
+
+		if len(got.Ops) != c.ops {
+			t.Errorf("%s: got=%+v", c.name, got.Ops)
+		}
+	}
+}
+
+func TestCompile_error(t *testing.T) {
+	cases := []struct {
+		code string
+		err  error
+	}{
+		{"qubit q; foo q;", compiler.ErrGateNotFound},
+		{"input float x; qubit q;", compiler.ErrNotFound},
+		{"qubit[2] q; U(0, 0, 0) q[2];", compiler.ErrInvalidOperand},
+		{"qubit q; qubit q;", compiler.ErrAlreadyDeclared},
+		{"qubit q; bit c; c = measure q; if (c == 1) { U(0, 0, 0) q; }", compiler.ErrClassicalControl},
+		{"def f(qubit a) -> bit { return measure a; } qubit q; int n = f(q);", compiler.ErrClassicalControl},
+		{"gate g a { g a; } qubit q; g q;", compiler.ErrUnsupported},
+		{"qubit q; while (true) { }", compiler.ErrTooManyOps},
+		{"qubit q; pow(1e9) @ U(0, 0, 0) q;", compiler.ErrTooManyOps},
+		{"qubit q; pow(1e18) @ U(0, 0, 0) q;", compiler.ErrInvalidOperand},
+		{"qubit q; pow(1 / 0) @ U(0, 0, 0) q;", compiler.ErrInvalidOperand},
+		{"qubit q; ctrl(-1) @ U(0, 0, 0) q;", compiler.ErrInvalidOperand},
+		{"qubit[2] q; negctrl(0) @ U(0, 0, 0) q[0], q[1];", compiler.ErrInvalidOperand},
+		{"int x = 1 << -1;", compiler.ErrInvalidOperand},
+		{"int x = 8; x >>= -1;", compiler.ErrInvalidOperand},
+	}
+
+	for _, c := range cases {
+		program, err := parser.Parse(c.code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		if _, err := compiler.Compile(program, compiler.WithMaxOps(100)); !errors.Is(err, c.err) {
+			t.Errorf("%s: got=%v, want=%v", c.code, err, c.err)
+		}
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		code   string
+		qubits int
+		ops    int
+		multi  int
+	}{
+		{"qubit[3] q; U(pi, 0, pi) q;", 3, 3, 0},
+		{"qubit[2] q; for int i in [0:1] { U(pi, 0, pi) q[i]; }", 2, 2, 0},
+		{"qubit[2] q; for int i in [0:2:9] { U(pi, 0, pi) q; }", 2, 10, 0},
+		{"qubit q; for int i in {1, 2, 3} { U(pi, 0, pi) q; }", 1, 3, 0},
+		{"qubit q; pow(3) @ U(pi, 0, pi) q;", 1, 3, 0},
+		{"gate g a, b { U(0, 0, 0) a; U(0, 0, 0) b; } qubit[4] q; g q[0:1], q[2:3];", 4, 4, 0},
+		{"gate h q { U(pi/2, 0, pi) q; } qubit[40] q; h q[0];", 40, 1, 0},
+		{"def f(qubit a) { reset a; } qubit q; f(q);", 1, 1, 0},
+		{"const int n = 30; qubit[n] q; qreg r[n + 2]; bit[n] c = measure q;", 62, 30, 0},
+		{"input int n; qubit[n] q; for int i in [0:n-1] { for int j in [0:n-1] { U(0, 0, 0) q[i]; } }", 1000, 1000000, 0},
+		{"qubit q; bit c; c = measure q; if (c == 1) { U(0, 0, 0) q; U(0, 0, 0) q; } else { U(0, 0, 0) q; }", 1, 3, 0},
+		{"qubit[100] q; for int i in [0:9223372036854775806] { U(0, 0, 0) q; }", 100, math.MaxInt, 0},
+		{"qubit[2 << -1] q; pow(1 >> -1) @ U(0, 0, 0) q;", 1, 1, 0},
+		{"gate cx a, b { ctrl @ U(pi, 0, pi) a, b; } qubit[3] q; cx q[0], q[1]; U(0, 0, 0) q[2]; negctrl @ U(0, 0, 0) q[1], q[2];", 3, 3, 2},
+		{"gate g a, b { U(0, 0, 0) a; U(0, 0, 0) b; } qubit[3] q; g q[0], q[1]; ctrl @ g q[0], q[1], q[2];", 3, 4, 2},
+	}
+
+	for _, c := range cases {
+		program, err := parser.Parse(c.code)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		got := compiler.Estimate(program, compiler.WithInputs(map[string]any{"n": int64(1000)}))
+		if got.Qubits != c.qubits || got.Ops != c.ops || got.Multi != c.multi {
+			t.Errorf("%s: got=%+v, want=%d, %d, %d", c.code, got, c.qubits, c.ops, c.multi)
+		}
+	}
+}
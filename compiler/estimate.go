@@ -0,0 +1,367 @@
+package compiler
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/antlr4-go/antlr/v4"
+	gen "github.com/itsubaki/qasm/gen/parser"
+)
+
+// Resources is the estimated size of a program.
+// Multi is the number of the operations on two or more qubits, e.g. the controlled gates.
+type Resources struct {
+	Qubits int
+	Ops    int
+	Multi  int
+}
+
+// Estimate returns the number of qubits and a rough number of operations of the program
+// from the qubit declarations, the gate calls and the loop bounds, without expanding the program.
+// The body of a while loop is counted once, and the larger branch of an if statement is counted.
+// Expressions that cannot be evaluated at compile time are skipped, and the values saturate at math.MaxInt.
+func Estimate(program antlr.Tree, opts ...Option) *Resources {
+	e := newEstimator(false, opts...)
+	ops := e.walk(program, e.global)
+
+	m := newEstimator(true, opts...)
+	multi := m.walk(program, m.global)
+
+	return &Resources{
+		Qubits: e.qubits,
+		Ops:    ops,
+		Multi:  multi,
+	}
+}
+
+type estimator struct {
+	*Compiler
+	defs     map[string]*gen.DefStatementContext // the subroutines are counted, not run
+	multi    bool                                // only the operations on two or more qubits are counted
+	qubits   int
+	sizes    map[string]int  // the size of each quantum register
+	costs    map[string]int  // the number of operations of each gate and subroutine
+	visiting map[string]bool // the gates and subroutines being counted
+}
+
+func newEstimator(multi bool, opts ...Option) *estimator {
+	return &estimator{
+		Compiler: New(opts...),
+		defs:     make(map[string]*gen.DefStatementContext),
+		multi:    multi,
+		sizes:    make(map[string]int),
+		costs:    make(map[string]int),
+		visiting: make(map[string]bool),
+	}
+}
+
+// single returns the operations on a single qubit, or 0 if only the operations on two or more qubits are counted.
+func (e *estimator) single(ops int) int {
+	if e.multi {
+		return 0
+	}
+
+	return ops
+}
+
+// walk returns the number of operations of the tree.
+func (e *estimator) walk(tree antlr.Tree, sc *scope) int {
+	switch ctx := tree.(type) {
+	case *gen.ProgramContext, *gen.StatementOrScopeContext, *gen.StatementContext:
+		var ops int
+		for _, child := range tree.GetChildren() {
+			ops = add(ops, e.walk(child, sc))
+		}
+
+		return ops
+	case *gen.ScopeContext:
+		return e.scope(ctx, newScope(sc))
+	case *gen.BoxStatementContext:
+		return e.scope(ctx.Scope(), newScope(sc))
+	case *gen.QuantumDeclarationStatementContext:
+		e.declare(ctx.Identifier().GetText(), ctx.QubitType().Designator(), sc)
+		return 0
+	case *gen.OldStyleDeclarationStatementContext:
+		if ctx.QREG() != nil {
+			e.declare(ctx.Identifier().GetText(), ctx.Designator(), sc)
+		}
+
+		return 0
+	case *gen.ClassicalDeclarationStatementContext:
+		if ctx.ScalarType() != nil && ctx.ScalarType().BIT() != nil {
+			// bit[n] c = measure q;
+			if decl := ctx.DeclarationExpression(); decl != nil && decl.MeasureExpression() != nil {
+				return e.single(e.width(decl.MeasureExpression().GateOperand()))
+			}
+
+			return 0
+		}
+
+		// the errors are reported by the simulator
+		_ = e.exec(ctx, sc)
+		return 0
+	case *gen.ConstDeclarationStatementContext:
+		_ = e.exec(ctx, sc)
+		return 0
+	case *gen.IoDeclarationStatementContext:
+		if ctx.ScalarType() == nil || ctx.ScalarType().BIT() == nil {
+			_ = e.exec(ctx, sc)
+		}
+
+		return 0
+	case *gen.GateStatementContext:
+		e.gates[ctx.Identifier().GetText()] = ctx
+		return 0
+	case *gen.DefStatementContext:
+		e.defs[ctx.Identifier().GetText()] = ctx
+		return 0
+	case *gen.GateCallStatementContext:
+		return e.gateCall(ctx, sc)
+	case *gen.MeasureArrowAssignmentStatementContext:
+		return e.single(e.width(ctx.MeasureExpression().GateOperand()))
+	case *gen.AssignmentStatementContext:
+		if ctx.MeasureExpression() != nil {
+			return e.single(e.width(ctx.MeasureExpression().GateOperand()))
+		}
+
+		return 0
+	case *gen.ResetStatementContext:
+		return e.single(e.width(ctx.GateOperand()))
+	case *gen.BarrierStatementContext:
+		return e.single(1)
+	case *gen.ForStatementContext:
+		return e.forStatement(ctx, sc)
+	case *gen.WhileStatementContext:
+		return e.walk(ctx.GetBody(), newScope(sc))
+	case *gen.IfStatementContext:
+		ops := e.walk(ctx.GetIf_body(), newScope(sc))
+		if ctx.GetElse_body() != nil {
+			ops = max(ops, e.walk(ctx.GetElse_body(), newScope(sc)))
+		}
+
+		return ops
+	case *gen.ExpressionStatementContext:
+		call, ok := ctx.Expression().(*gen.CallExpressionContext)
+		if !ok {
+			return 0
+		}
+
+		return e.def(call.Identifier().GetText())
+	default:
+		return 0
+	}
+}
+
+func (e *estimator) scope(ctx gen.IScopeContext, sc *scope) int {
+	var ops int
+	for _, s := range ctx.AllStatementOrScope() {
+		ops = add(ops, e.walk(s, sc))
+	}
+
+	return ops
+}
+
+func (e *estimator) declare(name string, designator gen.IDesignatorContext, sc *scope) {
+	n, err := e.size(designator, sc)
+	if err != nil {
+		// the errors are reported by the simulator
+		n = 1
+	}
+
+	e.qubits = add(e.qubits, n)
+	e.sizes[name] = n
+}
+
+// width returns the number of qubits of the operand, or the size of the register if it is sliced.
+func (e *estimator) width(ctx gen.IGateOperandContext) int {
+	if ctx == nil || ctx.IndexedIdentifier() == nil {
+		return 1
+	}
+
+	n, ok := e.sizes[ctx.IndexedIdentifier().Identifier().GetText()]
+	if !ok {
+		return 1
+	}
+
+	for _, index := range ctx.IndexedIdentifier().AllIndexOperator() {
+		if index.SetExpression() != nil {
+			return n
+		}
+
+		for _, child := range index.GetChildren() {
+			if _, ok := child.(gen.IRangeExpressionContext); ok {
+				return n
+			}
+		}
+
+		// q[i]
+		return 1
+	}
+
+	return n
+}
+
+func (e *estimator) gateCall(ctx *gen.GateCallStatementContext, sc *scope) int {
+	// every operation of a controlled gate is on two or more qubits
+	if e.multi && slices.ContainsFunc(ctx.AllGateModifier(), func(m gen.IGateModifierContext) bool {
+		return m.CTRL() != nil || m.NEGCTRL() != nil
+	}) {
+		e.multi = false
+		defer func() { e.multi = true }()
+	}
+
+	ops := e.single(1)
+	if ctx.Identifier() != nil {
+		ops = e.gate(ctx.Identifier().GetText())
+	}
+
+	// broadcast
+	if ctx.GateOperandList() != nil {
+		var n int
+		for _, o := range ctx.GateOperandList().AllGateOperand() {
+			n = max(n, e.width(o))
+		}
+
+		ops = Mul(ops, max(n, 1))
+	}
+
+	// pow(k) @ g repeats g k times
+	for _, m := range ctx.AllGateModifier() {
+		if m.POW() == nil {
+			continue
+		}
+
+		v, err := e.eval(m.Expression(), sc)
+		if err != nil {
+			continue
+		}
+
+		if p := math.Abs(toFloat(v)); p == math.Trunc(p) && p < math.MaxInt {
+			ops = Mul(ops, int(p))
+		}
+	}
+
+	return ops
+}
+
+// gate returns the number of operations of the gate body.
+func (e *estimator) gate(name string) int {
+	if name == "U" || name == "gphase" {
+		return e.single(1)
+	}
+
+	g, ok := e.gates[name]
+	if !ok || e.visiting[name] {
+		return e.single(1)
+	}
+
+	var args []string
+	for _, id := range g.GetQubits().AllIdentifier() {
+		args = append(args, id.GetText())
+	}
+
+	return e.cost(name, func() int {
+		return e.body(g.Scope(), args)
+	})
+}
+
+// def returns the number of operations of the subroutine body.
+func (e *estimator) def(name string) int {
+	d, ok := e.defs[name]
+	if !ok || e.visiting[name] {
+		return 0
+	}
+
+	var args []string
+	if d.ArgumentDefinitionList() != nil {
+		for _, p := range d.ArgumentDefinitionList().AllArgumentDefinition() {
+			args = append(args, p.Identifier().GetText())
+		}
+	}
+
+	return e.cost(name, func() int {
+		return e.body(d.Scope(), args)
+	})
+}
+
+// body returns the number of operations of the gate or subroutine body.
+// The arguments shadow the registers, and are counted as single qubits.
+func (e *estimator) body(ctx gen.IScopeContext, args []string) int {
+	saved := e.sizes
+	defer func() { e.sizes = saved }()
+
+	e.sizes = maps.Clone(saved)
+	for _, a := range args {
+		delete(e.sizes, a)
+	}
+
+	return e.scope(ctx, newScope(e.global))
+}
+
+func (e *estimator) cost(name string, f func() int) int {
+	// cached by the mode, since every operation of a controlled gate is counted
+	key := name
+	if !e.multi {
+		key = "*" + name
+	}
+
+	if ops, ok := e.costs[key]; ok {
+		return ops
+	}
+
+	e.visiting[name] = true
+	defer delete(e.visiting, name)
+
+	ops := f()
+	e.costs[key] = ops
+	return ops
+}
+
+func (e *estimator) forStatement(ctx *gen.ForStatementContext, sc *scope) int {
+	// the iterations, or 1 if the bounds are not known at compile time
+	n, first := 1, any(nil)
+	switch {
+	case ctx.RangeExpression() != nil:
+		start, step, stop, err := e.rangeBounds(ctx.RangeExpression(), -1, sc)
+		if err != nil {
+			break
+		}
+
+		n, first = max((stop-start)/step+1, 0), int64(start)
+	case ctx.SetExpression() != nil:
+		exprs := ctx.SetExpression().AllExpression()
+		n = len(exprs)
+		if n > 0 {
+			first, _ = e.eval(exprs[0], sc)
+		}
+	}
+
+	inner := newScope(sc)
+	if first != nil {
+		inner.vars[ctx.Identifier().GetText()] = first
+	}
+
+	return Mul(n, e.walk(ctx.GetBody(), inner))
+}
+
+func add(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+
+	return a + b
+}
+
+// Mul returns a * b of non-negative a and b, or math.MaxInt if it overflows.
+func Mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	if a > math.MaxInt/b {
+		return math.MaxInt
+	}
+
+	return a * b
+}
@@ -0,0 +1,377 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	gen "github.com/itsubaki/qasm/gen/parser"
+)
+
+var constants = map[string]float64{
+	"pi":    math.Pi,
+	"π":     math.Pi,
+	"tau":   2 * math.Pi,
+	"τ":     2 * math.Pi,
+	"euler": math.E,
+	"ℇ":     math.E,
+}
+
+// positions is the bit positions of an integer.
+var positions = func() []int {
+	p := make([]int, 64)
+	for i := range p {
+		p[i] = i
+	}
+
+	return p
+}()
+
+var functions = map[string]func(float64) float64{
+	"sin":    math.Sin,
+	"cos":    math.Cos,
+	"tan":    math.Tan,
+	"arcsin": math.Asin,
+	"arccos": math.Acos,
+	"arctan": math.Atan,
+	"exp":    math.Exp,
+	"log":    math.Log,
+	"sqrt":   math.Sqrt,
+	"ceil":   math.Ceil,
+	"floor":  math.Floor,
+}
+
+// eval evaluates the expression at compile time, or with the measured bits if the compiler has a machine.
+// The result is int64, float64 or bool. The classical bits are int64 with the first bit as the least significant bit.
+func (c *Compiler) eval(ctx gen.IExpressionContext, sc *scope) (any, error) {
+	switch e := ctx.(type) {
+	case *gen.ParenthesisExpressionContext:
+		return c.eval(e.Expression(), sc)
+	case *gen.LiteralExpressionContext:
+		return c.literal(e, sc)
+	case *gen.UnaryExpressionContext:
+		v, err := c.eval(e.Expression(), sc)
+		if err != nil {
+			return nil, err
+		}
+
+		switch e.GetOp().GetText() {
+		case "-":
+			if i, ok := v.(int64); ok {
+				return -i, nil
+			}
+
+			return -toFloat(v), nil
+		case "!":
+			return !toBool(v), nil
+		case "~":
+			return ^toInt(v), nil
+		}
+	case *gen.PowerExpressionContext:
+		return c.binary(e.Expression(0), "**", e.Expression(1), sc)
+	case *gen.MultiplicativeExpressionContext:
+		return c.binary(e.Expression(0), e.GetOp().GetText(), e.Expression(1), sc)
+	case *gen.AdditiveExpressionContext:
+		return c.binary(e.Expression(0), e.GetOp().GetText(), e.Expression(1), sc)
+	case *gen.BitshiftExpressionContext:
+		return c.binary(e.Expression(0), e.GetOp().GetText(), e.Expression(1), sc)
+	case *gen.ComparisonExpressionContext:
+		return c.binary(e.Expression(0), e.GetOp().GetText(), e.Expression(1), sc)
+	case *gen.EqualityExpressionContext:
+		return c.binary(e.Expression(0), e.GetOp().GetText(), e.Expression(1), sc)
+	case *gen.BitwiseAndExpressionContext:
+		return c.binary(e.Expression(0), "&", e.Expression(1), sc)
+	case *gen.BitwiseXorExpressionContext:
+		return c.binary(e.Expression(0), "^", e.Expression(1), sc)
+	case *gen.BitwiseOrExpressionContext:
+		return c.binary(e.Expression(0), "|", e.Expression(1), sc)
+	case *gen.LogicalAndExpressionContext:
+		return c.binary(e.Expression(0), "&&", e.Expression(1), sc)
+	case *gen.LogicalOrExpressionContext:
+		return c.binary(e.Expression(0), "||", e.Expression(1), sc)
+	case *gen.CastExpressionContext:
+		v, err := c.eval(e.Expression(), sc)
+		if err != nil {
+			return nil, err
+		}
+
+		return cast(e.ScalarType(), v), nil
+	case *gen.CallExpressionContext:
+		return c.callExpression(e, sc)
+	case *gen.IndexExpressionContext:
+		if _, err := c.qubits(e.Expression(), sc); err == nil {
+			return nil, fmt.Errorf("%s: %w", e.GetText(), ErrInvalidOperand)
+		}
+
+		if bits, ok := c.register(e, sc); ok {
+			selected, err := c.index(e.IndexOperator(), bits, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			return c.read(e.GetText(), selected)
+		}
+
+		// the bits of an integer. e.g. i[0]
+		v, err := c.eval(e.Expression(), sc)
+		if err != nil {
+			return nil, err
+		}
+
+		selected, err := c.index(e.IndexOperator(), positions, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		var out int64
+		for i, p := range selected {
+			out |= (toInt(v) >> p & 1) << i
+		}
+
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", ctx.GetText(), ErrUnsupported)
+}
+
+func (c *Compiler) literal(e *gen.LiteralExpressionContext, sc *scope) (any, error) {
+	text := strings.ReplaceAll(e.GetText(), "_", "")
+	switch {
+	case e.Identifier() != nil:
+		name := e.Identifier().GetText()
+		if v, _, ok := sc.variable(name); ok {
+			return v, nil
+		}
+
+		if v, ok := constants[name]; ok {
+			return v, nil
+		}
+
+		if bits, ok := sc.bit(name); ok {
+			return c.read(name, bits)
+		}
+
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	case e.DecimalIntegerLiteral() != nil:
+		return strconv.ParseInt(text, 10, 64)
+	case e.BinaryIntegerLiteral() != nil:
+		return strconv.ParseInt(text[2:], 2, 64)
+	case e.OctalIntegerLiteral() != nil:
+		return strconv.ParseInt(text[2:], 8, 64)
+	case e.HexIntegerLiteral() != nil:
+		return strconv.ParseInt(text[2:], 16, 64)
+	case e.FloatLiteral() != nil:
+		return strconv.ParseFloat(text, 64)
+	case e.BooleanLiteral() != nil:
+		return text == "true", nil
+	case e.BitstringLiteral() != nil:
+		return strconv.ParseInt(strings.Trim(text, `"`), 2, 64)
+	}
+
+	return nil, fmt.Errorf("%s: %w", e.GetText(), ErrUnsupported)
+}
+
+func (c *Compiler) callExpression(e *gen.CallExpressionContext, sc *scope) (any, error) {
+	name := e.Identifier().GetText()
+	if _, ok := c.defs[name]; ok {
+		r, err := c.callDef(e, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.value == nil {
+			return nil, fmt.Errorf("%s: no return value: %w", name, ErrInvalidOperand)
+		}
+
+		if r.measured {
+			switch {
+			case c.machine != nil:
+				c.feedback = true
+			case c.reading:
+				c.unknown = true
+			default:
+				return nil, fmt.Errorf("%s: %w", name, ErrClassicalControl)
+			}
+		}
+
+		return r.value, nil
+	}
+
+	var args []any
+	if e.ExpressionList() != nil {
+		for _, x := range e.ExpressionList().AllExpression() {
+			v, err := c.eval(x, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, v)
+		}
+	}
+
+	if name == "mod" && len(args) == 2 {
+		return binary("%", args[0], args[1])
+	}
+
+	f, ok := functions[name]
+	if !ok || len(args) != 1 {
+		return nil, fmt.Errorf("%s: %w", name, ErrUnsupported)
+	}
+
+	return f(toFloat(args[0])), nil
+}
+
+func (c *Compiler) binary(x gen.IExpressionContext, op string, y gen.IExpressionContext, sc *scope) (any, error) {
+	a, err := c.eval(x, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.eval(y, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return binary(op, a, b)
+}
+
+func binary(op string, a, b any) (any, error) {
+	ai, aok := a.(int64)
+	bi, bok := b.(int64)
+	ints := aok && bok
+
+	switch op {
+	case "+":
+		if ints {
+			return ai + bi, nil
+		}
+
+		return toFloat(a) + toFloat(b), nil
+	case "-":
+		if ints {
+			return ai - bi, nil
+		}
+
+		return toFloat(a) - toFloat(b), nil
+	case "*":
+		if ints {
+			return ai * bi, nil
+		}
+
+		return toFloat(a) * toFloat(b), nil
+	case "/":
+		if ints && bi != 0 {
+			return ai / bi, nil
+		}
+
+		return toFloat(a) / toFloat(b), nil
+	case "%":
+		if ints && bi != 0 {
+			return ai % bi, nil
+		}
+
+		return math.Mod(toFloat(a), toFloat(b)), nil
+	case "**":
+		if ints && bi >= 0 {
+			return int64(math.Pow(float64(ai), float64(bi))), nil
+		}
+
+		return math.Pow(toFloat(a), toFloat(b)), nil
+	case "<<", ">>":
+		if toInt(b) < 0 {
+			return nil, fmt.Errorf("%s %d: negative shift count: %w", op, toInt(b), ErrInvalidOperand)
+		}
+
+		if op == "<<" {
+			return toInt(a) << toInt(b), nil
+		}
+
+		return toInt(a) >> toInt(b), nil
+	case "&":
+		return toInt(a) & toInt(b), nil
+	case "^":
+		return toInt(a) ^ toInt(b), nil
+	case "|":
+		return toInt(a) | toInt(b), nil
+	case "&&":
+		return toBool(a) && toBool(b), nil
+	case "||":
+		return toBool(a) || toBool(b), nil
+	case "<":
+		return toFloat(a) < toFloat(b), nil
+	case "<=":
+		return toFloat(a) <= toFloat(b), nil
+	case ">":
+		return toFloat(a) > toFloat(b), nil
+	case ">=":
+		return toFloat(a) >= toFloat(b), nil
+	case "==":
+		return toFloat(a) == toFloat(b), nil
+	case "!=":
+		return toFloat(a) != toFloat(b), nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", op, ErrUnsupported)
+}
+
+func toFloat(v any) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func toInt(v any) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case bool:
+		if v {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func toBool(v any) bool {
+	switch v := v.(type) {
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case bool:
+		return v
+	}
+
+	return false
+}
+
+// cast converts the value to the scalar type.
+func cast(t gen.IScalarTypeContext, v any) any {
+	switch {
+	case t == nil:
+		return v
+	case t.INT() != nil, t.UINT() != nil:
+		return toInt(v)
+	case t.BOOL() != nil:
+		return toBool(v)
+	default:
+		return toFloat(v)
+	}
+}
+
+func zero(t gen.IScalarTypeContext) any {
+	return cast(t, int64(0))
+}
@@ -0,0 +1,333 @@
+package density
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+var (
+	ErrInvalidProbability = errors.New("invalid probability")
+	ErrTooManyQubits      = errors.New("too many qubits")
+)
+
+// Noise is the noise model. Each channel is applied to every qubit a gate acts on, after the gate.
+// ReadoutError is the probability that a measured bit is flipped.
+type Noise struct {
+	Depolarizing     float64
+	AmplitudeDamping float64
+	PhaseDamping     float64
+	BitFlip          float64
+	ReadoutError     float64
+}
+
+// Validate returns an error if a probability is not in [0, 1].
+func (n Noise) Validate() error {
+	for name, p := range map[string]float64{
+		"depolarizing":      n.Depolarizing,
+		"amplitude_damping": n.AmplitudeDamping,
+		"phase_damping":     n.PhaseDamping,
+		"bit_flip":          n.BitFlip,
+		"readout_error":     n.ReadoutError,
+	} {
+		if p < 0 || p > 1 || math.IsNaN(p) {
+			return fmt.Errorf("%s=%v: %w", name, p, ErrInvalidProbability)
+		}
+	}
+
+	return nil
+}
+
+// State is the density matrix of n qubits.
+// Rho is stored row-major, rho[i][j] = Rho[i<<n|j], where qubit 0 is the most significant bit.
+type State struct {
+	N      int
+	Rho    []complex128
+	Clbits []int
+}
+
+// New returns |0...0><0...0|.
+func New(n int) *State {
+	rho := make([]complex128, 1<<(2*n))
+	rho[0] = 1
+
+	return &State{
+		N:   n,
+		Rho: rho,
+	}
+}
+
+// Run runs the circuit with the noise model.
+// maxQubits limits the number of qubits of the vectorized density matrix, or 0 for no limit.
+func Run(c *circuit.Circuit, noise Noise, rand func() float64, maxQubits int) (*State, error) {
+	if err := noise.Validate(); err != nil {
+		return nil, err
+	}
+
+	if maxQubits > 0 && 2*c.Qubits > maxQubits {
+		return nil, fmt.Errorf("need=%d, max=%d: %w", 2*c.Qubits, maxQubits, ErrTooManyQubits)
+	}
+
+	s := New(c.Qubits)
+	s.Clbits = make([]int, c.Clbits)
+	for _, op := range c.Ops {
+		s.Step(op, noise, rand)
+	}
+
+	return s, nil
+}
+
+// Step applies the operation with the noise model, and returns the measured bit, or 0 if the operation is not a measurement.
+func (s *State) Step(op circuit.Op, noise Noise, rand func() float64) int {
+	switch op.Kind {
+	case circuit.Gate:
+		s.Apply(op.Matrix(), op.Target, op.Controls, op.NegControls)
+		for _, q := range op.Operands() {
+			s.Noise(noise, q)
+		}
+	case circuit.Measure:
+		m := s.Measure(op.Target, rand())
+		if rand() < noise.ReadoutError {
+			m ^= 1
+		}
+
+		if op.Clbit >= 0 && op.Clbit < len(s.Clbits) {
+			s.Clbits[op.Clbit] = m
+		}
+
+		return m
+	case circuit.Reset:
+		s.Reset(op.Target)
+	}
+
+	return 0
+}
+
+// Grow adds n qubits in |0> after the last qubit.
+func (s *State) Grow(n int) {
+	m := s.N + n
+	rho := make([]complex128, 1<<(2*m))
+	for i := range 1 << s.N {
+		for j := range 1 << s.N {
+			rho[(i<<n)<<m|j<<n] = s.Rho[i<<s.N|j]
+		}
+	}
+
+	s.N, s.Rho = m, rho
+}
+
+// Machine runs the operations on the state with the noise model as they are emitted by the compiler.
+// Rand is used for measurement, and MaxQubits limits the number of qubits of the vectorized density matrix, or 0 for no limit.
+type Machine struct {
+	*State
+	Noise     Noise
+	Rand      func() float64
+	MaxQubits int
+}
+
+// Grow adds n qubits in |0>.
+func (m *Machine) Grow(n int) error {
+	if m.MaxQubits > 0 && 2*(m.N+n) > m.MaxQubits {
+		return fmt.Errorf("need=%d, max=%d: %w", 2*(m.N+n), m.MaxQubits, ErrTooManyQubits)
+	}
+
+	m.State.Grow(n)
+	return nil
+}
+
+// Run runs the operation and returns the measured bit.
+func (m *Machine) Run(op circuit.Op) (int, error) {
+	return m.Step(op, m.Noise, m.Rand), nil
+}
+
+// Apply applies the controlled unitary m. rho -> U rho U^dagger.
+func (s *State) Apply(m [2][2]complex128, target int, controls, negControls []int) {
+	n := 2 * s.N
+	shift := func(qubits []int) []int {
+		out := make([]int, len(qubits))
+		for i, q := range qubits {
+			out[i] = q + s.N
+		}
+
+		return out
+	}
+
+	// U acts on the row index and conj(U) on the column index
+	circuit.Apply(s.Rho, n, m, target, controls, negControls)
+	circuit.Apply(s.Rho, n, conj(m), target+s.N, shift(controls), shift(negControls))
+}
+
+// Channel applies the Kraus operators to the qubit. rho -> sum K rho K^dagger.
+func (s *State) Channel(q int, kraus ...[2][2]complex128) {
+	out := make([]complex128, len(s.Rho))
+	for _, k := range kraus {
+		rho := make([]complex128, len(s.Rho))
+		copy(rho, s.Rho)
+
+		circuit.Apply(rho, 2*s.N, k, q, nil, nil)
+		circuit.Apply(rho, 2*s.N, conj(k), q+s.N, nil, nil)
+		for i := range out {
+			out[i] += rho[i]
+		}
+	}
+
+	s.Rho = out
+}
+
+// Noise applies the noise channels to the qubit.
+func (s *State) Noise(noise Noise, q int) {
+	if p := noise.Depolarizing; p > 0 {
+		s.Channel(q, Depolarizing(p)...)
+	}
+
+	if g := noise.AmplitudeDamping; g > 0 {
+		s.Channel(q, AmplitudeDamping(g)...)
+	}
+
+	if l := noise.PhaseDamping; l > 0 {
+		s.Channel(q, PhaseDamping(l)...)
+	}
+
+	if p := noise.BitFlip; p > 0 {
+		s.Channel(q, BitFlip(p)...)
+	}
+}
+
+// Measure measures the qubit in the computational basis and collapses the state.
+func (s *State) Measure(q int, r float64) int {
+	mask := 1 << (s.N - 1 - q)
+
+	var p1 float64
+	for i := range 1 << s.N {
+		if i&mask != 0 {
+			p1 += real(s.Rho[i<<s.N|i])
+		}
+	}
+
+	m, p := 0, 1-p1
+	if r < p1 {
+		m, p = 1, p1
+	}
+
+	for i := range 1 << s.N {
+		for j := range 1 << s.N {
+			k := i<<s.N | j
+			if (i&mask != 0) != (m == 1) || (j&mask != 0) != (m == 1) {
+				s.Rho[k] = 0
+				continue
+			}
+
+			s.Rho[k] /= complex(p, 0)
+		}
+	}
+
+	return m
+}
+
+// Reset resets the qubit to |0>.
+func (s *State) Reset(q int) {
+	s.Channel(q,
+		[2][2]complex128{{1, 0}, {0, 0}},
+		[2][2]complex128{{0, 1}, {0, 0}},
+	)
+}
+
+// Diagonal returns the probabilities of the computational basis states.
+func (s *State) Diagonal() []float64 {
+	out := make([]float64, 1<<s.N)
+	for i := range out {
+		out[i] = real(s.Rho[i<<s.N|i])
+	}
+
+	return out
+}
+
+// Sample returns a computational basis state sampled from the diagonal.
+// Each bit is flipped with the probability readout.
+func (s *State) Sample(rand func() float64, readout float64) int {
+	diag := s.Diagonal()
+
+	k, r, acc := len(diag)-1, rand(), 0.0
+	for i, p := range diag {
+		acc += p
+		if r < acc {
+			k = i
+			break
+		}
+	}
+
+	if readout > 0 {
+		for q := range s.N {
+			if rand() < readout {
+				k ^= 1 << q
+			}
+		}
+	}
+
+	return k
+}
+
+// Purity returns tr(rho^2).
+func (s *State) Purity() float64 {
+	var sum float64
+	for _, v := range s.Rho {
+		sum += real(v)*real(v) + imag(v)*imag(v)
+	}
+
+	return sum
+}
+
+// Qubits returns the number of qubits of the density matrix of size len(rho).
+func Qubits(rho []complex128) int {
+	return (bits.Len(uint(len(rho))) - 1) / 2
+}
+
+// Depolarizing returns the Kraus operators of the depolarizing channel.
+func Depolarizing(p float64) [][2][2]complex128 {
+	a, b := complex(math.Sqrt(1-p), 0), complex(math.Sqrt(p/3), 0)
+	return [][2][2]complex128{
+		{{a, 0}, {0, a}},
+		{{0, b}, {b, 0}},
+		{{0, -1i * b}, {1i * b, 0}},
+		{{b, 0}, {0, -b}},
+	}
+}
+
+// AmplitudeDamping returns the Kraus operators of the amplitude damping channel.
+func AmplitudeDamping(gamma float64) [][2][2]complex128 {
+	return [][2][2]complex128{
+		{{1, 0}, {0, complex(math.Sqrt(1-gamma), 0)}},
+		{{0, complex(math.Sqrt(gamma), 0)}, {0, 0}},
+	}
+}
+
+// PhaseDamping returns the Kraus operators of the phase damping channel.
+func PhaseDamping(lambda float64) [][2][2]complex128 {
+	return [][2][2]complex128{
+		{{1, 0}, {0, complex(math.Sqrt(1-lambda), 0)}},
+		{{0, 0}, {0, complex(math.Sqrt(lambda), 0)}},
+	}
+}
+
+// BitFlip returns the Kraus operators of the bit flip channel.
+func BitFlip(p float64) [][2][2]complex128 {
+	a, b := complex(math.Sqrt(1-p), 0), complex(math.Sqrt(p), 0)
+	return [][2][2]complex128{
+		{{a, 0}, {0, a}},
+		{{0, b}, {b, 0}},
+	}
+}
+
+func conj(m [2][2]complex128) [2][2]complex128 {
+	var out [2][2]complex128
+	for i := range 2 {
+		for j := range 2 {
+			out[i][j] = complex(real(m[i][j]), -imag(m[i][j]))
+		}
+	}
+
+	return out
+}
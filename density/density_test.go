@@ -0,0 +1,186 @@
+package density_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/density"
+)
+
+var (
+	h = circuit.Op{Kind: circuit.Gate, Theta: math.Pi / 2, Lambda: math.Pi, Clbit: -1}
+	x = circuit.Op{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Clbit: -1}
+)
+
+func bell() *circuit.Circuit {
+	cx := x
+	cx.Target, cx.Controls = 1, []int{0}
+
+	return &circuit.Circuit{
+		Qubits: 2,
+		Ops:    []circuit.Op{h, cx},
+	}
+}
+
+func ExampleRun() {
+	s, err := density.Run(bell(), density.Noise{}, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.2f\n", s.Diagonal())
+	fmt.Printf("%.2f\n", real(s.Rho[0<<2|3]))
+	fmt.Printf("%.2f\n", s.Purity())
+
+	// Output:
+	// [0.50 0.00 0.00 0.50]
+	// 0.50
+	// 1.00
+}
+
+func ExampleRun_depolarizing() {
+	s, err := density.Run(bell(), density.Noise{Depolarizing: 0.1}, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.4f\n", s.Diagonal())
+	fmt.Println(s.Purity() < 1)
+
+	// Output:
+	// [0.4378 0.0622 0.0622 0.4378]
+	// true
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		noise density.Noise
+		ops   []circuit.Op
+		want  []float64
+	}{
+		{density.Noise{}, []circuit.Op{x}, []float64{0, 1}},
+		{density.Noise{AmplitudeDamping: 1}, []circuit.Op{x}, []float64{1, 0}},
+		{density.Noise{AmplitudeDamping: 0.25}, []circuit.Op{x}, []float64{0.25, 0.75}},
+		{density.Noise{BitFlip: 0.1}, []circuit.Op{x}, []float64{0.1, 0.9}},
+		{density.Noise{PhaseDamping: 0.5}, []circuit.Op{x}, []float64{0, 1}},
+		{density.Noise{}, []circuit.Op{x, {Kind: circuit.Reset, Clbit: -1}}, []float64{1, 0}},
+	}
+
+	for _, c := range cases {
+		s, err := density.Run(&circuit.Circuit{Qubits: 1, Ops: c.ops}, c.noise, nil, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		got := s.Diagonal()
+		for i := range got {
+			if math.Abs(got[i]-c.want[i]) > 1e-12 {
+				t.Errorf("%+v: got=%v, want=%v", c.noise, got, c.want)
+			}
+		}
+	}
+}
+
+func TestRun_phaseDamping(t *testing.T) {
+	s, err := density.Run(&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{h}}, density.Noise{PhaseDamping: 1}, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// coherence is lost
+	if math.Abs(real(s.Rho[1])) > 1e-12 {
+		t.Errorf("got=%v", s.Rho)
+	}
+}
+
+func TestRun_measure(t *testing.T) {
+	c := bell()
+	c.Clbits = 2
+	c.Ops = append(c.Ops,
+		circuit.Op{Kind: circuit.Measure, Target: 0, Clbit: 0},
+		circuit.Op{Kind: circuit.Measure, Target: 1, Clbit: 1},
+	)
+
+	for _, r := range []float64{0.2, 0.8} {
+		s, err := density.Run(c, density.Noise{}, func() float64 { return r }, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if s.Clbits[0] != s.Clbits[1] {
+			t.Errorf("got=%v", s.Clbits)
+		}
+
+		if math.Abs(s.Purity()-1) > 1e-12 {
+			t.Errorf("purity=%v", s.Purity())
+		}
+	}
+}
+
+func TestRun_invalid(t *testing.T) {
+	cases := []struct {
+		noise     density.Noise
+		maxQubits int
+		err       error
+	}{
+		{density.Noise{Depolarizing: -0.1}, 0, density.ErrInvalidProbability},
+		{density.Noise{ReadoutError: 1.5}, 0, density.ErrInvalidProbability},
+		{density.Noise{}, 3, density.ErrTooManyQubits},
+	}
+
+	for _, c := range cases {
+		if _, err := density.Run(bell(), c.noise, nil, c.maxQubits); !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+	}
+}
+
+func TestState_Sample(t *testing.T) {
+	s := density.New(2)
+
+	if got := s.Sample(func() float64 { return 0.5 }, 0); got != 0 {
+		t.Errorf("got=%v", got)
+	}
+
+	// readout error flips every bit
+	if got := s.Sample(func() float64 { return 0 }, 1); got != 3 {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestMachine(t *testing.T) {
+	want, err := density.Run(bell(), density.Noise{Depolarizing: 0.1}, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// the qubits are added as they are declared
+	m := &density.Machine{
+		State: density.New(0),
+		Noise: density.Noise{Depolarizing: 0.1},
+	}
+
+	for _, op := range bell().Ops {
+		if err := m.Grow(1); err != nil {
+			t.Fatalf("grow: %v", err)
+		}
+
+		if _, err := m.Run(op); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	}
+
+	for i := range want.Rho {
+		if math.Abs(real(m.Rho[i]-want.Rho[i])) > 1e-12 || math.Abs(imag(m.Rho[i]-want.Rho[i])) > 1e-12 {
+			t.Fatalf("got=%v, want=%v", m.Rho, want.Rho)
+		}
+	}
+
+	m.MaxQubits = 4
+	if err := m.Grow(1); !errors.Is(err, density.ErrTooManyQubits) {
+		t.Errorf("got=%v, want=%v", err, density.ErrTooManyQubits)
+	}
+}
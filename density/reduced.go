@@ -0,0 +1,188 @@
+package density
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+var ErrInvalidSubsystem = errors.New("invalid subsystem")
+
+// Reduced returns the reduced density matrix of the qubits in keep, tracing out the others from the pure state amp.
+// The qubits of the reduced density matrix are in the order of keep.
+func Reduced(amp []complex128, keep []int) ([]complex128, error) {
+	n := bits.Len(uint(len(amp))) - 1
+	sub, env, err := split(n, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	// rho = M M^dagger, where M[a][e] = amp[a, e]
+	d, de := 1<<len(keep), 1<<(n-len(keep))
+	m := make([]complex128, d*de)
+	for k, v := range amp {
+		m[sub[k]*de+env[k]] = v
+	}
+
+	rho := make([]complex128, d*d)
+	for a := range d {
+		for b := range d {
+			var sum complex128
+			for e := range de {
+				v := m[b*de+e]
+				sum += m[a*de+e] * complex(real(v), -imag(v))
+			}
+
+			rho[a*d+b] = sum
+		}
+	}
+
+	return rho, nil
+}
+
+// PartialTrace returns the reduced density matrix of the qubits in keep, tracing out the others from rho.
+// The qubits of the reduced density matrix are in the order of keep.
+func PartialTrace(rho []complex128, keep []int) ([]complex128, error) {
+	n := Qubits(rho)
+	sub, env, err := split(n, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	d, dim := 1<<len(keep), 1<<n
+	out := make([]complex128, d*d)
+	for i := range dim {
+		for j := range dim {
+			if env[i] != env[j] {
+				continue
+			}
+
+			out[sub[i]*d+sub[j]] += rho[i*dim+j]
+		}
+	}
+
+	return out, nil
+}
+
+// Bloch returns the Bloch vector of the single-qubit density matrix.
+func Bloch(rho []complex128) (x, y, z float64) {
+	return 2 * real(rho[1]), 2 * imag(rho[2]), real(rho[0] - rho[3])
+}
+
+// Entropy returns the von Neumann entropy -tr(rho log2 rho) in bits.
+func Entropy(rho []complex128) float64 {
+	var s float64
+	for _, v := range Eigenvalues(rho) {
+		if v > 1e-12 {
+			s -= v * math.Log2(v)
+		}
+	}
+
+	// rounding errors of eigenvalues close to 1
+	return max(s, 0)
+}
+
+// Eigenvalues returns the eigenvalues of the Hermitian matrix h in ascending order.
+func Eigenvalues(h []complex128) []float64 {
+	n := int(math.Sqrt(float64(len(h))))
+
+	// the real symmetric matrix [[A, -B], [B, A]] of h = A + iB has each eigenvalue of h twice
+	m := 2 * n
+	a := make([]float64, m*m)
+	for i := range n {
+		for j := range n {
+			re, im := real(h[i*n+j]), imag(h[i*n+j])
+			a[i*m+j], a[(i+n)*m+j+n] = re, re
+			a[i*m+j+n], a[(i+n)*m+j] = -im, im
+		}
+	}
+
+	jacobi(a, m)
+
+	diag := make([]float64, m)
+	for i := range m {
+		diag[i] = a[i*m+i]
+	}
+	slices.Sort(diag)
+
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = (diag[2*i] + diag[2*i+1]) / 2
+	}
+
+	return out
+}
+
+// jacobi diagonalizes the real symmetric m x m matrix a in place with the cyclic Jacobi method.
+func jacobi(a []float64, m int) {
+	for range 100 {
+		var off float64
+		for p := range m {
+			for q := p + 1; q < m; q++ {
+				off += a[p*m+q] * a[p*m+q]
+			}
+		}
+
+		if off < 1e-24 {
+			return
+		}
+
+		for p := range m {
+			for q := p + 1; q < m; q++ {
+				apq := a[p*m+q]
+				if apq == 0 {
+					continue
+				}
+
+				theta := (a[q*m+q] - a[p*m+p]) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := range m {
+					akp, akq := a[k*m+p], a[k*m+q]
+					a[k*m+p], a[k*m+q] = c*akp-s*akq, s*akp+c*akq
+				}
+
+				for k := range m {
+					apk, aqk := a[p*m+k], a[q*m+k]
+					a[p*m+k], a[q*m+k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+			}
+		}
+	}
+}
+
+// split returns the index of each basis state in the subsystem keep and in the rest.
+func split(n int, keep []int) (sub, env []int, err error) {
+	for i, q := range keep {
+		if q < 0 || q >= n || slices.Contains(keep[:i], q) {
+			return nil, nil, fmt.Errorf("qubit=%d: %w", q, ErrInvalidSubsystem)
+		}
+	}
+
+	var rest []int
+	for q := range n {
+		if !slices.Contains(keep, q) {
+			rest = append(rest, q)
+		}
+	}
+
+	sub, env = make([]int, 1<<n), make([]int, 1<<n)
+	for k := range 1 << n {
+		for _, q := range keep {
+			sub[k] = sub[k]<<1 | (k>>(n-1-q))&1
+		}
+
+		for _, q := range rest {
+			env[k] = env[k]<<1 | (k>>(n-1-q))&1
+		}
+	}
+
+	return sub, env, nil
+}
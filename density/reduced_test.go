@@ -0,0 +1,158 @@
+package density_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/itsubaki/quasar/density"
+)
+
+func ExampleReduced() {
+	// (|00> + |11>)/sqrt(2)
+	amp := []complex128{complex(1/math.Sqrt2, 0), 0, 0, complex(1/math.Sqrt2, 0)}
+
+	rho, err := density.Reduced(amp, []int{0})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.2f\n", abs(rho))
+	fmt.Println(density.Bloch(rho))
+	fmt.Printf("%.4f\n", density.Entropy(rho))
+
+	// Output:
+	// [0.50 0.00 0.00 0.50]
+	// 0 0 0
+	// 1.0000
+}
+
+func abs(v []complex128) []float64 {
+	out := make([]float64, len(v))
+	for i := range v {
+		out[i] = cmplx.Abs(v[i])
+	}
+
+	return out
+}
+
+func TestReduced(t *testing.T) {
+	h := complex(1/math.Sqrt2, 0)
+
+	cases := []struct {
+		amp     []complex128
+		keep    []int
+		x, y, z float64
+	}{
+		{[]complex128{1, 0}, []int{0}, 0, 0, 1},
+		{[]complex128{0, 1}, []int{0}, 0, 0, -1},
+		{[]complex128{h, h}, []int{0}, 1, 0, 0},
+		{[]complex128{h, 1i * h}, []int{0}, 0, 1, 0},
+		// |0> x |+>
+		{[]complex128{h, h, 0, 0}, []int{0}, 0, 0, 1},
+		{[]complex128{h, h, 0, 0}, []int{1}, 1, 0, 0},
+		// |1> x |0>
+		{[]complex128{0, 0, 1, 0}, []int{1}, 0, 0, 1},
+	}
+
+	for _, c := range cases {
+		rho, err := density.Reduced(c.amp, c.keep)
+		if err != nil {
+			t.Fatalf("reduced: %v", err)
+		}
+
+		x, y, z := density.Bloch(rho)
+		if math.Abs(x-c.x) > 1e-12 || math.Abs(y-c.y) > 1e-12 || math.Abs(z-c.z) > 1e-12 {
+			t.Errorf("got=%v, %v, %v, want=%v, %v, %v", x, y, z, c.x, c.y, c.z)
+		}
+	}
+}
+
+func TestPartialTrace(t *testing.T) {
+	// |01> + |10> on 3 qubits with qubit 2 in |1>
+	amp := make([]complex128, 8)
+	amp[0b011], amp[0b101] = complex(1/math.Sqrt2, 0), complex(1/math.Sqrt2, 0)
+
+	// |amp><amp|
+	rho := make([]complex128, 64)
+	for i := range amp {
+		for j := range amp {
+			rho[i*8+j] = amp[i] * cmplx.Conj(amp[j])
+		}
+	}
+
+	for _, keep := range [][]int{{0}, {1}, {2}, {0, 1}, {2, 0}, {1, 2, 0}} {
+		got, err := density.PartialTrace(rho, keep)
+		if err != nil {
+			t.Fatalf("partial trace: %v", err)
+		}
+
+		want, err := density.Reduced(amp, keep)
+		if err != nil {
+			t.Fatalf("reduced: %v", err)
+		}
+
+		for i := range want {
+			if cmplx.Abs(got[i]-want[i]) > 1e-12 {
+				t.Errorf("keep=%v, got=%v, want=%v", keep, got, want)
+				break
+			}
+		}
+	}
+
+	// the entanglement between qubits 0 and 1 only
+	cases := []struct {
+		keep    []int
+		entropy float64
+	}{
+		{[]int{0}, 1},
+		{[]int{1}, 1},
+		{[]int{2}, 0},
+		{[]int{0, 1}, 0},
+		{[]int{0, 2}, 1},
+	}
+
+	for _, c := range cases {
+		r, err := density.PartialTrace(rho, c.keep)
+		if err != nil {
+			t.Fatalf("partial trace: %v", err)
+		}
+
+		if got := density.Entropy(r); math.Abs(got-c.entropy) > 1e-9 {
+			t.Errorf("keep=%v, got=%v, want=%v", c.keep, got, c.entropy)
+		}
+	}
+}
+
+func TestPartialTrace_invalid(t *testing.T) {
+	for _, keep := range [][]int{{-1}, {2}, {0, 0}} {
+		if _, err := density.PartialTrace(density.New(2).Rho, keep); !errors.Is(err, density.ErrInvalidSubsystem) {
+			t.Errorf("got=%v, want=%v", err, density.ErrInvalidSubsystem)
+		}
+	}
+}
+
+func TestEigenvalues(t *testing.T) {
+	cases := []struct {
+		h    []complex128
+		want []float64
+	}{
+		{[]complex128{1, 0, 0, 0}, []float64{0, 1}},
+		{[]complex128{0, 1, 1, 0}, []float64{-1, 1}},
+		{[]complex128{0, -1i, 1i, 0}, []float64{-1, 1}},
+		{[]complex128{2, 1 - 1i, 1 + 1i, 3}, []float64{1, 4}},
+		{[]complex128{0.25, 0, 0, 0, 0, 0.25, 0, 0, 0, 0, 0.25, 0, 0, 0, 0, 0.25}, []float64{0.25, 0.25, 0.25, 0.25}},
+	}
+
+	for _, c := range cases {
+		got := density.Eigenvalues(c.h)
+		for i := range c.want {
+			if math.Abs(got[i]-c.want[i]) > 1e-9 {
+				t.Errorf("got=%v, want=%v", got, c.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package draw
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+// ASCII returns the circuit diagram as ASCII text.
+// Controls are drawn as "*", negated controls as "o", and the target of a controlled X as "(+)".
+func ASCII(c *circuit.Circuit, expand bool) string {
+	wires := Wires(c)
+	width := 0
+	for _, w := range wires {
+		width = max(width, utf8.RuneCountInString(w))
+	}
+
+	// wire rows and the gaps between them
+	rows := make([]strings.Builder, max(2*c.Qubits-1, 0))
+	for q, w := range wires {
+		fmt.Fprintf(&rows[2*q], "%-*s: ", width, w)
+		if q+1 < c.Qubits {
+			rows[2*q+1].WriteString(strings.Repeat(" ", width+2))
+		}
+	}
+
+	for _, col := range Columns(Elements(c, expand), c.Qubits) {
+		cells := make([]string, len(rows))
+		for _, e := range col {
+			lo, hi := e.Span()
+			for q := lo; q <= hi; q++ {
+				cells[2*q] = symbol(e, q)
+			}
+
+			if e.Kind == Barrier {
+				continue
+			}
+
+			for r := 2 * lo; r < 2*hi; r += 2 {
+				cells[r+1] = "|"
+			}
+		}
+
+		w := 1
+		for _, cell := range cells {
+			w = max(w, utf8.RuneCountInString(cell))
+		}
+
+		for r := range rows {
+			fill := "-"
+			if r%2 == 1 {
+				fill = " "
+			}
+
+			n := utf8.RuneCountInString(cells[r])
+			left := (w - n) / 2
+			rows[r].WriteString(fill)
+			rows[r].WriteString(strings.Repeat(fill, left))
+			rows[r].WriteString(cells[r])
+			rows[r].WriteString(strings.Repeat(fill, w-n-left))
+			rows[r].WriteString(fill)
+		}
+	}
+
+	var sb strings.Builder
+	for r := range rows {
+		line := rows[r].String()
+		if r%2 == 0 {
+			line += "-"
+		}
+
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+func symbol(e Element, q int) string {
+	switch {
+	case slices.Contains(e.Controls, q):
+		return "*"
+	case slices.Contains(e.NegControls, q):
+		return "o"
+	case !slices.Contains(e.Targets, q):
+		// the vertical line crossing the wire
+		if e.Kind == Barrier {
+			return ""
+		}
+
+		return "|"
+	}
+
+	switch e.Kind {
+	case Targ:
+		return "(+)"
+	case Swap:
+		return "x"
+	case Barrier:
+		return "|"
+	default:
+		return "[" + e.Label() + "]"
+	}
+}
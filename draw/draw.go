@@ -0,0 +1,280 @@
+package draw
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+type Kind int
+
+const (
+	Box Kind = iota
+	Targ
+	Swap
+	Measure
+	Reset
+	Barrier
+)
+
+// Element is an operation in the diagram.
+// A Box is drawn on each target, a Targ is the target of a controlled X, and a Swap exchanges its two targets.
+// Controls and NegControls are connected to the targets by a vertical line.
+// Condition is the branch on measured bits the element is in, e.g. "c==1", or empty.
+type Element struct {
+	Kind        Kind
+	Name        string
+	Params      []float64
+	Power       float64
+	Targets     []int
+	Controls    []int
+	NegControls []int
+	Condition   string
+}
+
+// Span returns the lowest and highest qubits of the element.
+func (e Element) Span() (lo, hi int) {
+	qubits := slices.Concat(e.Targets, e.Controls, e.NegControls)
+	return slices.Min(qubits), slices.Max(qubits)
+}
+
+// Label returns the name with the params, the power and the condition, e.g. "rz(pi/2)", "h^-1" or "x if c==1".
+func (e Element) Label() string {
+	switch e.Kind {
+	case Measure:
+		return "M"
+	case Reset:
+		return "|0>"
+	}
+
+	label := e.Name
+	if len(e.Params) > 0 {
+		params := make([]string, len(e.Params))
+		for i, p := range e.Params {
+			params[i] = Angle(p)
+		}
+
+		label = fmt.Sprintf("%s(%s)", label, strings.Join(params, ", "))
+	}
+
+	if e.Power != 0 && e.Power != 1 {
+		label = fmt.Sprintf("%s^%s", label, strconv.FormatFloat(e.Power, 'g', 4, 64))
+	}
+
+	if e.Condition != "" {
+		label = fmt.Sprintf("%s if %s", label, e.Condition)
+	}
+
+	return label
+}
+
+// Angle returns the angle as a multiple of pi if possible, e.g. "pi/2" or "-3pi/4".
+func Angle(v float64) string {
+	for _, d := range []int{1, 2, 3, 4, 6, 8} {
+		n := v * float64(d) / math.Pi
+		r := math.Round(n)
+		if r == 0 || math.Abs(n-r) > 1e-9 {
+			continue
+		}
+
+		var s string
+		switch r {
+		case 1:
+			s = "pi"
+		case -1:
+			s = "-pi"
+		default:
+			s = fmt.Sprintf("%dpi", int(r))
+		}
+
+		if d > 1 {
+			s = fmt.Sprintf("%s/%d", s, d)
+		}
+
+		return s
+	}
+
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// Elements returns the elements of the circuit in order.
+// The operations expanded from a gate call are drawn as the called gate unless expand is true.
+// An operation in a branch on measured bits is drawn as a box labeled with the condition.
+// Global phases are not drawn.
+func Elements(c *circuit.Circuit, expand bool) []Element {
+	var out []Element
+	for i := 0; i < len(c.Ops); {
+		// operations expanded from the same statement
+		call, j := c.Ops[i].Call, i+1
+		for call != nil && j < len(c.Ops) && c.Ops[j].Call == call {
+			j++
+		}
+
+		if !expand && call != nil && call.Gate != "" && len(call.Qubits) > 0 {
+			out = append(out, conditional(fromCall(call), c.Ops[i].Condition))
+			i = j
+			continue
+		}
+
+		for _, op := range c.Ops[i:j] {
+			if e, ok := fromOp(op); ok {
+				out = append(out, conditional(e, op.Condition))
+			}
+		}
+
+		i = j
+	}
+
+	return out
+}
+
+// Columns assigns the elements to columns in order, so that the elements in a column do not overlap.
+func Columns(elems []Element, qubits int) [][]Element {
+	var out [][]Element
+	next := make([]int, qubits)
+	for _, e := range elems {
+		lo, hi := e.Span()
+
+		var col int
+		for q := lo; q <= hi; q++ {
+			col = max(col, next[q])
+		}
+
+		for q := lo; q <= hi; q++ {
+			next[q] = col + 1
+		}
+
+		if col == len(out) {
+			out = append(out, nil)
+		}
+
+		out[col] = append(out[col], e)
+	}
+
+	return out
+}
+
+// Wires returns the label of each qubit, e.g. "q[0]", or "q" for a single qubit register.
+func Wires(c *circuit.Circuit) []string {
+	out := make([]string, c.Qubits)
+	for i := range out {
+		out[i] = strconv.Itoa(i)
+	}
+
+	for _, r := range c.QRegs {
+		for i, q := range r.Index {
+			out[q] = fmt.Sprintf("%s[%d]", r.Name, i)
+			if len(r.Index) == 1 {
+				out[q] = r.Name
+			}
+		}
+	}
+
+	return out
+}
+
+func fromCall(call *circuit.Call) Element {
+	e := Element{
+		Kind:        Box,
+		Name:        call.Gate,
+		Params:      call.Params,
+		Power:       call.Power,
+		Controls:    slices.Clone(call.Controls),
+		NegControls: slices.Clone(call.NegControls),
+	}
+
+	for _, q := range call.Qubits {
+		if slices.Contains(e.Controls, q) || slices.Contains(e.NegControls, q) {
+			continue
+		}
+
+		e.Targets = append(e.Targets, q)
+	}
+
+	// e.g. ctrl @ gphase(a) q
+	if len(e.Targets) == 0 {
+		if len(e.Controls) > 0 {
+			e.Targets, e.Controls = e.Controls[len(e.Controls)-1:], e.Controls[:len(e.Controls)-1]
+		} else {
+			e.Targets, e.NegControls = e.NegControls[len(e.NegControls)-1:], e.NegControls[:len(e.NegControls)-1]
+		}
+	}
+
+	if e.Power != 1 {
+		return e
+	}
+
+	// the controls of the standard gates
+	n := len(e.Targets)
+	switch strings.ToLower(e.Name) {
+	case "x", "cx", "cnot", "ccx", "toffoli":
+		e.Controls = append(e.Controls, e.Targets[:n-1]...)
+		e.Targets = e.Targets[n-1:]
+		if len(e.Controls)+len(e.NegControls) > 0 {
+			e.Kind = Targ
+		}
+	case "cz":
+		e.Name = "z"
+		e.Controls = append(e.Controls, e.Targets[:n-1]...)
+		e.Targets = e.Targets[n-1:]
+	case "swap", "cswap":
+		if n >= 2 {
+			e.Kind = Swap
+			e.Controls = append(e.Controls, e.Targets[:n-2]...)
+			e.Targets = e.Targets[n-2:]
+		}
+	}
+
+	return e
+}
+
+func conditional(e Element, cond *circuit.Condition) Element {
+	if cond == nil || e.Kind == Barrier {
+		return e
+	}
+
+	if e.Kind == Measure || e.Kind == Reset {
+		e.Name = e.Label()
+	}
+
+	e.Kind, e.Condition = Box, cond.String()
+	return e
+}
+
+func fromOp(op circuit.Op) (Element, bool) {
+	switch op.Kind {
+	case circuit.Gate:
+		name, params := circuit.Name(op)
+		e := Element{
+			Kind:        Box,
+			Name:        name,
+			Params:      params,
+			Power:       1,
+			Targets:     []int{op.Target},
+			Controls:    slices.Clone(op.Controls),
+			NegControls: slices.Clone(op.NegControls),
+		}
+
+		if name == "x" && len(e.Controls)+len(e.NegControls) > 0 {
+			e.Kind = Targ
+		}
+
+		return e, true
+	case circuit.Measure:
+		return Element{Kind: Measure, Targets: []int{op.Target}}, true
+	case circuit.Reset:
+		return Element{Kind: Reset, Targets: []int{op.Target}}, true
+	case circuit.Barrier:
+		if len(op.Qubits) == 0 {
+			return Element{}, false
+		}
+
+		return Element{Kind: Barrier, Targets: slices.Clone(op.Qubits)}, true
+	default:
+		return Element{}, false
+	}
+}
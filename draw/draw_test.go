@@ -0,0 +1,228 @@
+package draw_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/draw"
+)
+
+func bell() *circuit.Circuit {
+	h := &circuit.Call{Name: "h", Qubits: []int{0}, Gate: "h", Power: 1}
+	cx := &circuit.Call{Name: "cx", Qubits: []int{0, 1}, Gate: "cx", Power: 1}
+	m := &circuit.Call{Name: "measure"}
+
+	return &circuit.Circuit{
+		Qubits: 2,
+		Clbits: 2,
+		QRegs:  []circuit.Register{{Name: "q", Index: []int{0, 1}}},
+		CRegs:  []circuit.Register{{Name: "c", Index: []int{0, 1}}},
+		Ops: []circuit.Op{
+			{Kind: circuit.Gate, Theta: math.Pi / 2, Lambda: math.Pi, Target: 0, Clbit: -1, Call: h},
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, Controls: []int{0}, Clbit: -1, Call: cx},
+			{Kind: circuit.Measure, Target: 0, Clbit: 0, Call: m},
+			{Kind: circuit.Measure, Target: 1, Clbit: 1, Call: m},
+		},
+	}
+}
+
+func ExampleASCII() {
+	fmt.Print(draw.ASCII(bell(), false))
+
+	// Output:
+	// q[0]: -[h]---*---[M]--
+	//              |
+	// q[1]: ------(+)--[M]--
+}
+
+func ExampleQuantikz() {
+	fmt.Print(draw.Quantikz(bell(), false))
+
+	// Output:
+	// \begin{quantikz}
+	// \lstick{q[0]} & \gate{\mathrm{h}} & \ctrl{1} & \meter{} & \qw \\
+	// \lstick{q[1]} & \qw & \targ{} & \meter{} & \qw
+	// \end{quantikz}
+}
+
+func TestElements(t *testing.T) {
+	// ctrl @ negctrl @ inv @ foo(pi/2) q[0], q[3], q[1], q[2];
+	call := &circuit.Call{
+		Name:        "ctrl @ negctrl @ inv @ foo",
+		Params:      []float64{math.Pi / 2},
+		Qubits:      []int{0, 3, 1, 2},
+		Gate:        "foo",
+		Controls:    []int{0},
+		NegControls: []int{3},
+		Power:       -1,
+	}
+
+	c := &circuit.Circuit{
+		Qubits: 4,
+		QRegs:  []circuit.Register{{Name: "q", Index: []int{0, 1, 2, 3}}},
+		Ops: []circuit.Op{
+			{Kind: circuit.Gate, Lambda: -math.Pi / 4, Target: 1, Controls: []int{0}, NegControls: []int{3}, Clbit: -1, Call: call},
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 2, Controls: []int{1, 0}, NegControls: []int{3}, Clbit: -1, Call: call},
+			{Kind: circuit.GlobalPhase, Phase: math.Pi, Clbit: -1},
+			{Kind: circuit.Barrier, Qubits: []int{0, 1}, Clbit: -1},
+		},
+	}
+
+	boxed := draw.Elements(c, false)
+	if len(boxed) != 2 {
+		t.Fatalf("got=%v", boxed)
+	}
+
+	if got := boxed[0]; got.Label() != "foo(pi/2)^-1" || fmt.Sprint(got.Targets, got.Controls, got.NegControls) != "[1 2] [0] [3]" {
+		t.Errorf("got=%+v", got)
+	}
+
+	expanded := draw.Elements(c, true)
+	if len(expanded) != 3 {
+		t.Fatalf("got=%v", expanded)
+	}
+
+	if got := expanded[0]; got.Kind != draw.Box || got.Label() != "tdg" {
+		t.Errorf("got=%+v", got)
+	}
+
+	if got := expanded[1]; got.Kind != draw.Targ || fmt.Sprint(got.Targets, got.Controls) != "[2] [1 0]" {
+		t.Errorf("got=%+v", got)
+	}
+
+	if got := expanded[2]; got.Kind != draw.Barrier {
+		t.Errorf("got=%+v", got)
+	}
+
+	for _, s := range []string{
+		draw.ASCII(c, false),
+		draw.ASCII(c, true),
+		draw.SVG(c, false),
+		draw.SVG(c, true),
+		draw.Quantikz(c, false),
+		draw.Quantikz(c, true),
+	} {
+		if len(s) == 0 {
+			t.Errorf("empty")
+		}
+	}
+
+	if got := draw.Quantikz(c, false); !strings.Contains(got, `\gate[2]{\mathrm{foo}(\pi/2)^{\dagger}}`) || !strings.Contains(got, `\octrl{-2}`) {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestElements_condition(t *testing.T) {
+	cond := &circuit.Condition{Expr: "c==1"}
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Clbits: 1,
+		Ops: []circuit.Op{
+			{Kind: circuit.Measure, Target: 0, Clbit: 0},
+			{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, Controls: []int{0}, Clbit: -1, Condition: cond},
+			{Kind: circuit.Reset, Target: 0, Clbit: -1, Condition: &circuit.Condition{Expr: "c==1", Else: true}},
+		},
+	}
+
+	elems := draw.Elements(c, true)
+	if len(elems) != 3 {
+		t.Fatalf("got=%v", elems)
+	}
+
+	for i, want := range []string{"M", "x if c==1", "|0> if !(c==1)"} {
+		if got := elems[i].Label(); got != want {
+			t.Errorf("got=%v, want=%v", got, want)
+		}
+	}
+
+	if got := elems[1]; got.Kind != draw.Box || fmt.Sprint(got.Targets, got.Controls) != "[1] [0]" {
+		t.Errorf("got=%+v", got)
+	}
+
+	if got := draw.ASCII(c, true); !strings.Contains(got, "[x if c==1]") {
+		t.Errorf("got=%v", got)
+	}
+
+	if got := draw.Quantikz(c, true); !strings.Contains(got, `\gate{\mathrm{x}\ \text{if c==1}}`) {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestElements_standard(t *testing.T) {
+	cases := []struct {
+		call *circuit.Call
+		kind draw.Kind
+		want string
+	}{
+		{&circuit.Call{Gate: "x", Qubits: []int{0}, Power: 1}, draw.Box, "[0] []"},
+		{&circuit.Call{Gate: "x", Qubits: []int{0, 1}, Controls: []int{0}, Power: 1}, draw.Targ, "[1] [0]"},
+		{&circuit.Call{Gate: "ccx", Qubits: []int{2, 0, 1}, Power: 1}, draw.Targ, "[1] [2 0]"},
+		{&circuit.Call{Gate: "cz", Qubits: []int{0, 1}, Power: 1}, draw.Box, "[1] [0]"},
+		{&circuit.Call{Gate: "cswap", Qubits: []int{0, 1, 2}, Power: 1}, draw.Swap, "[1 2] [0]"},
+		{&circuit.Call{Gate: "gphase", Params: []float64{1}, Qubits: []int{0, 1}, Controls: []int{0, 1}, Power: 1}, draw.Box, "[1] [0]"},
+		{&circuit.Call{Gate: "cx", Qubits: []int{0, 1}, Power: 2}, draw.Box, "[0 1] []"},
+	}
+
+	for _, c := range cases {
+		elems := draw.Elements(&circuit.Circuit{
+			Qubits: 3,
+			Ops:    []circuit.Op{{Kind: circuit.Gate, Call: c.call}},
+		}, false)
+
+		if got := elems[0]; got.Kind != c.kind || fmt.Sprint(got.Targets, got.Controls) != c.want {
+			t.Errorf("got=%+v", got)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	elems := []draw.Element{
+		{Targets: []int{0}},
+		{Targets: []int{2}},
+		{Targets: []int{2}, Controls: []int{0}},
+		{Targets: []int{1}},
+		{Targets: []int{3}},
+	}
+
+	var got []int
+	for _, col := range draw.Columns(elems, 4) {
+		got = append(got, len(col))
+	}
+
+	if fmt.Sprint(got) != "[3 1 1]" {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestAngle(t *testing.T) {
+	cases := []struct {
+		v    float64
+		want string
+	}{
+		{0, "0"},
+		{math.Pi, "pi"},
+		{-math.Pi / 2, "-pi/2"},
+		{3 * math.Pi / 4, "3pi/4"},
+		{2 * math.Pi, "2pi"},
+		{math.Pi / 3, "pi/3"},
+		{1.5, "1.5"},
+	}
+
+	for _, c := range cases {
+		if got := draw.Angle(c.v); got != c.want {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	got := draw.SVG(bell(), false)
+	for _, want := range []string{"<svg", ">q[0]</text>", ">h</text>", `r="10"`, ">M</text>", "</svg>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q not found", want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package draw
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+// Quantikz returns the circuit diagram as a LaTeX quantikz environment.
+func Quantikz(c *circuit.Circuit, expand bool) string {
+	wires := Wires(c)
+	rows := make([][]string, c.Qubits)
+	for q, w := range wires {
+		rows[q] = []string{fmt.Sprintf(`\lstick{%s}`, escape(w))}
+	}
+
+	for _, col := range Columns(Elements(c, expand), c.Qubits) {
+		cells := make([]string, c.Qubits)
+		for q := range cells {
+			cells[q] = `\qw`
+		}
+
+		for _, e := range col {
+			target := slices.Min(e.Targets)
+			for _, q := range e.Controls {
+				cells[q] = fmt.Sprintf(`\ctrl{%d}`, target-q)
+			}
+
+			for _, q := range e.NegControls {
+				cells[q] = fmt.Sprintf(`\octrl{%d}`, target-q)
+			}
+
+			switch e.Kind {
+			case Targ:
+				cells[target] = `\targ{}`
+			case Swap:
+				other := slices.Max(e.Targets)
+				cells[target], cells[other] = fmt.Sprintf(`\swap{%d}`, other-target), `\targX{}`
+			case Measure:
+				cells[target] = `\meter{}`
+			case Reset:
+				cells[target] = `\gate{\ket{0}}`
+			case Barrier:
+				cells[target] = `\qw \slice{}`
+			default:
+				label := tex(e)
+				lo, hi := target, slices.Max(e.Targets)
+				if hi-lo+1 == len(e.Targets) && len(e.Targets) > 1 {
+					// the box covers the contiguous targets
+					cells[lo] = fmt.Sprintf(`\gate[%d]{%s}`, len(e.Targets), label)
+					for q := lo + 1; q <= hi; q++ {
+						cells[q] = ""
+					}
+
+					continue
+				}
+
+				for _, q := range e.Targets {
+					cells[q] = fmt.Sprintf(`\gate{%s}`, label)
+				}
+
+				if lo < hi {
+					cells[lo] += fmt.Sprintf(`\vqw{%d}`, hi-lo)
+				}
+			}
+		}
+
+		for q := range rows {
+			rows[q] = append(rows[q], cells[q])
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`\begin{quantikz}` + "\n")
+	for q, row := range rows {
+		sb.WriteString(strings.Join(append(row, `\qw`), " & "))
+		if q+1 < len(rows) {
+			sb.WriteString(` \\`)
+		}
+
+		sb.WriteByte('\n')
+	}
+
+	sb.WriteString(`\end{quantikz}` + "\n")
+	return sb.String()
+}
+
+// tex returns the label in math mode, e.g. \mathrm{rz}(\pi/2)^{\dagger}.
+func tex(e Element) string {
+	label := fmt.Sprintf(`\mathrm{%s}`, escape(e.Name))
+	if len(e.Params) > 0 {
+		params := make([]string, len(e.Params))
+		for i, p := range e.Params {
+			params[i] = strings.ReplaceAll(Angle(p), "pi", `\pi `)
+			params[i] = strings.TrimSpace(strings.ReplaceAll(params[i], `\pi /`, `\pi/`))
+		}
+
+		label += "(" + strings.Join(params, ", ") + ")"
+	}
+
+	switch e.Power {
+	case 0, 1:
+	case -1:
+		label += `^{\dagger}`
+	default:
+		label += fmt.Sprintf("^{%s}", strconv.FormatFloat(e.Power, 'g', 4, 64))
+	}
+
+	if e.Condition != "" {
+		label += fmt.Sprintf(`\ \text{if %s}`, escape(e.Condition))
+	}
+
+	return label
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\textbackslash{}`, "_", `\_`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "{", `\{`, "}", `\}`).Replace(s)
+}
@@ -0,0 +1,99 @@
+package draw
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+const (
+	rowHeight = 40
+	boxHeight = 28
+	charWidth = 9
+	margin    = 10
+)
+
+// SVG returns the circuit diagram as an SVG image.
+func SVG(c *circuit.Circuit, expand bool) string {
+	wires := Wires(c)
+	left := 0
+	for _, w := range wires {
+		left = max(left, utf8.RuneCountInString(w))
+	}
+	left = left*charWidth + 2*margin
+
+	y := func(q int) int {
+		return margin + rowHeight/2 + q*rowHeight
+	}
+
+	var body strings.Builder
+	x := left
+	for _, col := range Columns(Elements(c, expand), c.Qubits) {
+		w := boxHeight
+		for _, e := range col {
+			if e.Kind == Box {
+				w = max(w, utf8.RuneCountInString(e.Label())*charWidth+margin)
+			}
+		}
+
+		cx := x + margin + w/2
+		for _, e := range col {
+			lo, hi := e.Span()
+			if e.Kind == Barrier {
+				fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="gray" stroke-dasharray="4"/>`+"\n", cx, y(lo)-rowHeight/2, cx, y(hi)+rowHeight/2)
+				continue
+			}
+
+			if lo < hi {
+				fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", cx, y(lo), cx, y(hi))
+			}
+
+			for _, q := range e.Controls {
+				fmt.Fprintf(&body, `<circle cx="%d" cy="%d" r="5" fill="black"/>`+"\n", cx, y(q))
+			}
+
+			for _, q := range e.NegControls {
+				fmt.Fprintf(&body, `<circle cx="%d" cy="%d" r="5" fill="white" stroke="black"/>`+"\n", cx, y(q))
+			}
+
+			for _, q := range e.Targets {
+				switch e.Kind {
+				case Targ:
+					fmt.Fprintf(&body, `<circle cx="%d" cy="%d" r="10" fill="white" stroke="black"/>`+"\n", cx, y(q))
+					fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", cx-10, y(q), cx+10, y(q))
+					fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", cx, y(q)-10, cx, y(q)+10)
+				case Swap:
+					fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", cx-6, y(q)-6, cx+6, y(q)+6)
+					fmt.Fprintf(&body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", cx-6, y(q)+6, cx+6, y(q)-6)
+				default:
+					bw := boxHeight
+					if e.Kind == Box {
+						bw = w
+					}
+
+					fmt.Fprintf(&body, `<rect x="%d" y="%d" width="%d" height="%d" fill="white" stroke="black"/>`+"\n", cx-bw/2, y(q)-boxHeight/2, bw, boxHeight)
+					fmt.Fprintf(&body, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n", cx, y(q), html.EscapeString(e.Label()))
+				}
+			}
+		}
+
+		x += w + 2*margin
+	}
+
+	width, height := x+margin, 2*margin+c.Qubits*rowHeight
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="14">`+"\n", width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	for q, w := range wires {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="central">%s</text>`+"\n", left-margin, y(q), html.EscapeString(w))
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", left, y(q), width-margin, y(q))
+	}
+
+	sb.WriteString(body.String())
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
@@ -33,6 +33,7 @@ func (a *apiFeature) start() {
 	h, err := handler.New(
 		0, // no limit
 		&store.MemoryStore{},
+		&store.MemoryJobStore{},
 	)
 	if err != nil {
 		log.Fatalf("new handler: %v", err)
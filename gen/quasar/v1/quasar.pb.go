@@ -22,28 +22,3164 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Backend is the simulation backend.
+// BACKEND_UNSPECIFIED is the state vector, or the stabilizer for Clifford circuits too large for the state vector.
+type Backend int32
+
+const (
+	Backend_BACKEND_UNSPECIFIED    Backend = 0
+	Backend_BACKEND_STATEVECTOR    Backend = 1
+	Backend_BACKEND_DENSITY_MATRIX Backend = 2
+	Backend_BACKEND_STABILIZER     Backend = 3
+	Backend_BACKEND_MPS            Backend = 4
+)
+
+// Enum value maps for Backend.
+var (
+	Backend_name = map[int32]string{
+		0: "BACKEND_UNSPECIFIED",
+		1: "BACKEND_STATEVECTOR",
+		2: "BACKEND_DENSITY_MATRIX",
+		3: "BACKEND_STABILIZER",
+		4: "BACKEND_MPS",
+	}
+	Backend_value = map[string]int32{
+		"BACKEND_UNSPECIFIED":    0,
+		"BACKEND_STATEVECTOR":    1,
+		"BACKEND_DENSITY_MATRIX": 2,
+		"BACKEND_STABILIZER":     3,
+		"BACKEND_MPS":            4,
+	}
+)
+
+func (x Backend) Enum() *Backend {
+	p := new(Backend)
+	*p = x
+	return p
+}
+
+func (x Backend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Backend) Descriptor() protoreflect.EnumDescriptor {
+	return file_quasar_v1_quasar_proto_enumTypes[0].Descriptor()
+}
+
+func (Backend) Type() protoreflect.EnumType {
+	return &file_quasar_v1_quasar_proto_enumTypes[0]
+}
+
+func (x Backend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Backend.Descriptor instead.
+func (Backend) EnumDescriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{0}
+}
+
+// DrawFormat is the format of the circuit diagram.
+// DRAW_FORMAT_UNSPECIFIED is ASCII text.
+type DrawFormat int32
+
+const (
+	DrawFormat_DRAW_FORMAT_UNSPECIFIED DrawFormat = 0
+	DrawFormat_DRAW_FORMAT_ASCII       DrawFormat = 1
+	DrawFormat_DRAW_FORMAT_SVG         DrawFormat = 2
+	DrawFormat_DRAW_FORMAT_LATEX       DrawFormat = 3
+)
+
+// Enum value maps for DrawFormat.
+var (
+	DrawFormat_name = map[int32]string{
+		0: "DRAW_FORMAT_UNSPECIFIED",
+		1: "DRAW_FORMAT_ASCII",
+		2: "DRAW_FORMAT_SVG",
+		3: "DRAW_FORMAT_LATEX",
+	}
+	DrawFormat_value = map[string]int32{
+		"DRAW_FORMAT_UNSPECIFIED": 0,
+		"DRAW_FORMAT_ASCII":       1,
+		"DRAW_FORMAT_SVG":         2,
+		"DRAW_FORMAT_LATEX":       3,
+	}
+)
+
+func (x DrawFormat) Enum() *DrawFormat {
+	p := new(DrawFormat)
+	*p = x
+	return p
+}
+
+func (x DrawFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DrawFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_quasar_v1_quasar_proto_enumTypes[1].Descriptor()
+}
+
+func (DrawFormat) Type() protoreflect.EnumType {
+	return &file_quasar_v1_quasar_proto_enumTypes[1]
+}
+
+func (x DrawFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DrawFormat.Descriptor instead.
+func (DrawFormat) EnumDescriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1}
+}
+
+// JobStatus is the status of an asynchronous simulation.
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNSPECIFIED JobStatus = 0
+	JobStatus_JOB_STATUS_QUEUED      JobStatus = 1
+	JobStatus_JOB_STATUS_RUNNING     JobStatus = 2
+	JobStatus_JOB_STATUS_SUCCEEDED   JobStatus = 3
+	JobStatus_JOB_STATUS_FAILED      JobStatus = 4
+	JobStatus_JOB_STATUS_CANCELED    JobStatus = 5
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNSPECIFIED",
+		1: "JOB_STATUS_QUEUED",
+		2: "JOB_STATUS_RUNNING",
+		3: "JOB_STATUS_SUCCEEDED",
+		4: "JOB_STATUS_FAILED",
+		5: "JOB_STATUS_CANCELED",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNSPECIFIED": 0,
+		"JOB_STATUS_QUEUED":      1,
+		"JOB_STATUS_RUNNING":     2,
+		"JOB_STATUS_SUCCEEDED":   3,
+		"JOB_STATUS_FAILED":      4,
+		"JOB_STATUS_CANCELED":    5,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_quasar_v1_quasar_proto_enumTypes[2].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_quasar_v1_quasar_proto_enumTypes[2]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2}
+}
+
+type NoiseModel struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Depolarizing     float64                `protobuf:"fixed64,1,opt,name=depolarizing,proto3" json:"depolarizing,omitempty"`
+	AmplitudeDamping float64                `protobuf:"fixed64,2,opt,name=amplitude_damping,json=amplitudeDamping,proto3" json:"amplitude_damping,omitempty"`
+	PhaseDamping     float64                `protobuf:"fixed64,3,opt,name=phase_damping,json=phaseDamping,proto3" json:"phase_damping,omitempty"`
+	BitFlip          float64                `protobuf:"fixed64,4,opt,name=bit_flip,json=bitFlip,proto3" json:"bit_flip,omitempty"`
+	ReadoutError     float64                `protobuf:"fixed64,5,opt,name=readout_error,json=readoutError,proto3" json:"readout_error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NoiseModel) Reset() {
+	*x = NoiseModel{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoiseModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoiseModel) ProtoMessage() {}
+
+func (x *NoiseModel) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoiseModel.ProtoReflect.Descriptor instead.
+func (*NoiseModel) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NoiseModel) GetDepolarizing() float64 {
+	if x != nil {
+		return x.Depolarizing
+	}
+	return 0
+}
+
+func (x *NoiseModel) GetAmplitudeDamping() float64 {
+	if x != nil {
+		return x.AmplitudeDamping
+	}
+	return 0
+}
+
+func (x *NoiseModel) GetPhaseDamping() float64 {
+	if x != nil {
+		return x.PhaseDamping
+	}
+	return 0
+}
+
+func (x *NoiseModel) GetBitFlip() float64 {
+	if x != nil {
+		return x.BitFlip
+	}
+	return 0
+}
+
+func (x *NoiseModel) GetReadoutError() float64 {
+	if x != nil {
+		return x.ReadoutError
+	}
+	return 0
+}
+
 type SimulateRequest struct {
+	state       protoimpl.MessageState    `protogen:"open.v1"`
+	Code        string                    `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Shots       *int32                    `protobuf:"varint,2,opt,name=shots,proto3,oneof" json:"shots,omitempty"`
+	Seed        *uint64                   `protobuf:"varint,3,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	Inputs      map[string]float64        `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Sweep       []*SimulateRequest_Inputs `protobuf:"bytes,5,rep,name=sweep,proto3" json:"sweep,omitempty"`
+	Observables []string                  `protobuf:"bytes,6,rep,name=observables,proto3" json:"observables,omitempty"`
+	// precision is the number of decimal places of the results, 6 by default. 0 disables rounding.
+	Precision *int32 `protobuf:"varint,7,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	// epsilon omits the basis states whose rounded probability is less than it, 1e-6 by default.
+	Epsilon             *float64                  `protobuf:"fixed64,8,opt,name=epsilon,proto3,oneof" json:"epsilon,omitempty"`
+	Dense               bool                      `protobuf:"varint,9,opt,name=dense,proto3" json:"dense,omitempty"`
+	Packed              bool                      `protobuf:"varint,10,opt,name=packed,proto3" json:"packed,omitempty"`
+	Backend             Backend                   `protobuf:"varint,11,opt,name=backend,proto3,enum=quasar.v1.Backend" json:"backend,omitempty"`
+	Noise               *NoiseModel               `protobuf:"bytes,12,opt,name=noise,proto3" json:"noise,omitempty"`
+	MaxBondDimension    *int32                    `protobuf:"varint,13,opt,name=max_bond_dimension,json=maxBondDimension,proto3,oneof" json:"max_bond_dimension,omitempty"`
+	TruncationThreshold *float64                  `protobuf:"fixed64,14,opt,name=truncation_threshold,json=truncationThreshold,proto3,oneof" json:"truncation_threshold,omitempty"`
+	Analysis            *SimulateRequest_Analysis `protobuf:"bytes,15,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	Includes            map[string]string         `protobuf:"bytes,16,rep,name=includes,proto3" json:"includes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Imports             []string                  `protobuf:"bytes,17,rep,name=imports,proto3" json:"imports,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SimulateRequest) Reset() {
+	*x = SimulateRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateRequest) ProtoMessage() {}
+
+func (x *SimulateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateRequest.ProtoReflect.Descriptor instead.
+func (*SimulateRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SimulateRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SimulateRequest) GetShots() int32 {
+	if x != nil && x.Shots != nil {
+		return *x.Shots
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetSeed() uint64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetInputs() map[string]float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetSweep() []*SimulateRequest_Inputs {
+	if x != nil {
+		return x.Sweep
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetObservables() []string {
+	if x != nil {
+		return x.Observables
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetPrecision() int32 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetEpsilon() float64 {
+	if x != nil && x.Epsilon != nil {
+		return *x.Epsilon
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetDense() bool {
+	if x != nil {
+		return x.Dense
+	}
+	return false
+}
+
+func (x *SimulateRequest) GetPacked() bool {
+	if x != nil {
+		return x.Packed
+	}
+	return false
+}
+
+func (x *SimulateRequest) GetBackend() Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return Backend_BACKEND_UNSPECIFIED
+}
+
+func (x *SimulateRequest) GetNoise() *NoiseModel {
+	if x != nil {
+		return x.Noise
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetMaxBondDimension() int32 {
+	if x != nil && x.MaxBondDimension != nil {
+		return *x.MaxBondDimension
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetTruncationThreshold() float64 {
+	if x != nil && x.TruncationThreshold != nil {
+		return *x.TruncationThreshold
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetAnalysis() *SimulateRequest_Analysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetIncludes() map[string]string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+type SimulateResponse struct {
+	state           protoimpl.MessageState                 `protogen:"open.v1"`
+	States          []*SimulateResponse_State              `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	Counts          map[string]int32                       `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Classical       map[string]*SimulateResponse_Classical `protobuf:"bytes,3,rep,name=classical,proto3" json:"classical,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Sweep           []*SimulateResponse_Result             `protobuf:"bytes,4,rep,name=sweep,proto3" json:"sweep,omitempty"`
+	Expectations    []float64                              `protobuf:"fixed64,5,rep,packed,name=expectations,proto3" json:"expectations,omitempty"`
+	Packed          []byte                                 `protobuf:"bytes,6,opt,name=packed,proto3" json:"packed,omitempty"`
+	Diagonal        []float64                              `protobuf:"fixed64,7,rep,packed,name=diagonal,proto3" json:"diagonal,omitempty"`
+	DensityMatrix   []byte                                 `protobuf:"bytes,8,opt,name=density_matrix,json=densityMatrix,proto3" json:"density_matrix,omitempty"`
+	Stabilizers     []string                               `protobuf:"bytes,9,rep,name=stabilizers,proto3" json:"stabilizers,omitempty"`
+	TruncationError float64                                `protobuf:"fixed64,10,opt,name=truncation_error,json=truncationError,proto3" json:"truncation_error,omitempty"`
+	BondDimension   int32                                  `protobuf:"varint,11,opt,name=bond_dimension,json=bondDimension,proto3" json:"bond_dimension,omitempty"`
+	Analysis        *SimulateResponse_Analysis             `protobuf:"bytes,12,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulateResponse) Reset() {
+	*x = SimulateResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateResponse) ProtoMessage() {}
+
+func (x *SimulateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateResponse.ProtoReflect.Descriptor instead.
+func (*SimulateResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SimulateResponse) GetStates() []*SimulateResponse_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetClassical() map[string]*SimulateResponse_Classical {
+	if x != nil {
+		return x.Classical
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetSweep() []*SimulateResponse_Result {
+	if x != nil {
+		return x.Sweep
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetExpectations() []float64 {
+	if x != nil {
+		return x.Expectations
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetPacked() []byte {
+	if x != nil {
+		return x.Packed
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetDiagonal() []float64 {
+	if x != nil {
+		return x.Diagonal
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetDensityMatrix() []byte {
+	if x != nil {
+		return x.DensityMatrix
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetStabilizers() []string {
+	if x != nil {
+		return x.Stabilizers
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetTruncationError() float64 {
+	if x != nil {
+		return x.TruncationError
+	}
+	return 0
+}
+
+func (x *SimulateResponse) GetBondDimension() int32 {
+	if x != nil {
+		return x.BondDimension
+	}
+	return 0
+}
+
+func (x *SimulateResponse) GetAnalysis() *SimulateResponse_Analysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+// SimulateStreamRequest uses the code, inputs, seed, precision and epsilon of the request on the state vector.
+// The other backends, noise, shots, sweep, observables and analysis are not supported.
+type SimulateStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *SimulateRequest       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	MaxSnapshots  *int32                 `protobuf:"varint,2,opt,name=max_snapshots,json=maxSnapshots,proto3,oneof" json:"max_snapshots,omitempty"`
+	Lines         []int32                `protobuf:"varint,3,rep,packed,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateStreamRequest) Reset() {
+	*x = SimulateStreamRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateStreamRequest) ProtoMessage() {}
+
+func (x *SimulateStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateStreamRequest.ProtoReflect.Descriptor instead.
+func (*SimulateStreamRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SimulateStreamRequest) GetRequest() *SimulateRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SimulateStreamRequest) GetMaxSnapshots() int32 {
+	if x != nil && x.MaxSnapshots != nil {
+		return *x.MaxSnapshots
+	}
+	return 0
+}
+
+func (x *SimulateStreamRequest) GetLines() []int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// SimulateStreamResponse is the state after the statement at the source line and column.
+// gate, params and qubits are of the first operation of the statement, e.g. "h" or "measure", and empty if there is none.
+type SimulateStreamResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Step          int32                     `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	Line          int32                     `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                     `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Gate          string                    `protobuf:"bytes,4,opt,name=gate,proto3" json:"gate,omitempty"`
+	Params        []float64                 `protobuf:"fixed64,5,rep,packed,name=params,proto3" json:"params,omitempty"`
+	Qubits        []int32                   `protobuf:"varint,6,rep,packed,name=qubits,proto3" json:"qubits,omitempty"`
+	States        []*SimulateResponse_State `protobuf:"bytes,7,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateStreamResponse) Reset() {
+	*x = SimulateStreamResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateStreamResponse) ProtoMessage() {}
+
+func (x *SimulateStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateStreamResponse.ProtoReflect.Descriptor instead.
+func (*SimulateStreamResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SimulateStreamResponse) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *SimulateStreamResponse) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SimulateStreamResponse) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *SimulateStreamResponse) GetGate() string {
+	if x != nil {
+		return x.Gate
+	}
+	return ""
+}
+
+func (x *SimulateStreamResponse) GetParams() []float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SimulateStreamResponse) GetQubits() []int32 {
+	if x != nil {
+		return x.Qubits
+	}
+	return nil
+}
+
+func (x *SimulateStreamResponse) GetStates() []*SimulateResponse_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// StartSessionRequest starts a debug session of the code, or of the shared snippet if code is empty.
+type StartSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	SnippetId     string                 `protobuf:"bytes,2,opt,name=snippet_id,json=snippetId,proto3" json:"snippet_id,omitempty"`
+	Inputs        map[string]float64     `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Seed          *uint64                `protobuf:"varint,4,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	Includes      map[string]string      `protobuf:"bytes,5,rep,name=includes,proto3" json:"includes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Imports       []string               `protobuf:"bytes,6,rep,name=imports,proto3" json:"imports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSessionRequest) Reset() {
+	*x = StartSessionRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSessionRequest) ProtoMessage() {}
+
+func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartSessionRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StartSessionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetSnippetId() string {
+	if x != nil {
+		return x.SnippetId
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetInputs() map[string]float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *StartSessionRequest) GetSeed() uint64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *StartSessionRequest) GetIncludes() map[string]string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+func (x *StartSessionRequest) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+// Session is the state of a debug session.
+// line and column are the position of the next statement, or of the next operation of a gate body after Continue,
+// and are unset when done.
+type Session struct {
+	state         protoimpl.MessageState                 `protogen:"open.v1"`
+	Id            string                                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Step          int32                                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	Line          *int32                                 `protobuf:"varint,3,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	Column        *int32                                 `protobuf:"varint,4,opt,name=column,proto3,oneof" json:"column,omitempty"`
+	Done          bool                                   `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	States        []*SimulateResponse_State              `protobuf:"bytes,6,rep,name=states,proto3" json:"states,omitempty"`
+	Classical     map[string]*SimulateResponse_Classical `protobuf:"bytes,7,rep,name=classical,proto3" json:"classical,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpiresAt     *timestamppb.Timestamp                 `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *Session) GetLine() int32 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *Session) GetColumn() int32 {
+	if x != nil && x.Column != nil {
+		return *x.Column
+	}
+	return 0
+}
+
+func (x *Session) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *Session) GetStates() []*SimulateResponse_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *Session) GetClassical() map[string]*SimulateResponse_Classical {
+	if x != nil {
+		return x.Classical
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type StartSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSessionResponse) Reset() {
+	*x = StartSessionResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSessionResponse) ProtoMessage() {}
+
+func (x *StartSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSessionResponse.ProtoReflect.Descriptor instead.
+func (*StartSessionResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StartSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type StepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Count         *int32                 `protobuf:"varint,2,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StepRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StepRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+type StepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepResponse) Reset() {
+	*x = StepResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepResponse) ProtoMessage() {}
+
+func (x *StepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepResponse.ProtoReflect.Descriptor instead.
+func (*StepResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StepResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// ContinueRequest runs until the next statement or the next operation of a gate body on the line,
+// or to the end if line is unset.
+type ContinueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Line          *int32                 `protobuf:"varint,2,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContinueRequest) Reset() {
+	*x = ContinueRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContinueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinueRequest) ProtoMessage() {}
+
+func (x *ContinueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContinueRequest.ProtoReflect.Descriptor instead.
+func (*ContinueRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ContinueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ContinueRequest) GetLine() int32 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+type ContinueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContinueResponse) Reset() {
+	*x = ContinueResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContinueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinueResponse) ProtoMessage() {}
+
+func (x *ContinueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContinueResponse.ProtoReflect.Descriptor instead.
+func (*ContinueResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ContinueResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type InspectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Register      string                 `protobuf:"bytes,2,opt,name=register,proto3" json:"register,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectRequest) Reset() {
+	*x = InspectRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectRequest) ProtoMessage() {}
+
+func (x *InspectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectRequest.ProtoReflect.Descriptor instead.
+func (*InspectRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InspectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InspectRequest) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+// InspectResponse has the marginal probabilities of a quantum register, or the value of a classical register.
+type InspectResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Register      string                      `protobuf:"bytes,1,opt,name=register,proto3" json:"register,omitempty"`
+	States        []*SimulateResponse_State   `protobuf:"bytes,2,rep,name=states,proto3" json:"states,omitempty"`
+	Classical     *SimulateResponse_Classical `protobuf:"bytes,3,opt,name=classical,proto3" json:"classical,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectResponse) Reset() {
+	*x = InspectResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectResponse) ProtoMessage() {}
+
+func (x *InspectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectResponse.ProtoReflect.Descriptor instead.
+func (*InspectResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InspectResponse) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *InspectResponse) GetStates() []*SimulateResponse_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *InspectResponse) GetClassical() *SimulateResponse_Classical {
+	if x != nil {
+		return x.Classical
+	}
+	return nil
+}
+
+type EndRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndRequest) Reset() {
+	*x = EndRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndRequest) ProtoMessage() {}
+
+func (x *EndRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndRequest.ProtoReflect.Descriptor instead.
+func (*EndRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EndRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EndResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndResponse) Reset() {
+	*x = EndResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndResponse) ProtoMessage() {}
+
+func (x *EndResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndResponse.ProtoReflect.Descriptor instead.
+func (*EndResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{15}
+}
+
+// UnitaryRequest returns the unitary of the code, or of the user-defined gate applied to the params if gate is set.
+type UnitaryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Code   string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Gate   *string                `protobuf:"bytes,2,opt,name=gate,proto3,oneof" json:"gate,omitempty"`
+	Params []float64              `protobuf:"fixed64,3,rep,packed,name=params,proto3" json:"params,omitempty"`
+	Inputs map[string]float64     `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// precision is the same as the precision of SimulateRequest.
+	Precision     *int32            `protobuf:"varint,5,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	Includes      map[string]string `protobuf:"bytes,6,rep,name=includes,proto3" json:"includes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitaryRequest) Reset() {
+	*x = UnitaryRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitaryRequest) ProtoMessage() {}
+
+func (x *UnitaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitaryRequest.ProtoReflect.Descriptor instead.
+func (*UnitaryRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UnitaryRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UnitaryRequest) GetGate() string {
+	if x != nil && x.Gate != nil {
+		return *x.Gate
+	}
+	return ""
+}
+
+func (x *UnitaryRequest) GetParams() []float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *UnitaryRequest) GetInputs() map[string]float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *UnitaryRequest) GetPrecision() int32 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+func (x *UnitaryRequest) GetIncludes() map[string]string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+// UnitaryResponse has the 2^n x 2^n unitary matrix, row-major and packed.
+type UnitaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Qubits        int32                  `protobuf:"varint,1,opt,name=qubits,proto3" json:"qubits,omitempty"`
+	Matrix        []byte                 `protobuf:"bytes,2,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitaryResponse) Reset() {
+	*x = UnitaryResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitaryResponse) ProtoMessage() {}
+
+func (x *UnitaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitaryResponse.ProtoReflect.Descriptor instead.
+func (*UnitaryResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnitaryResponse) GetQubits() int32 {
+	if x != nil {
+		return x.Qubits
+	}
+	return 0
+}
+
+func (x *UnitaryResponse) GetMatrix() []byte {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+// Program is the code, or the shared snippet if code is empty.
+type Program struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Code      string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	SnippetId string                 `protobuf:"bytes,2,opt,name=snippet_id,json=snippetId,proto3" json:"snippet_id,omitempty"`
+	Inputs    map[string]float64     `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// includes are the contents of the files to include by name, e.g. {"mygates.inc": "gate ..."}.
+	Includes map[string]string `protobuf:"bytes,4,rep,name=includes,proto3" json:"includes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// imports are the IDs of the shared snippets whose gate and def definitions are included.
+	Imports       []string `protobuf:"bytes,5,rep,name=imports,proto3" json:"imports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Program) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Program) GetSnippetId() string {
+	if x != nil {
+		return x.SnippetId
+	}
+	return ""
+}
+
+func (x *Program) GetInputs() map[string]float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Program) GetIncludes() map[string]string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+func (x *Program) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+// EquivalentRequest compares the unitaries of the two programs up to global phase.
+type EquivalentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             *Program               `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             *Program               `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	Tolerance     *float64               `protobuf:"fixed64,3,opt,name=tolerance,proto3,oneof" json:"tolerance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EquivalentRequest) Reset() {
+	*x = EquivalentRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquivalentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquivalentRequest) ProtoMessage() {}
+
+func (x *EquivalentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquivalentRequest.ProtoReflect.Descriptor instead.
+func (*EquivalentRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EquivalentRequest) GetA() *Program {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *EquivalentRequest) GetB() *Program {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+func (x *EquivalentRequest) GetTolerance() float64 {
+	if x != nil && x.Tolerance != nil {
+		return *x.Tolerance
+	}
+	return 0
+}
+
+// EquivalentResponse has the process fidelity |Tr(A^dagger B)|^2 / d^2.
+// If they are not equivalent, counterexample is the input whose outputs have the lowest state fidelity, e.g. "01",
+// or the equal superposition of two basis states, e.g. "00+11", if the outputs of every basis state differ only in phase.
+// counterexample_fidelity is the state fidelity of its outputs.
+type EquivalentResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Equivalent             bool                   `protobuf:"varint,1,opt,name=equivalent,proto3" json:"equivalent,omitempty"`
+	Qubits                 int32                  `protobuf:"varint,2,opt,name=qubits,proto3" json:"qubits,omitempty"`
+	Fidelity               float64                `protobuf:"fixed64,3,opt,name=fidelity,proto3" json:"fidelity,omitempty"`
+	Counterexample         *string                `protobuf:"bytes,4,opt,name=counterexample,proto3,oneof" json:"counterexample,omitempty"`
+	CounterexampleFidelity *float64               `protobuf:"fixed64,5,opt,name=counterexample_fidelity,json=counterexampleFidelity,proto3,oneof" json:"counterexample_fidelity,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EquivalentResponse) Reset() {
+	*x = EquivalentResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquivalentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquivalentResponse) ProtoMessage() {}
+
+func (x *EquivalentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquivalentResponse.ProtoReflect.Descriptor instead.
+func (*EquivalentResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EquivalentResponse) GetEquivalent() bool {
+	if x != nil {
+		return x.Equivalent
+	}
+	return false
+}
+
+func (x *EquivalentResponse) GetQubits() int32 {
+	if x != nil {
+		return x.Qubits
+	}
+	return 0
+}
+
+func (x *EquivalentResponse) GetFidelity() float64 {
+	if x != nil {
+		return x.Fidelity
+	}
+	return 0
+}
+
+func (x *EquivalentResponse) GetCounterexample() string {
+	if x != nil && x.Counterexample != nil {
+		return *x.Counterexample
+	}
+	return ""
+}
+
+func (x *EquivalentResponse) GetCounterexampleFidelity() float64 {
+	if x != nil && x.CounterexampleFidelity != nil {
+		return *x.CounterexampleFidelity
+	}
+	return 0
+}
+
+// CompareRequest compares the final state of a with the final state of b, or with the target state vector.
+// The target is packed, and must have 2^n normalized amplitudes where n is the number of qubits of a.
+type CompareRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	A     *Program               `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	// Types that are valid to be assigned to Other:
+	//
+	//	*CompareRequest_B
+	//	*CompareRequest_Target
+	Other isCompareRequest_Other `protobuf_oneof:"other"`
+	Seed  *uint64                `protobuf:"varint,4,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	// precision and epsilon are the same as those of SimulateRequest.
+	// Equal rounded probabilities have a diff of +0.
+	Precision     *int32   `protobuf:"varint,5,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	Epsilon       *float64 `protobuf:"fixed64,6,opt,name=epsilon,proto3,oneof" json:"epsilon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompareRequest) GetA() *Program {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *CompareRequest) GetOther() isCompareRequest_Other {
+	if x != nil {
+		return x.Other
+	}
+	return nil
+}
+
+func (x *CompareRequest) GetB() *Program {
+	if x != nil {
+		if x, ok := x.Other.(*CompareRequest_B); ok {
+			return x.B
+		}
+	}
+	return nil
+}
+
+func (x *CompareRequest) GetTarget() []byte {
+	if x != nil {
+		if x, ok := x.Other.(*CompareRequest_Target); ok {
+			return x.Target
+		}
+	}
+	return nil
+}
+
+func (x *CompareRequest) GetSeed() uint64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *CompareRequest) GetPrecision() int32 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+func (x *CompareRequest) GetEpsilon() float64 {
+	if x != nil && x.Epsilon != nil {
+		return *x.Epsilon
+	}
+	return 0
+}
+
+type isCompareRequest_Other interface {
+	isCompareRequest_Other()
+}
+
+type CompareRequest_B struct {
+	B *Program `protobuf:"bytes,2,opt,name=b,proto3,oneof"`
+}
+
+type CompareRequest_Target struct {
+	Target []byte `protobuf:"bytes,3,opt,name=target,proto3,oneof"`
+}
+
+func (*CompareRequest_B) isCompareRequest_Other() {}
+
+func (*CompareRequest_Target) isCompareRequest_Other() {}
+
+// CompareResponse has the state fidelity |<a|b>|^2, the trace distance, and the probabilities of each basis state.
+type CompareResponse struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Qubits        int32                         `protobuf:"varint,1,opt,name=qubits,proto3" json:"qubits,omitempty"`
+	Fidelity      float64                       `protobuf:"fixed64,2,opt,name=fidelity,proto3" json:"fidelity,omitempty"`
+	TraceDistance float64                       `protobuf:"fixed64,3,opt,name=trace_distance,json=traceDistance,proto3" json:"trace_distance,omitempty"`
+	Differences   []*CompareResponse_Difference `protobuf:"bytes,4,rep,name=differences,proto3" json:"differences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CompareResponse) GetQubits() int32 {
+	if x != nil {
+		return x.Qubits
+	}
+	return 0
+}
+
+func (x *CompareResponse) GetFidelity() float64 {
+	if x != nil {
+		return x.Fidelity
+	}
+	return 0
+}
+
+func (x *CompareResponse) GetTraceDistance() float64 {
+	if x != nil {
+		return x.TraceDistance
+	}
+	return 0
+}
+
+func (x *CompareResponse) GetDifferences() []*CompareResponse_Difference {
+	if x != nil {
+		return x.Differences
+	}
+	return nil
+}
+
+// DrawRequest renders the circuit of the program.
+// User-defined gates are drawn as boxes unless expand is true.
+type DrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	Format        DrawFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=quasar.v1.DrawFormat" json:"format,omitempty"`
+	Expand        bool                   `protobuf:"varint,3,opt,name=expand,proto3" json:"expand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrawRequest) Reset() {
+	*x = DrawRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawRequest) ProtoMessage() {}
+
+func (x *DrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawRequest.ProtoReflect.Descriptor instead.
+func (*DrawRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DrawRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+func (x *DrawRequest) GetFormat() DrawFormat {
+	if x != nil {
+		return x.Format
+	}
+	return DrawFormat_DRAW_FORMAT_UNSPECIFIED
+}
+
+func (x *DrawRequest) GetExpand() bool {
+	if x != nil {
+		return x.Expand
+	}
+	return false
+}
+
+// DrawResponse has the diagram as ASCII text, an SVG image, or a LaTeX quantikz environment.
+type DrawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Diagram       string                 `protobuf:"bytes,1,opt,name=diagram,proto3" json:"diagram,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrawResponse) Reset() {
+	*x = DrawResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawResponse) ProtoMessage() {}
+
+func (x *DrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawResponse.ProtoReflect.Descriptor instead.
+func (*DrawResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DrawResponse) GetDiagram() string {
+	if x != nil {
+		return x.Diagram
+	}
+	return ""
+}
+
+// AnalyzeRequest reports the metrics of the program without simulating it.
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AnalyzeRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+// AnalyzeResponse has the metrics after user-defined gates are expanded.
+// Gates counts the operations by name, e.g. "h", "cx" or "measure", with a "c" for each control.
+// T-count counts the uncontrolled t and tdg gates.
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Qubits        int32                  `protobuf:"varint,1,opt,name=qubits,proto3" json:"qubits,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	Gates         map[string]int32       `protobuf:"bytes,3,rep,name=gates,proto3" json:"gates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	TwoQubitGates int32                  `protobuf:"varint,4,opt,name=two_qubit_gates,json=twoQubitGates,proto3" json:"two_qubit_gates,omitempty"`
+	TCount        int32                  `protobuf:"varint,5,opt,name=t_count,json=tCount,proto3" json:"t_count,omitempty"`
+	Clifford      bool                   `protobuf:"varint,6,opt,name=clifford,proto3" json:"clifford,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AnalyzeResponse) GetQubits() int32 {
+	if x != nil {
+		return x.Qubits
+	}
+	return 0
+}
+
+func (x *AnalyzeResponse) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *AnalyzeResponse) GetGates() map[string]int32 {
+	if x != nil {
+		return x.Gates
+	}
+	return nil
+}
+
+func (x *AnalyzeResponse) GetTwoQubitGates() int32 {
+	if x != nil {
+		return x.TwoQubitGates
+	}
+	return 0
+}
+
+func (x *AnalyzeResponse) GetTCount() int32 {
+	if x != nil {
+		return x.TCount
+	}
+	return 0
+}
+
+func (x *AnalyzeResponse) GetClifford() bool {
+	if x != nil {
+		return x.Clifford
+	}
+	return false
+}
+
+// OptimizeRequest rewrites the program into an equivalent program with fewer gates.
+type OptimizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizeRequest) Reset() {
+	*x = OptimizeRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeRequest) ProtoMessage() {}
+
+func (x *OptimizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeRequest.ProtoReflect.Descriptor instead.
+func (*OptimizeRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OptimizeRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+// OptimizeResponse has the optimized OpenQASM 3 program and the metrics before and after the optimization.
+// The optimized program has the same unitary as the original up to global phase.
+type OptimizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Before        *AnalyzeResponse       `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         *AnalyzeResponse       `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizeResponse) Reset() {
+	*x = OptimizeResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeResponse) ProtoMessage() {}
+
+func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeResponse.ProtoReflect.Descriptor instead.
+func (*OptimizeResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OptimizeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetBefore() *AnalyzeResponse {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetAfter() *AnalyzeResponse {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// TranspileRequest decomposes the program into the basis gates, e.g. ["rz", "sx", "x", "cx"] or ["u3", "cz"].
+// The supported gates are U, u3, rz, ry, rx, sx, x, cx and cz.
+// The program is routed onto the coupling map if it is not empty.
+type TranspileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	Basis         []string               `protobuf:"bytes,2,rep,name=basis,proto3" json:"basis,omitempty"`
+	CouplingMap   []*Edge                `protobuf:"bytes,3,rep,name=coupling_map,json=couplingMap,proto3" json:"coupling_map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranspileRequest) Reset() {
+	*x = TranspileRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranspileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranspileRequest) ProtoMessage() {}
+
+func (x *TranspileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranspileRequest.ProtoReflect.Descriptor instead.
+func (*TranspileRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TranspileRequest) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+func (x *TranspileRequest) GetBasis() []string {
+	if x != nil {
+		return x.Basis
+	}
+	return nil
+}
+
+func (x *TranspileRequest) GetCouplingMap() []*Edge {
+	if x != nil {
+		return x.CouplingMap
+	}
+	return nil
+}
+
+// TranspileResponse has the OpenQASM 3 program of the basis gates with their definitions.
+// The program has the same unitary as the original up to global phase.
+type TranspileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Metrics       *AnalyzeResponse       `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Routing       *Routing               `protobuf:"bytes,3,opt,name=routing,proto3,oneof" json:"routing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranspileResponse) Reset() {
+	*x = TranspileResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranspileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranspileResponse) ProtoMessage() {}
+
+func (x *TranspileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranspileResponse.ProtoReflect.Descriptor instead.
+func (*TranspileResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TranspileResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TranspileResponse) GetMetrics() *AnalyzeResponse {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *TranspileResponse) GetRouting() *Routing {
+	if x != nil {
+		return x.Routing
+	}
+	return nil
+}
+
+// Edge connects two physical qubits of a device. The edges are undirected.
+// A coupling map has up to 10000 edges between the physical qubits 0 to 1023.
+type Edge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             int32                  `protobuf:"varint,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             int32                  `protobuf:"varint,2,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Edge) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *Edge) GetB() int32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+// Routing maps the logical qubits onto the physical qubits of the coupling map.
+// Layout is the physical qubit of each logical qubit at the start of the program,
+// and final_layout is the physical qubit of each logical qubit at the end, after the swaps.
+type Routing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Layout        []int32                `protobuf:"varint,1,rep,packed,name=layout,proto3" json:"layout,omitempty"`
+	FinalLayout   []int32                `protobuf:"varint,2,rep,packed,name=final_layout,json=finalLayout,proto3" json:"final_layout,omitempty"`
+	Swaps         int32                  `protobuf:"varint,3,opt,name=swaps,proto3" json:"swaps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Routing) Reset() {
+	*x = Routing{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Routing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Routing) ProtoMessage() {}
+
+func (x *Routing) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Routing.ProtoReflect.Descriptor instead.
+func (*Routing) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Routing) GetLayout() []int32 {
+	if x != nil {
+		return x.Layout
+	}
+	return nil
+}
+
+func (x *Routing) GetFinalLayout() []int32 {
+	if x != nil {
+		return x.FinalLayout
+	}
+	return nil
+}
+
+func (x *Routing) GetSwaps() int32 {
+	if x != nil {
+		return x.Swaps
+	}
+	return 0
+}
+
+type ShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareRequest) ProtoMessage() {}
+
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ShareRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareResponse) Reset() {
+	*x = ShareResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareResponse) ProtoMessage() {}
+
+func (x *ShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareResponse.ProtoReflect.Descriptor instead.
+func (*ShareResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ShareResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type EditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditRequest) Reset() {
+	*x = EditRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditRequest) ProtoMessage() {}
+
+func (x *EditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditRequest.ProtoReflect.Descriptor instead.
+func (*EditRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EditRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditResponse) Reset() {
+	*x = EditResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditResponse) ProtoMessage() {}
+
+func (x *EditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditResponse.ProtoReflect.Descriptor instead.
+func (*EditResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *EditResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EditResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *EditResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        JobStatus              `protobuf:"varint,2,opt,name=status,proto3,enum=quasar.v1.JobStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3,oneof" json:"finished_at,omitempty"`
+	Result        *SimulateResponse      `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	Error         *string                `protobuf:"bytes,7,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Job) GetResult() *SimulateResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Job) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type SubmitRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Request *SimulateRequest       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// owner is a secret key of the caller, e.g. a random token kept by the client.
+	// ListJobs returns only the jobs submitted with the same owner, and the jobs without an owner are not listed.
+	Owner         string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRequest) Reset() {
+	*x = SubmitRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRequest) ProtoMessage() {}
+
+func (x *SubmitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRequest.ProtoReflect.Descriptor instead.
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubmitRequest) GetRequest() *SimulateRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SubmitRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type SubmitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitResponse) ProtoMessage() {}
+
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitResponse.ProtoReflect.Descriptor instead.
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SubmitResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobResponse) Reset() {
+	*x = CancelJobResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobResponse) ProtoMessage() {}
+
+func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelJobResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CancelJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit *int32                 `protobuf:"varint,1,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// owner is the key the jobs were submitted with.
+	Owner         string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListJobsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// ValidateRequest also compiles the code and routes it onto the coupling map if it is not empty.
+type ValidateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CouplingMap   []*Edge                `protobuf:"bytes,2,rep,name=coupling_map,json=couplingMap,proto3" json:"coupling_map,omitempty"`
+	Includes      map[string]string      `protobuf:"bytes,3,rep,name=includes,proto3" json:"includes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Imports       []string               `protobuf:"bytes,4,rep,name=imports,proto3" json:"imports,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulateRequest) Reset() {
-	*x = SimulateRequest{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[0]
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulateRequest) String() string {
+func (x *ValidateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulateRequest) ProtoMessage() {}
+func (*ValidateRequest) ProtoMessage() {}
 
-func (x *SimulateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[0]
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -54,40 +3190,68 @@ func (x *SimulateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulateRequest.ProtoReflect.Descriptor instead.
-func (*SimulateRequest) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *SimulateRequest) GetCode() string {
+func (x *ValidateRequest) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-type SimulateResponse struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	States        []*SimulateResponse_State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+func (x *ValidateRequest) GetCouplingMap() []*Edge {
+	if x != nil {
+		return x.CouplingMap
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetIncludes() map[string]string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Valid   bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Line    *int32                 `protobuf:"varint,2,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	Column  *int32                 `protobuf:"varint,3,opt,name=column,proto3,oneof" json:"column,omitempty"`
+	Message *string                `protobuf:"bytes,4,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	Routing *Routing               `protobuf:"bytes,5,opt,name=routing,proto3,oneof" json:"routing,omitempty"`
+	// version is the OpenQASM version in the header, e.g. "2.0" or "3.0", or empty if there is no header.
+	// OpenQASM 2 programs are translated to OpenQASM 3 with the gates of qelib1.inc.
+	Version       string `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulateResponse) Reset() {
-	*x = SimulateResponse{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[1]
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulateResponse) String() string {
+func (x *ValidateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulateResponse) ProtoMessage() {}
+func (*ValidateResponse) ProtoMessage() {}
 
-func (x *SimulateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[1]
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -98,40 +3262,75 @@ func (x *SimulateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulateResponse.ProtoReflect.Descriptor instead.
-func (*SimulateResponse) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *SimulateResponse) GetStates() []*SimulateResponse_State {
+func (x *ValidateResponse) GetValid() bool {
 	if x != nil {
-		return x.States
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetLine() int32 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *ValidateResponse) GetColumn() int32 {
+	if x != nil && x.Column != nil {
+		return *x.Column
+	}
+	return 0
+}
+
+func (x *ValidateResponse) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetRouting() *Routing {
+	if x != nil {
+		return x.Routing
 	}
 	return nil
 }
 
-type ShareRequest struct {
+func (x *ValidateResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SimulateRequest_Inputs struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Values        map[string]float64     `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShareRequest) Reset() {
-	*x = ShareRequest{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[2]
+func (x *SimulateRequest_Inputs) Reset() {
+	*x = SimulateRequest_Inputs{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShareRequest) String() string {
+func (x *SimulateRequest_Inputs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShareRequest) ProtoMessage() {}
+func (*SimulateRequest_Inputs) ProtoMessage() {}
 
-func (x *ShareRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[2]
+func (x *SimulateRequest_Inputs) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,41 +3341,43 @@ func (x *ShareRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
-func (*ShareRequest) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SimulateRequest_Inputs.ProtoReflect.Descriptor instead.
+func (*SimulateRequest_Inputs) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1, 0}
 }
 
-func (x *ShareRequest) GetCode() string {
+func (x *SimulateRequest_Inputs) GetValues() map[string]float64 {
 	if x != nil {
-		return x.Code
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
-type ShareResponse struct {
+// Analysis requests the Bloch vector of each qubit,
+// and the reduced density matrix and the entanglement entropy of the subsystem if it is not empty.
+// Qubits are numbered in the order of declaration.
+type SimulateRequest_Analysis struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Subsystem     []int32                `protobuf:"varint,1,rep,packed,name=subsystem,proto3" json:"subsystem,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShareResponse) Reset() {
-	*x = ShareResponse{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[3]
+func (x *SimulateRequest_Analysis) Reset() {
+	*x = SimulateRequest_Analysis{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShareResponse) String() string {
+func (x *SimulateRequest_Analysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShareResponse) ProtoMessage() {}
+func (*SimulateRequest_Analysis) ProtoMessage() {}
 
-func (x *ShareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[3]
+func (x *SimulateRequest_Analysis) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,47 +3388,152 @@ func (x *ShareResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShareResponse.ProtoReflect.Descriptor instead.
-func (*ShareResponse) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use SimulateRequest_Analysis.ProtoReflect.Descriptor instead.
+func (*SimulateRequest_Analysis) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1, 1}
 }
 
-func (x *ShareResponse) GetId() string {
+func (x *SimulateRequest_Analysis) GetSubsystem() []int32 {
 	if x != nil {
-		return x.Id
+		return x.Subsystem
 	}
-	return ""
+	return nil
 }
 
-func (x *ShareResponse) GetCreatedAt() *timestamppb.Timestamp {
+type SimulateResponse_Amplitude struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Real          float64                `protobuf:"fixed64,1,opt,name=real,proto3" json:"real,omitempty"`
+	Imag          float64                `protobuf:"fixed64,2,opt,name=imag,proto3" json:"imag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateResponse_Amplitude) Reset() {
+	*x = SimulateResponse_Amplitude{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateResponse_Amplitude) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateResponse_Amplitude) ProtoMessage() {}
+
+func (x *SimulateResponse_Amplitude) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[53]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateResponse_Amplitude.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Amplitude) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *SimulateResponse_Amplitude) GetReal() float64 {
+	if x != nil {
+		return x.Real
+	}
+	return 0
+}
+
+func (x *SimulateResponse_Amplitude) GetImag() float64 {
+	if x != nil {
+		return x.Imag
+	}
+	return 0
+}
+
+type SimulateResponse_State struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Probability   float64                     `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
+	Amplitude     *SimulateResponse_Amplitude `protobuf:"bytes,2,opt,name=amplitude,proto3" json:"amplitude,omitempty"`
+	BinaryString  []string                    `protobuf:"bytes,3,rep,name=binary_string,json=binaryString,proto3" json:"binary_string,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateResponse_State) Reset() {
+	*x = SimulateResponse_State{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateResponse_State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateResponse_State) ProtoMessage() {}
+
+func (x *SimulateResponse_State) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateResponse_State.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_State) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *SimulateResponse_State) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *SimulateResponse_State) GetAmplitude() *SimulateResponse_Amplitude {
+	if x != nil {
+		return x.Amplitude
 	}
 	return nil
 }
 
-type EditRequest struct {
+func (x *SimulateResponse_State) GetBinaryString() []string {
+	if x != nil {
+		return x.BinaryString
+	}
+	return nil
+}
+
+type SimulateResponse_Bits struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Values        []int32                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditRequest) Reset() {
-	*x = EditRequest{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[4]
+func (x *SimulateResponse_Bits) Reset() {
+	*x = SimulateResponse_Bits{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditRequest) String() string {
+func (x *SimulateResponse_Bits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditRequest) ProtoMessage() {}
+func (*SimulateResponse_Bits) ProtoMessage() {}
 
-func (x *EditRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[4]
+func (x *SimulateResponse_Bits) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,42 +3544,47 @@ func (x *EditRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditRequest.ProtoReflect.Descriptor instead.
-func (*EditRequest) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SimulateResponse_Bits.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Bits) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 2}
 }
 
-func (x *EditRequest) GetId() string {
+func (x *SimulateResponse_Bits) GetValues() []int32 {
 	if x != nil {
-		return x.Id
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
-type EditResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+type SimulateResponse_Classical struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*SimulateResponse_Classical_Bits
+	//	*SimulateResponse_Classical_Int
+	//	*SimulateResponse_Classical_Float
+	//	*SimulateResponse_Classical_Angle
+	//	*SimulateResponse_Classical_Bool
+	Value         isSimulateResponse_Classical_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditResponse) Reset() {
-	*x = EditResponse{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[5]
+func (x *SimulateResponse_Classical) Reset() {
+	*x = SimulateResponse_Classical{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditResponse) String() string {
+func (x *SimulateResponse_Classical) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditResponse) ProtoMessage() {}
+func (*SimulateResponse_Classical) ProtoMessage() {}
 
-func (x *EditResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[5]
+func (x *SimulateResponse_Classical) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,54 +3595,123 @@ func (x *EditResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditResponse.ProtoReflect.Descriptor instead.
-func (*EditResponse) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SimulateResponse_Classical.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Classical) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 3}
 }
 
-func (x *EditResponse) GetId() string {
+func (x *SimulateResponse_Classical) GetValue() isSimulateResponse_Classical_Value {
 	if x != nil {
-		return x.Id
+		return x.Value
 	}
-	return ""
+	return nil
 }
 
-func (x *EditResponse) GetCode() string {
+func (x *SimulateResponse_Classical) GetBits() *SimulateResponse_Bits {
 	if x != nil {
-		return x.Code
+		if x, ok := x.Value.(*SimulateResponse_Classical_Bits); ok {
+			return x.Bits
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *EditResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SimulateResponse_Classical) GetInt() int64 {
 	if x != nil {
-		return x.CreatedAt
+		if x, ok := x.Value.(*SimulateResponse_Classical_Int); ok {
+			return x.Int
+		}
 	}
-	return nil
+	return 0
 }
 
-type ValidateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SimulateResponse_Classical) GetFloat() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*SimulateResponse_Classical_Float); ok {
+			return x.Float
+		}
+	}
+	return 0
 }
 
-func (x *ValidateRequest) Reset() {
-	*x = ValidateRequest{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[6]
+func (x *SimulateResponse_Classical) GetAngle() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*SimulateResponse_Classical_Angle); ok {
+			return x.Angle
+		}
+	}
+	return 0
+}
+
+func (x *SimulateResponse_Classical) GetBool() bool {
+	if x != nil {
+		if x, ok := x.Value.(*SimulateResponse_Classical_Bool); ok {
+			return x.Bool
+		}
+	}
+	return false
+}
+
+type isSimulateResponse_Classical_Value interface {
+	isSimulateResponse_Classical_Value()
+}
+
+type SimulateResponse_Classical_Bits struct {
+	Bits *SimulateResponse_Bits `protobuf:"bytes,1,opt,name=bits,proto3,oneof"`
+}
+
+type SimulateResponse_Classical_Int struct {
+	Int int64 `protobuf:"varint,2,opt,name=int,proto3,oneof"`
+}
+
+type SimulateResponse_Classical_Float struct {
+	Float float64 `protobuf:"fixed64,3,opt,name=float,proto3,oneof"`
+}
+
+type SimulateResponse_Classical_Angle struct {
+	Angle float64 `protobuf:"fixed64,4,opt,name=angle,proto3,oneof"`
+}
+
+type SimulateResponse_Classical_Bool struct {
+	Bool bool `protobuf:"varint,5,opt,name=bool,proto3,oneof"`
+}
+
+func (*SimulateResponse_Classical_Bits) isSimulateResponse_Classical_Value() {}
+
+func (*SimulateResponse_Classical_Int) isSimulateResponse_Classical_Value() {}
+
+func (*SimulateResponse_Classical_Float) isSimulateResponse_Classical_Value() {}
+
+func (*SimulateResponse_Classical_Angle) isSimulateResponse_Classical_Value() {}
+
+func (*SimulateResponse_Classical_Bool) isSimulateResponse_Classical_Value() {}
+
+// Analysis has the Bloch vector of each qubit from its single-qubit reduced density matrix,
+// and the packed reduced density matrix and the von Neumann entropy in bits of the subsystem.
+type SimulateResponse_Analysis struct {
+	state                protoimpl.MessageState             `protogen:"open.v1"`
+	Bloch                []*SimulateResponse_Analysis_Bloch `protobuf:"bytes,1,rep,name=bloch,proto3" json:"bloch,omitempty"`
+	ReducedDensityMatrix []byte                             `protobuf:"bytes,2,opt,name=reduced_density_matrix,json=reducedDensityMatrix,proto3" json:"reduced_density_matrix,omitempty"`
+	Entropy              *float64                           `protobuf:"fixed64,3,opt,name=entropy,proto3,oneof" json:"entropy,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SimulateResponse_Analysis) Reset() {
+	*x = SimulateResponse_Analysis{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateRequest) String() string {
+func (x *SimulateResponse_Analysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateRequest) ProtoMessage() {}
+func (*SimulateResponse_Analysis) ProtoMessage() {}
 
-func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[6]
+func (x *SimulateResponse_Analysis) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,43 +3722,65 @@ func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
-func (*ValidateRequest) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SimulateResponse_Analysis.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Analysis) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 4}
 }
 
-func (x *ValidateRequest) GetCode() string {
+func (x *SimulateResponse_Analysis) GetBloch() []*SimulateResponse_Analysis_Bloch {
 	if x != nil {
-		return x.Code
+		return x.Bloch
 	}
-	return ""
+	return nil
 }
 
-type ValidateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	Line          *int32                 `protobuf:"varint,2,opt,name=line,proto3,oneof" json:"line,omitempty"`
-	Column        *int32                 `protobuf:"varint,3,opt,name=column,proto3,oneof" json:"column,omitempty"`
-	Message       *string                `protobuf:"bytes,4,opt,name=message,proto3,oneof" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SimulateResponse_Analysis) GetReducedDensityMatrix() []byte {
+	if x != nil {
+		return x.ReducedDensityMatrix
+	}
+	return nil
 }
 
-func (x *ValidateResponse) Reset() {
-	*x = ValidateResponse{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[7]
+func (x *SimulateResponse_Analysis) GetEntropy() float64 {
+	if x != nil && x.Entropy != nil {
+		return *x.Entropy
+	}
+	return 0
+}
+
+type SimulateResponse_Result struct {
+	state           protoimpl.MessageState                 `protogen:"open.v1"`
+	Inputs          map[string]float64                     `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	States          []*SimulateResponse_State              `protobuf:"bytes,2,rep,name=states,proto3" json:"states,omitempty"`
+	Counts          map[string]int32                       `protobuf:"bytes,3,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Classical       map[string]*SimulateResponse_Classical `protobuf:"bytes,4,rep,name=classical,proto3" json:"classical,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Expectations    []float64                              `protobuf:"fixed64,5,rep,packed,name=expectations,proto3" json:"expectations,omitempty"`
+	Packed          []byte                                 `protobuf:"bytes,6,opt,name=packed,proto3" json:"packed,omitempty"`
+	Diagonal        []float64                              `protobuf:"fixed64,7,rep,packed,name=diagonal,proto3" json:"diagonal,omitempty"`
+	DensityMatrix   []byte                                 `protobuf:"bytes,8,opt,name=density_matrix,json=densityMatrix,proto3" json:"density_matrix,omitempty"`
+	Stabilizers     []string                               `protobuf:"bytes,9,rep,name=stabilizers,proto3" json:"stabilizers,omitempty"`
+	TruncationError float64                                `protobuf:"fixed64,10,opt,name=truncation_error,json=truncationError,proto3" json:"truncation_error,omitempty"`
+	BondDimension   int32                                  `protobuf:"varint,11,opt,name=bond_dimension,json=bondDimension,proto3" json:"bond_dimension,omitempty"`
+	Analysis        *SimulateResponse_Analysis             `protobuf:"bytes,12,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulateResponse_Result) Reset() {
+	*x = SimulateResponse_Result{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateResponse) String() string {
+func (x *SimulateResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateResponse) ProtoMessage() {}
+func (*SimulateResponse_Result) ProtoMessage() {}
 
-func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[7]
+func (x *SimulateResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,62 +3791,119 @@ func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
-func (*ValidateResponse) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SimulateResponse_Result.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Result) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 5}
 }
 
-func (x *ValidateResponse) GetValid() bool {
+func (x *SimulateResponse_Result) GetInputs() map[string]float64 {
 	if x != nil {
-		return x.Valid
+		return x.Inputs
 	}
-	return false
+	return nil
 }
 
-func (x *ValidateResponse) GetLine() int32 {
-	if x != nil && x.Line != nil {
-		return *x.Line
+func (x *SimulateResponse_Result) GetStates() []*SimulateResponse_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetClassical() map[string]*SimulateResponse_Classical {
+	if x != nil {
+		return x.Classical
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetExpectations() []float64 {
+	if x != nil {
+		return x.Expectations
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetPacked() []byte {
+	if x != nil {
+		return x.Packed
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetDiagonal() []float64 {
+	if x != nil {
+		return x.Diagonal
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetDensityMatrix() []byte {
+	if x != nil {
+		return x.DensityMatrix
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetStabilizers() []string {
+	if x != nil {
+		return x.Stabilizers
+	}
+	return nil
+}
+
+func (x *SimulateResponse_Result) GetTruncationError() float64 {
+	if x != nil {
+		return x.TruncationError
 	}
 	return 0
 }
 
-func (x *ValidateResponse) GetColumn() int32 {
-	if x != nil && x.Column != nil {
-		return *x.Column
+func (x *SimulateResponse_Result) GetBondDimension() int32 {
+	if x != nil {
+		return x.BondDimension
 	}
 	return 0
 }
 
-func (x *ValidateResponse) GetMessage() string {
-	if x != nil && x.Message != nil {
-		return *x.Message
+func (x *SimulateResponse_Result) GetAnalysis() *SimulateResponse_Analysis {
+	if x != nil {
+		return x.Analysis
 	}
-	return ""
+	return nil
 }
 
-type SimulateResponse_Amplitude struct {
+type SimulateResponse_Analysis_Bloch struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Real          float64                `protobuf:"fixed64,1,opt,name=real,proto3" json:"real,omitempty"`
-	Imag          float64                `protobuf:"fixed64,2,opt,name=imag,proto3" json:"imag,omitempty"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float64                `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulateResponse_Amplitude) Reset() {
-	*x = SimulateResponse_Amplitude{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[8]
+func (x *SimulateResponse_Analysis_Bloch) Reset() {
+	*x = SimulateResponse_Analysis_Bloch{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulateResponse_Amplitude) String() string {
+func (x *SimulateResponse_Analysis_Bloch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulateResponse_Amplitude) ProtoMessage() {}
+func (*SimulateResponse_Analysis_Bloch) ProtoMessage() {}
 
-func (x *SimulateResponse_Amplitude) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[8]
+func (x *SimulateResponse_Analysis_Bloch) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,49 +3914,58 @@ func (x *SimulateResponse_Amplitude) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulateResponse_Amplitude.ProtoReflect.Descriptor instead.
-func (*SimulateResponse_Amplitude) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use SimulateResponse_Analysis_Bloch.ProtoReflect.Descriptor instead.
+func (*SimulateResponse_Analysis_Bloch) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{2, 4, 0}
 }
 
-func (x *SimulateResponse_Amplitude) GetReal() float64 {
+func (x *SimulateResponse_Analysis_Bloch) GetX() float64 {
 	if x != nil {
-		return x.Real
+		return x.X
 	}
 	return 0
 }
 
-func (x *SimulateResponse_Amplitude) GetImag() float64 {
+func (x *SimulateResponse_Analysis_Bloch) GetY() float64 {
 	if x != nil {
-		return x.Imag
+		return x.Y
 	}
 	return 0
 }
 
-type SimulateResponse_State struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Probability   float64                     `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
-	Amplitude     *SimulateResponse_Amplitude `protobuf:"bytes,2,opt,name=amplitude,proto3" json:"amplitude,omitempty"`
-	BinaryString  []string                    `protobuf:"bytes,3,rep,name=binary_string,json=binaryString,proto3" json:"binary_string,omitempty"`
+func (x *SimulateResponse_Analysis_Bloch) GetZ() float64 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+// Difference has the probabilities of the basis state in a and b, and diff = b - a.
+type CompareResponse_Difference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BinaryString  []string               `protobuf:"bytes,1,rep,name=binary_string,json=binaryString,proto3" json:"binary_string,omitempty"`
+	A             float64                `protobuf:"fixed64,2,opt,name=a,proto3" json:"a,omitempty"`
+	B             float64                `protobuf:"fixed64,3,opt,name=b,proto3" json:"b,omitempty"`
+	Diff          float64                `protobuf:"fixed64,4,opt,name=diff,proto3" json:"diff,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulateResponse_State) Reset() {
-	*x = SimulateResponse_State{}
-	mi := &file_quasar_v1_quasar_proto_msgTypes[9]
+func (x *CompareResponse_Difference) Reset() {
+	*x = CompareResponse_Difference{}
+	mi := &file_quasar_v1_quasar_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulateResponse_State) String() string {
+func (x *CompareResponse_Difference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulateResponse_State) ProtoMessage() {}
+func (*CompareResponse_Difference) ProtoMessage() {}
 
-func (x *SimulateResponse_State) ProtoReflect() protoreflect.Message {
-	mi := &file_quasar_v1_quasar_proto_msgTypes[9]
+func (x *CompareResponse_Difference) ProtoReflect() protoreflect.Message {
+	mi := &file_quasar_v1_quasar_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,48 +3976,342 @@ func (x *SimulateResponse_State) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulateResponse_State.ProtoReflect.Descriptor instead.
-func (*SimulateResponse_State) Descriptor() ([]byte, []int) {
-	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use CompareResponse_Difference.ProtoReflect.Descriptor instead.
+func (*CompareResponse_Difference) Descriptor() ([]byte, []int) {
+	return file_quasar_v1_quasar_proto_rawDescGZIP(), []int{22, 0}
 }
 
-func (x *SimulateResponse_State) GetProbability() float64 {
+func (x *CompareResponse_Difference) GetBinaryString() []string {
 	if x != nil {
-		return x.Probability
+		return x.BinaryString
+	}
+	return nil
+}
+
+func (x *CompareResponse_Difference) GetA() float64 {
+	if x != nil {
+		return x.A
 	}
 	return 0
 }
 
-func (x *SimulateResponse_State) GetAmplitude() *SimulateResponse_Amplitude {
+func (x *CompareResponse_Difference) GetB() float64 {
 	if x != nil {
-		return x.Amplitude
+		return x.B
 	}
-	return nil
+	return 0
 }
 
-func (x *SimulateResponse_State) GetBinaryString() []string {
+func (x *CompareResponse_Difference) GetDiff() float64 {
 	if x != nil {
-		return x.BinaryString
+		return x.Diff
 	}
-	return nil
+	return 0
 }
 
 var File_quasar_v1_quasar_proto protoreflect.FileDescriptor
 
 const file_quasar_v1_quasar_proto_rawDesc = "" +
 	"\n" +
-	"\x16quasar/v1/quasar.proto\x12\tquasar.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"%\n" +
+	"\x16quasar/v1/quasar.proto\x12\tquasar.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc2\x01\n" +
+	"\n" +
+	"NoiseModel\x12\"\n" +
+	"\fdepolarizing\x18\x01 \x01(\x01R\fdepolarizing\x12+\n" +
+	"\x11amplitude_damping\x18\x02 \x01(\x01R\x10amplitudeDamping\x12#\n" +
+	"\rphase_damping\x18\x03 \x01(\x01R\fphaseDamping\x12\x19\n" +
+	"\bbit_flip\x18\x04 \x01(\x01R\abitFlip\x12#\n" +
+	"\rreadout_error\x18\x05 \x01(\x01R\freadoutError\"\xd7\b\n" +
 	"\x0fSimulateRequest\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\tR\x04code\"\x98\x02\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\x05shots\x18\x02 \x01(\x05H\x00R\x05shots\x88\x01\x01\x12\x17\n" +
+	"\x04seed\x18\x03 \x01(\x04H\x01R\x04seed\x88\x01\x01\x12>\n" +
+	"\x06inputs\x18\x04 \x03(\v2&.quasar.v1.SimulateRequest.InputsEntryR\x06inputs\x127\n" +
+	"\x05sweep\x18\x05 \x03(\v2!.quasar.v1.SimulateRequest.InputsR\x05sweep\x12 \n" +
+	"\vobservables\x18\x06 \x03(\tR\vobservables\x12!\n" +
+	"\tprecision\x18\a \x01(\x05H\x02R\tprecision\x88\x01\x01\x12\x1d\n" +
+	"\aepsilon\x18\b \x01(\x01H\x03R\aepsilon\x88\x01\x01\x12\x14\n" +
+	"\x05dense\x18\t \x01(\bR\x05dense\x12\x16\n" +
+	"\x06packed\x18\n" +
+	" \x01(\bR\x06packed\x12,\n" +
+	"\abackend\x18\v \x01(\x0e2\x12.quasar.v1.BackendR\abackend\x12+\n" +
+	"\x05noise\x18\f \x01(\v2\x15.quasar.v1.NoiseModelR\x05noise\x121\n" +
+	"\x12max_bond_dimension\x18\r \x01(\x05H\x04R\x10maxBondDimension\x88\x01\x01\x126\n" +
+	"\x14truncation_threshold\x18\x0e \x01(\x01H\x05R\x13truncationThreshold\x88\x01\x01\x12?\n" +
+	"\banalysis\x18\x0f \x01(\v2#.quasar.v1.SimulateRequest.AnalysisR\banalysis\x12D\n" +
+	"\bincludes\x18\x10 \x03(\v2(.quasar.v1.SimulateRequest.IncludesEntryR\bincludes\x12\x18\n" +
+	"\aimports\x18\x11 \x03(\tR\aimports\x1a\x8a\x01\n" +
+	"\x06Inputs\x12E\n" +
+	"\x06values\x18\x01 \x03(\v2-.quasar.v1.SimulateRequest.Inputs.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a(\n" +
+	"\bAnalysis\x12\x1c\n" +
+	"\tsubsystem\x18\x01 \x03(\x05R\tsubsystem\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
+	"\rIncludesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_shotsB\a\n" +
+	"\x05_seedB\f\n" +
+	"\n" +
+	"_precisionB\n" +
+	"\n" +
+	"\b_epsilonB\x15\n" +
+	"\x13_max_bond_dimensionB\x17\n" +
+	"\x15_truncation_threshold\"\x95\x11\n" +
 	"\x10SimulateResponse\x129\n" +
-	"\x06states\x18\x01 \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\x1a3\n" +
+	"\x06states\x18\x01 \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\x12?\n" +
+	"\x06counts\x18\x02 \x03(\v2'.quasar.v1.SimulateResponse.CountsEntryR\x06counts\x12H\n" +
+	"\tclassical\x18\x03 \x03(\v2*.quasar.v1.SimulateResponse.ClassicalEntryR\tclassical\x128\n" +
+	"\x05sweep\x18\x04 \x03(\v2\".quasar.v1.SimulateResponse.ResultR\x05sweep\x12\"\n" +
+	"\fexpectations\x18\x05 \x03(\x01R\fexpectations\x12\x16\n" +
+	"\x06packed\x18\x06 \x01(\fR\x06packed\x12\x1a\n" +
+	"\bdiagonal\x18\a \x03(\x01R\bdiagonal\x12%\n" +
+	"\x0edensity_matrix\x18\b \x01(\fR\rdensityMatrix\x12 \n" +
+	"\vstabilizers\x18\t \x03(\tR\vstabilizers\x12)\n" +
+	"\x10truncation_error\x18\n" +
+	" \x01(\x01R\x0ftruncationError\x12%\n" +
+	"\x0ebond_dimension\x18\v \x01(\x05R\rbondDimension\x12@\n" +
+	"\banalysis\x18\f \x01(\v2$.quasar.v1.SimulateResponse.AnalysisR\banalysis\x1a3\n" +
 	"\tAmplitude\x12\x12\n" +
 	"\x04real\x18\x01 \x01(\x01R\x04real\x12\x12\n" +
 	"\x04imag\x18\x02 \x01(\x01R\x04imag\x1a\x93\x01\n" +
 	"\x05State\x12 \n" +
 	"\vprobability\x18\x01 \x01(\x01R\vprobability\x12C\n" +
 	"\tamplitude\x18\x02 \x01(\v2%.quasar.v1.SimulateResponse.AmplitudeR\tamplitude\x12#\n" +
-	"\rbinary_string\x18\x03 \x03(\tR\fbinaryString\"\"\n" +
+	"\rbinary_string\x18\x03 \x03(\tR\fbinaryString\x1a\x1e\n" +
+	"\x04Bits\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x05R\x06values\x1a\xa6\x01\n" +
+	"\tClassical\x126\n" +
+	"\x04bits\x18\x01 \x01(\v2 .quasar.v1.SimulateResponse.BitsH\x00R\x04bits\x12\x12\n" +
+	"\x03int\x18\x02 \x01(\x03H\x00R\x03int\x12\x16\n" +
+	"\x05float\x18\x03 \x01(\x01H\x00R\x05float\x12\x16\n" +
+	"\x05angle\x18\x04 \x01(\x01H\x00R\x05angle\x12\x14\n" +
+	"\x04bool\x18\x05 \x01(\bH\x00R\x04boolB\a\n" +
+	"\x05value\x1a\xe0\x01\n" +
+	"\bAnalysis\x12@\n" +
+	"\x05bloch\x18\x01 \x03(\v2*.quasar.v1.SimulateResponse.Analysis.BlochR\x05bloch\x124\n" +
+	"\x16reduced_density_matrix\x18\x02 \x01(\fR\x14reducedDensityMatrix\x12\x1d\n" +
+	"\aentropy\x18\x03 \x01(\x01H\x00R\aentropy\x88\x01\x01\x1a1\n" +
+	"\x05Bloch\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x01R\x01zB\n" +
+	"\n" +
+	"\b_entropy\x1a\xb4\x06\n" +
+	"\x06Result\x12F\n" +
+	"\x06inputs\x18\x01 \x03(\v2..quasar.v1.SimulateResponse.Result.InputsEntryR\x06inputs\x129\n" +
+	"\x06states\x18\x02 \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\x12F\n" +
+	"\x06counts\x18\x03 \x03(\v2..quasar.v1.SimulateResponse.Result.CountsEntryR\x06counts\x12O\n" +
+	"\tclassical\x18\x04 \x03(\v21.quasar.v1.SimulateResponse.Result.ClassicalEntryR\tclassical\x12\"\n" +
+	"\fexpectations\x18\x05 \x03(\x01R\fexpectations\x12\x16\n" +
+	"\x06packed\x18\x06 \x01(\fR\x06packed\x12\x1a\n" +
+	"\bdiagonal\x18\a \x03(\x01R\bdiagonal\x12%\n" +
+	"\x0edensity_matrix\x18\b \x01(\fR\rdensityMatrix\x12 \n" +
+	"\vstabilizers\x18\t \x03(\tR\vstabilizers\x12)\n" +
+	"\x10truncation_error\x18\n" +
+	" \x01(\x01R\x0ftruncationError\x12%\n" +
+	"\x0ebond_dimension\x18\v \x01(\x05R\rbondDimension\x12@\n" +
+	"\banalysis\x18\f \x01(\v2$.quasar.v1.SimulateResponse.AnalysisR\banalysis\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1ac\n" +
+	"\x0eClassicalEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12;\n" +
+	"\x05value\x18\x02 \x01(\v2%.quasar.v1.SimulateResponse.ClassicalR\x05value:\x028\x01\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1ac\n" +
+	"\x0eClassicalEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12;\n" +
+	"\x05value\x18\x02 \x01(\v2%.quasar.v1.SimulateResponse.ClassicalR\x05value:\x028\x01\"\x9f\x01\n" +
+	"\x15SimulateStreamRequest\x124\n" +
+	"\arequest\x18\x01 \x01(\v2\x1a.quasar.v1.SimulateRequestR\arequest\x12(\n" +
+	"\rmax_snapshots\x18\x02 \x01(\x05H\x00R\fmaxSnapshots\x88\x01\x01\x12\x14\n" +
+	"\x05lines\x18\x03 \x03(\x05R\x05linesB\x10\n" +
+	"\x0e_max_snapshots\"\xd7\x01\n" +
+	"\x16SimulateStreamResponse\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\x05R\x04step\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x12\n" +
+	"\x04gate\x18\x04 \x01(\tR\x04gate\x12\x16\n" +
+	"\x06params\x18\x05 \x03(\x01R\x06params\x12\x16\n" +
+	"\x06qubits\x18\x06 \x03(\x05R\x06qubits\x129\n" +
+	"\x06states\x18\a \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\"\x8a\x03\n" +
+	"\x13StartSessionRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"snippet_id\x18\x02 \x01(\tR\tsnippetId\x12B\n" +
+	"\x06inputs\x18\x03 \x03(\v2*.quasar.v1.StartSessionRequest.InputsEntryR\x06inputs\x12\x17\n" +
+	"\x04seed\x18\x04 \x01(\x04H\x00R\x04seed\x88\x01\x01\x12H\n" +
+	"\bincludes\x18\x05 \x03(\v2,.quasar.v1.StartSessionRequest.IncludesEntryR\bincludes\x12\x18\n" +
+	"\aimports\x18\x06 \x03(\tR\aimports\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
+	"\rIncludesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_seed\"\xa7\x03\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\x12\x17\n" +
+	"\x04line\x18\x03 \x01(\x05H\x00R\x04line\x88\x01\x01\x12\x1b\n" +
+	"\x06column\x18\x04 \x01(\x05H\x01R\x06column\x88\x01\x01\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\x129\n" +
+	"\x06states\x18\x06 \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\x12?\n" +
+	"\tclassical\x18\a \x03(\v2!.quasar.v1.Session.ClassicalEntryR\tclassical\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x1ac\n" +
+	"\x0eClassicalEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12;\n" +
+	"\x05value\x18\x02 \x01(\v2%.quasar.v1.SimulateResponse.ClassicalR\x05value:\x028\x01B\a\n" +
+	"\x05_lineB\t\n" +
+	"\a_column\"D\n" +
+	"\x14StartSessionResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.quasar.v1.SessionR\asession\"B\n" +
+	"\vStepRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05count\x18\x02 \x01(\x05H\x00R\x05count\x88\x01\x01B\b\n" +
+	"\x06_count\"<\n" +
+	"\fStepResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.quasar.v1.SessionR\asession\"C\n" +
+	"\x0fContinueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04line\x18\x02 \x01(\x05H\x00R\x04line\x88\x01\x01B\a\n" +
+	"\x05_line\"@\n" +
+	"\x10ContinueResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.quasar.v1.SessionR\asession\"<\n" +
+	"\x0eInspectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bregister\x18\x02 \x01(\tR\bregister\"\xad\x01\n" +
+	"\x0fInspectResponse\x12\x1a\n" +
+	"\bregister\x18\x01 \x01(\tR\bregister\x129\n" +
+	"\x06states\x18\x02 \x03(\v2!.quasar.v1.SimulateResponse.StateR\x06states\x12C\n" +
+	"\tclassical\x18\x03 \x01(\v2%.quasar.v1.SimulateResponse.ClassicalR\tclassical\"\x1c\n" +
+	"\n" +
+	"EndRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\r\n" +
+	"\vEndResponse\"\x8b\x03\n" +
+	"\x0eUnitaryRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\x04gate\x18\x02 \x01(\tH\x00R\x04gate\x88\x01\x01\x12\x16\n" +
+	"\x06params\x18\x03 \x03(\x01R\x06params\x12=\n" +
+	"\x06inputs\x18\x04 \x03(\v2%.quasar.v1.UnitaryRequest.InputsEntryR\x06inputs\x12!\n" +
+	"\tprecision\x18\x05 \x01(\x05H\x01R\tprecision\x88\x01\x01\x12C\n" +
+	"\bincludes\x18\x06 \x03(\v2'.quasar.v1.UnitaryRequest.IncludesEntryR\bincludes\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
+	"\rIncludesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_gateB\f\n" +
+	"\n" +
+	"_precision\"A\n" +
+	"\x0fUnitaryResponse\x12\x16\n" +
+	"\x06qubits\x18\x01 \x01(\x05R\x06qubits\x12\x16\n" +
+	"\x06matrix\x18\x02 \x01(\fR\x06matrix\"\xc4\x02\n" +
+	"\aProgram\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"snippet_id\x18\x02 \x01(\tR\tsnippetId\x126\n" +
+	"\x06inputs\x18\x03 \x03(\v2\x1e.quasar.v1.Program.InputsEntryR\x06inputs\x12<\n" +
+	"\bincludes\x18\x04 \x03(\v2 .quasar.v1.Program.IncludesEntryR\bincludes\x12\x18\n" +
+	"\aimports\x18\x05 \x03(\tR\aimports\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
+	"\rIncludesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x88\x01\n" +
+	"\x11EquivalentRequest\x12 \n" +
+	"\x01a\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\x01a\x12 \n" +
+	"\x01b\x18\x02 \x01(\v2\x12.quasar.v1.ProgramR\x01b\x12!\n" +
+	"\ttolerance\x18\x03 \x01(\x01H\x00R\ttolerance\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tolerance\"\x82\x02\n" +
+	"\x12EquivalentResponse\x12\x1e\n" +
+	"\n" +
+	"equivalent\x18\x01 \x01(\bR\n" +
+	"equivalent\x12\x16\n" +
+	"\x06qubits\x18\x02 \x01(\x05R\x06qubits\x12\x1a\n" +
+	"\bfidelity\x18\x03 \x01(\x01R\bfidelity\x12+\n" +
+	"\x0ecounterexample\x18\x04 \x01(\tH\x00R\x0ecounterexample\x88\x01\x01\x12<\n" +
+	"\x17counterexample_fidelity\x18\x05 \x01(\x01H\x01R\x16counterexampleFidelity\x88\x01\x01B\x11\n" +
+	"\x0f_counterexampleB\x1a\n" +
+	"\x18_counterexample_fidelity\"\xf7\x01\n" +
+	"\x0eCompareRequest\x12 \n" +
+	"\x01a\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\x01a\x12\"\n" +
+	"\x01b\x18\x02 \x01(\v2\x12.quasar.v1.ProgramH\x00R\x01b\x12\x18\n" +
+	"\x06target\x18\x03 \x01(\fH\x00R\x06target\x12\x17\n" +
+	"\x04seed\x18\x04 \x01(\x04H\x01R\x04seed\x88\x01\x01\x12!\n" +
+	"\tprecision\x18\x05 \x01(\x05H\x02R\tprecision\x88\x01\x01\x12\x1d\n" +
+	"\aepsilon\x18\x06 \x01(\x01H\x03R\aepsilon\x88\x01\x01B\a\n" +
+	"\x05otherB\a\n" +
+	"\x05_seedB\f\n" +
+	"\n" +
+	"_precisionB\n" +
+	"\n" +
+	"\b_epsilon\"\x98\x02\n" +
+	"\x0fCompareResponse\x12\x16\n" +
+	"\x06qubits\x18\x01 \x01(\x05R\x06qubits\x12\x1a\n" +
+	"\bfidelity\x18\x02 \x01(\x01R\bfidelity\x12%\n" +
+	"\x0etrace_distance\x18\x03 \x01(\x01R\rtraceDistance\x12G\n" +
+	"\vdifferences\x18\x04 \x03(\v2%.quasar.v1.CompareResponse.DifferenceR\vdifferences\x1aa\n" +
+	"\n" +
+	"Difference\x12#\n" +
+	"\rbinary_string\x18\x01 \x03(\tR\fbinaryString\x12\f\n" +
+	"\x01a\x18\x02 \x01(\x01R\x01a\x12\f\n" +
+	"\x01b\x18\x03 \x01(\x01R\x01b\x12\x12\n" +
+	"\x04diff\x18\x04 \x01(\x01R\x04diff\"\x82\x01\n" +
+	"\vDrawRequest\x12,\n" +
+	"\aprogram\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\aprogram\x12-\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x15.quasar.v1.DrawFormatR\x06format\x12\x16\n" +
+	"\x06expand\x18\x03 \x01(\bR\x06expand\"(\n" +
+	"\fDrawResponse\x12\x18\n" +
+	"\adiagram\x18\x01 \x01(\tR\adiagram\">\n" +
+	"\x0eAnalyzeRequest\x12,\n" +
+	"\aprogram\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\aprogram\"\x93\x02\n" +
+	"\x0fAnalyzeResponse\x12\x16\n" +
+	"\x06qubits\x18\x01 \x01(\x05R\x06qubits\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12;\n" +
+	"\x05gates\x18\x03 \x03(\v2%.quasar.v1.AnalyzeResponse.GatesEntryR\x05gates\x12&\n" +
+	"\x0ftwo_qubit_gates\x18\x04 \x01(\x05R\rtwoQubitGates\x12\x17\n" +
+	"\at_count\x18\x05 \x01(\x05R\x06tCount\x12\x1a\n" +
+	"\bclifford\x18\x06 \x01(\bR\bclifford\x1a8\n" +
+	"\n" +
+	"GatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"?\n" +
+	"\x0fOptimizeRequest\x12,\n" +
+	"\aprogram\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\aprogram\"\x8c\x01\n" +
+	"\x10OptimizeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x122\n" +
+	"\x06before\x18\x02 \x01(\v2\x1a.quasar.v1.AnalyzeResponseR\x06before\x120\n" +
+	"\x05after\x18\x03 \x01(\v2\x1a.quasar.v1.AnalyzeResponseR\x05after\"\x8a\x01\n" +
+	"\x10TranspileRequest\x12,\n" +
+	"\aprogram\x18\x01 \x01(\v2\x12.quasar.v1.ProgramR\aprogram\x12\x14\n" +
+	"\x05basis\x18\x02 \x03(\tR\x05basis\x122\n" +
+	"\fcoupling_map\x18\x03 \x03(\v2\x0f.quasar.v1.EdgeR\vcouplingMap\"\x9c\x01\n" +
+	"\x11TranspileResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x124\n" +
+	"\ametrics\x18\x02 \x01(\v2\x1a.quasar.v1.AnalyzeResponseR\ametrics\x121\n" +
+	"\arouting\x18\x03 \x01(\v2\x12.quasar.v1.RoutingH\x00R\arouting\x88\x01\x01B\n" +
+	"\n" +
+	"\b_routing\"\"\n" +
+	"\x04Edge\x12\f\n" +
+	"\x01a\x18\x01 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x02 \x01(\x05R\x01b\"Z\n" +
+	"\aRouting\x12\x16\n" +
+	"\x06layout\x18\x01 \x03(\x05R\x06layout\x12!\n" +
+	"\ffinal_layout\x18\x02 \x03(\x05R\vfinalLayout\x12\x14\n" +
+	"\x05swaps\x18\x03 \x01(\x05R\x05swaps\"\"\n" +
 	"\fShareRequest\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\"Z\n" +
 	"\rShareResponse\x12\x0e\n" +
@@ -562,23 +4324,105 @@ const file_quasar_v1_quasar_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"%\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf9\x02\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.quasar.v1.JobStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tstartedAt\x88\x01\x01\x12@\n" +
+	"\vfinished_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"finishedAt\x88\x01\x01\x123\n" +
+	"\x06result\x18\x06 \x01(\v2\x1b.quasar.v1.SimulateResponseR\x06result\x12\x19\n" +
+	"\x05error\x18\a \x01(\tH\x02R\x05error\x88\x01\x01B\r\n" +
+	"\v_started_atB\x0e\n" +
+	"\f_finished_atB\b\n" +
+	"\x06_error\"[\n" +
+	"\rSubmitRequest\x124\n" +
+	"\arequest\x18\x01 \x01(\v2\x1a.quasar.v1.SimulateRequestR\arequest\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\"[\n" +
+	"\x0eSubmitResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x0eGetJobResponse\x12 \n" +
+	"\x03job\x18\x01 \x01(\v2\x0e.quasar.v1.JobR\x03job\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x11CancelJobResponse\x12 \n" +
+	"\x03job\x18\x01 \x01(\v2\x0e.quasar.v1.JobR\x03job\"L\n" +
+	"\x0fListJobsRequest\x12\x19\n" +
+	"\x05limit\x18\x01 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05ownerB\b\n" +
+	"\x06_limit\"6\n" +
+	"\x10ListJobsResponse\x12\"\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0e.quasar.v1.JobR\x04jobs\"\xf6\x01\n" +
 	"\x0fValidateRequest\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\tR\x04code\"\x9d\x01\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x122\n" +
+	"\fcoupling_map\x18\x02 \x03(\v2\x0f.quasar.v1.EdgeR\vcouplingMap\x12D\n" +
+	"\bincludes\x18\x03 \x03(\v2(.quasar.v1.ValidateRequest.IncludesEntryR\bincludes\x12\x18\n" +
+	"\aimports\x18\x04 \x03(\tR\aimports\x1a;\n" +
+	"\rIncludesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf6\x01\n" +
 	"\x10ValidateResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
 	"\x04line\x18\x02 \x01(\x05H\x00R\x04line\x88\x01\x01\x12\x1b\n" +
 	"\x06column\x18\x03 \x01(\x05H\x01R\x06column\x88\x01\x01\x12\x1d\n" +
-	"\amessage\x18\x04 \x01(\tH\x02R\amessage\x88\x01\x01B\a\n" +
+	"\amessage\x18\x04 \x01(\tH\x02R\amessage\x88\x01\x01\x121\n" +
+	"\arouting\x18\x05 \x01(\v2\x12.quasar.v1.RoutingH\x03R\arouting\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversionB\a\n" +
 	"\x05_lineB\t\n" +
 	"\a_columnB\n" +
 	"\n" +
-	"\b_message2\x96\x02\n" +
+	"\b_messageB\n" +
+	"\n" +
+	"\b_routing*\x80\x01\n" +
+	"\aBackend\x12\x17\n" +
+	"\x13BACKEND_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13BACKEND_STATEVECTOR\x10\x01\x12\x1a\n" +
+	"\x16BACKEND_DENSITY_MATRIX\x10\x02\x12\x16\n" +
+	"\x12BACKEND_STABILIZER\x10\x03\x12\x0f\n" +
+	"\vBACKEND_MPS\x10\x04*l\n" +
+	"\n" +
+	"DrawFormat\x12\x1b\n" +
+	"\x17DRAW_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11DRAW_FORMAT_ASCII\x10\x01\x12\x13\n" +
+	"\x0fDRAW_FORMAT_SVG\x10\x02\x12\x15\n" +
+	"\x11DRAW_FORMAT_LATEX\x10\x03*\xa0\x01\n" +
+	"\tJobStatus\x12\x1a\n" +
+	"\x16JOB_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11JOB_STATUS_QUEUED\x10\x01\x12\x16\n" +
+	"\x12JOB_STATUS_RUNNING\x10\x02\x12\x18\n" +
+	"\x14JOB_STATUS_SUCCEEDED\x10\x03\x12\x15\n" +
+	"\x11JOB_STATUS_FAILED\x10\x04\x12\x17\n" +
+	"\x13JOB_STATUS_CANCELED\x10\x052\xba\v\n" +
 	"\rQuasarService\x12E\n" +
-	"\bSimulate\x12\x1a.quasar.v1.SimulateRequest\x1a\x1b.quasar.v1.SimulateResponse\"\x00\x12<\n" +
+	"\bSimulate\x12\x1a.quasar.v1.SimulateRequest\x1a\x1b.quasar.v1.SimulateResponse\"\x00\x12Y\n" +
+	"\x0eSimulateStream\x12 .quasar.v1.SimulateStreamRequest\x1a!.quasar.v1.SimulateStreamResponse\"\x000\x01\x12Q\n" +
+	"\fStartSession\x12\x1e.quasar.v1.StartSessionRequest\x1a\x1f.quasar.v1.StartSessionResponse\"\x00\x129\n" +
+	"\x04Step\x12\x16.quasar.v1.StepRequest\x1a\x17.quasar.v1.StepResponse\"\x00\x12E\n" +
+	"\bContinue\x12\x1a.quasar.v1.ContinueRequest\x1a\x1b.quasar.v1.ContinueResponse\"\x00\x12B\n" +
+	"\aInspect\x12\x19.quasar.v1.InspectRequest\x1a\x1a.quasar.v1.InspectResponse\"\x00\x126\n" +
+	"\x03End\x12\x15.quasar.v1.EndRequest\x1a\x16.quasar.v1.EndResponse\"\x00\x12B\n" +
+	"\aUnitary\x12\x19.quasar.v1.UnitaryRequest\x1a\x1a.quasar.v1.UnitaryResponse\"\x00\x12K\n" +
+	"\n" +
+	"Equivalent\x12\x1c.quasar.v1.EquivalentRequest\x1a\x1d.quasar.v1.EquivalentResponse\"\x00\x12B\n" +
+	"\aCompare\x12\x19.quasar.v1.CompareRequest\x1a\x1a.quasar.v1.CompareResponse\"\x00\x129\n" +
+	"\x04Draw\x12\x16.quasar.v1.DrawRequest\x1a\x17.quasar.v1.DrawResponse\"\x00\x12B\n" +
+	"\aAnalyze\x12\x19.quasar.v1.AnalyzeRequest\x1a\x1a.quasar.v1.AnalyzeResponse\"\x00\x12E\n" +
+	"\bOptimize\x12\x1a.quasar.v1.OptimizeRequest\x1a\x1b.quasar.v1.OptimizeResponse\"\x00\x12H\n" +
+	"\tTranspile\x12\x1b.quasar.v1.TranspileRequest\x1a\x1c.quasar.v1.TranspileResponse\"\x00\x12<\n" +
 	"\x05Share\x12\x17.quasar.v1.ShareRequest\x1a\x18.quasar.v1.ShareResponse\"\x00\x129\n" +
 	"\x04Edit\x12\x16.quasar.v1.EditRequest\x1a\x17.quasar.v1.EditResponse\"\x00\x12E\n" +
-	"\bValidate\x12\x1a.quasar.v1.ValidateRequest\x1a\x1b.quasar.v1.ValidateResponse\"\x00B3Z1github.com/itsubaki/quasar/gen/quasar/v1;quasarv1b\x06proto3"
+	"\bValidate\x12\x1a.quasar.v1.ValidateRequest\x1a\x1b.quasar.v1.ValidateResponse\"\x00\x12?\n" +
+	"\x06Submit\x12\x18.quasar.v1.SubmitRequest\x1a\x19.quasar.v1.SubmitResponse\"\x00\x12?\n" +
+	"\x06GetJob\x12\x18.quasar.v1.GetJobRequest\x1a\x19.quasar.v1.GetJobResponse\"\x00\x12H\n" +
+	"\tCancelJob\x12\x1b.quasar.v1.CancelJobRequest\x1a\x1c.quasar.v1.CancelJobResponse\"\x00\x12E\n" +
+	"\bListJobs\x12\x1a.quasar.v1.ListJobsRequest\x1a\x1b.quasar.v1.ListJobsResponse\"\x00B3Z1github.com/itsubaki/quasar/gen/quasar/v1;quasarv1b\x06proto3"
 
 var (
 	file_quasar_v1_quasar_proto_rawDescOnce sync.Once
@@ -592,38 +4436,207 @@ func file_quasar_v1_quasar_proto_rawDescGZIP() []byte {
 	return file_quasar_v1_quasar_proto_rawDescData
 }
 
-var file_quasar_v1_quasar_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_quasar_v1_quasar_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_quasar_v1_quasar_proto_msgTypes = make([]protoimpl.MessageInfo, 75)
 var file_quasar_v1_quasar_proto_goTypes = []any{
-	(*SimulateRequest)(nil),            // 0: quasar.v1.SimulateRequest
-	(*SimulateResponse)(nil),           // 1: quasar.v1.SimulateResponse
-	(*ShareRequest)(nil),               // 2: quasar.v1.ShareRequest
-	(*ShareResponse)(nil),              // 3: quasar.v1.ShareResponse
-	(*EditRequest)(nil),                // 4: quasar.v1.EditRequest
-	(*EditResponse)(nil),               // 5: quasar.v1.EditResponse
-	(*ValidateRequest)(nil),            // 6: quasar.v1.ValidateRequest
-	(*ValidateResponse)(nil),           // 7: quasar.v1.ValidateResponse
-	(*SimulateResponse_Amplitude)(nil), // 8: quasar.v1.SimulateResponse.Amplitude
-	(*SimulateResponse_State)(nil),     // 9: quasar.v1.SimulateResponse.State
-	(*timestamppb.Timestamp)(nil),      // 10: google.protobuf.Timestamp
+	(Backend)(0),                            // 0: quasar.v1.Backend
+	(DrawFormat)(0),                         // 1: quasar.v1.DrawFormat
+	(JobStatus)(0),                          // 2: quasar.v1.JobStatus
+	(*NoiseModel)(nil),                      // 3: quasar.v1.NoiseModel
+	(*SimulateRequest)(nil),                 // 4: quasar.v1.SimulateRequest
+	(*SimulateResponse)(nil),                // 5: quasar.v1.SimulateResponse
+	(*SimulateStreamRequest)(nil),           // 6: quasar.v1.SimulateStreamRequest
+	(*SimulateStreamResponse)(nil),          // 7: quasar.v1.SimulateStreamResponse
+	(*StartSessionRequest)(nil),             // 8: quasar.v1.StartSessionRequest
+	(*Session)(nil),                         // 9: quasar.v1.Session
+	(*StartSessionResponse)(nil),            // 10: quasar.v1.StartSessionResponse
+	(*StepRequest)(nil),                     // 11: quasar.v1.StepRequest
+	(*StepResponse)(nil),                    // 12: quasar.v1.StepResponse
+	(*ContinueRequest)(nil),                 // 13: quasar.v1.ContinueRequest
+	(*ContinueResponse)(nil),                // 14: quasar.v1.ContinueResponse
+	(*InspectRequest)(nil),                  // 15: quasar.v1.InspectRequest
+	(*InspectResponse)(nil),                 // 16: quasar.v1.InspectResponse
+	(*EndRequest)(nil),                      // 17: quasar.v1.EndRequest
+	(*EndResponse)(nil),                     // 18: quasar.v1.EndResponse
+	(*UnitaryRequest)(nil),                  // 19: quasar.v1.UnitaryRequest
+	(*UnitaryResponse)(nil),                 // 20: quasar.v1.UnitaryResponse
+	(*Program)(nil),                         // 21: quasar.v1.Program
+	(*EquivalentRequest)(nil),               // 22: quasar.v1.EquivalentRequest
+	(*EquivalentResponse)(nil),              // 23: quasar.v1.EquivalentResponse
+	(*CompareRequest)(nil),                  // 24: quasar.v1.CompareRequest
+	(*CompareResponse)(nil),                 // 25: quasar.v1.CompareResponse
+	(*DrawRequest)(nil),                     // 26: quasar.v1.DrawRequest
+	(*DrawResponse)(nil),                    // 27: quasar.v1.DrawResponse
+	(*AnalyzeRequest)(nil),                  // 28: quasar.v1.AnalyzeRequest
+	(*AnalyzeResponse)(nil),                 // 29: quasar.v1.AnalyzeResponse
+	(*OptimizeRequest)(nil),                 // 30: quasar.v1.OptimizeRequest
+	(*OptimizeResponse)(nil),                // 31: quasar.v1.OptimizeResponse
+	(*TranspileRequest)(nil),                // 32: quasar.v1.TranspileRequest
+	(*TranspileResponse)(nil),               // 33: quasar.v1.TranspileResponse
+	(*Edge)(nil),                            // 34: quasar.v1.Edge
+	(*Routing)(nil),                         // 35: quasar.v1.Routing
+	(*ShareRequest)(nil),                    // 36: quasar.v1.ShareRequest
+	(*ShareResponse)(nil),                   // 37: quasar.v1.ShareResponse
+	(*EditRequest)(nil),                     // 38: quasar.v1.EditRequest
+	(*EditResponse)(nil),                    // 39: quasar.v1.EditResponse
+	(*Job)(nil),                             // 40: quasar.v1.Job
+	(*SubmitRequest)(nil),                   // 41: quasar.v1.SubmitRequest
+	(*SubmitResponse)(nil),                  // 42: quasar.v1.SubmitResponse
+	(*GetJobRequest)(nil),                   // 43: quasar.v1.GetJobRequest
+	(*GetJobResponse)(nil),                  // 44: quasar.v1.GetJobResponse
+	(*CancelJobRequest)(nil),                // 45: quasar.v1.CancelJobRequest
+	(*CancelJobResponse)(nil),               // 46: quasar.v1.CancelJobResponse
+	(*ListJobsRequest)(nil),                 // 47: quasar.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),                // 48: quasar.v1.ListJobsResponse
+	(*ValidateRequest)(nil),                 // 49: quasar.v1.ValidateRequest
+	(*ValidateResponse)(nil),                // 50: quasar.v1.ValidateResponse
+	(*SimulateRequest_Inputs)(nil),          // 51: quasar.v1.SimulateRequest.Inputs
+	(*SimulateRequest_Analysis)(nil),        // 52: quasar.v1.SimulateRequest.Analysis
+	nil,                                     // 53: quasar.v1.SimulateRequest.InputsEntry
+	nil,                                     // 54: quasar.v1.SimulateRequest.IncludesEntry
+	nil,                                     // 55: quasar.v1.SimulateRequest.Inputs.ValuesEntry
+	(*SimulateResponse_Amplitude)(nil),      // 56: quasar.v1.SimulateResponse.Amplitude
+	(*SimulateResponse_State)(nil),          // 57: quasar.v1.SimulateResponse.State
+	(*SimulateResponse_Bits)(nil),           // 58: quasar.v1.SimulateResponse.Bits
+	(*SimulateResponse_Classical)(nil),      // 59: quasar.v1.SimulateResponse.Classical
+	(*SimulateResponse_Analysis)(nil),       // 60: quasar.v1.SimulateResponse.Analysis
+	(*SimulateResponse_Result)(nil),         // 61: quasar.v1.SimulateResponse.Result
+	nil,                                     // 62: quasar.v1.SimulateResponse.CountsEntry
+	nil,                                     // 63: quasar.v1.SimulateResponse.ClassicalEntry
+	(*SimulateResponse_Analysis_Bloch)(nil), // 64: quasar.v1.SimulateResponse.Analysis.Bloch
+	nil,                                     // 65: quasar.v1.SimulateResponse.Result.InputsEntry
+	nil,                                     // 66: quasar.v1.SimulateResponse.Result.CountsEntry
+	nil,                                     // 67: quasar.v1.SimulateResponse.Result.ClassicalEntry
+	nil,                                     // 68: quasar.v1.StartSessionRequest.InputsEntry
+	nil,                                     // 69: quasar.v1.StartSessionRequest.IncludesEntry
+	nil,                                     // 70: quasar.v1.Session.ClassicalEntry
+	nil,                                     // 71: quasar.v1.UnitaryRequest.InputsEntry
+	nil,                                     // 72: quasar.v1.UnitaryRequest.IncludesEntry
+	nil,                                     // 73: quasar.v1.Program.InputsEntry
+	nil,                                     // 74: quasar.v1.Program.IncludesEntry
+	(*CompareResponse_Difference)(nil),      // 75: quasar.v1.CompareResponse.Difference
+	nil,                                     // 76: quasar.v1.AnalyzeResponse.GatesEntry
+	nil,                                     // 77: quasar.v1.ValidateRequest.IncludesEntry
+	(*timestamppb.Timestamp)(nil),           // 78: google.protobuf.Timestamp
 }
 var file_quasar_v1_quasar_proto_depIdxs = []int32{
-	9,  // 0: quasar.v1.SimulateResponse.states:type_name -> quasar.v1.SimulateResponse.State
-	10, // 1: quasar.v1.ShareResponse.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: quasar.v1.EditResponse.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 3: quasar.v1.SimulateResponse.State.amplitude:type_name -> quasar.v1.SimulateResponse.Amplitude
-	0,  // 4: quasar.v1.QuasarService.Simulate:input_type -> quasar.v1.SimulateRequest
-	2,  // 5: quasar.v1.QuasarService.Share:input_type -> quasar.v1.ShareRequest
-	4,  // 6: quasar.v1.QuasarService.Edit:input_type -> quasar.v1.EditRequest
-	6,  // 7: quasar.v1.QuasarService.Validate:input_type -> quasar.v1.ValidateRequest
-	1,  // 8: quasar.v1.QuasarService.Simulate:output_type -> quasar.v1.SimulateResponse
-	3,  // 9: quasar.v1.QuasarService.Share:output_type -> quasar.v1.ShareResponse
-	5,  // 10: quasar.v1.QuasarService.Edit:output_type -> quasar.v1.EditResponse
-	7,  // 11: quasar.v1.QuasarService.Validate:output_type -> quasar.v1.ValidateResponse
-	8,  // [8:12] is the sub-list for method output_type
-	4,  // [4:8] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	53, // 0: quasar.v1.SimulateRequest.inputs:type_name -> quasar.v1.SimulateRequest.InputsEntry
+	51, // 1: quasar.v1.SimulateRequest.sweep:type_name -> quasar.v1.SimulateRequest.Inputs
+	0,  // 2: quasar.v1.SimulateRequest.backend:type_name -> quasar.v1.Backend
+	3,  // 3: quasar.v1.SimulateRequest.noise:type_name -> quasar.v1.NoiseModel
+	52, // 4: quasar.v1.SimulateRequest.analysis:type_name -> quasar.v1.SimulateRequest.Analysis
+	54, // 5: quasar.v1.SimulateRequest.includes:type_name -> quasar.v1.SimulateRequest.IncludesEntry
+	57, // 6: quasar.v1.SimulateResponse.states:type_name -> quasar.v1.SimulateResponse.State
+	62, // 7: quasar.v1.SimulateResponse.counts:type_name -> quasar.v1.SimulateResponse.CountsEntry
+	63, // 8: quasar.v1.SimulateResponse.classical:type_name -> quasar.v1.SimulateResponse.ClassicalEntry
+	61, // 9: quasar.v1.SimulateResponse.sweep:type_name -> quasar.v1.SimulateResponse.Result
+	60, // 10: quasar.v1.SimulateResponse.analysis:type_name -> quasar.v1.SimulateResponse.Analysis
+	4,  // 11: quasar.v1.SimulateStreamRequest.request:type_name -> quasar.v1.SimulateRequest
+	57, // 12: quasar.v1.SimulateStreamResponse.states:type_name -> quasar.v1.SimulateResponse.State
+	68, // 13: quasar.v1.StartSessionRequest.inputs:type_name -> quasar.v1.StartSessionRequest.InputsEntry
+	69, // 14: quasar.v1.StartSessionRequest.includes:type_name -> quasar.v1.StartSessionRequest.IncludesEntry
+	57, // 15: quasar.v1.Session.states:type_name -> quasar.v1.SimulateResponse.State
+	70, // 16: quasar.v1.Session.classical:type_name -> quasar.v1.Session.ClassicalEntry
+	78, // 17: quasar.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	9,  // 18: quasar.v1.StartSessionResponse.session:type_name -> quasar.v1.Session
+	9,  // 19: quasar.v1.StepResponse.session:type_name -> quasar.v1.Session
+	9,  // 20: quasar.v1.ContinueResponse.session:type_name -> quasar.v1.Session
+	57, // 21: quasar.v1.InspectResponse.states:type_name -> quasar.v1.SimulateResponse.State
+	59, // 22: quasar.v1.InspectResponse.classical:type_name -> quasar.v1.SimulateResponse.Classical
+	71, // 23: quasar.v1.UnitaryRequest.inputs:type_name -> quasar.v1.UnitaryRequest.InputsEntry
+	72, // 24: quasar.v1.UnitaryRequest.includes:type_name -> quasar.v1.UnitaryRequest.IncludesEntry
+	73, // 25: quasar.v1.Program.inputs:type_name -> quasar.v1.Program.InputsEntry
+	74, // 26: quasar.v1.Program.includes:type_name -> quasar.v1.Program.IncludesEntry
+	21, // 27: quasar.v1.EquivalentRequest.a:type_name -> quasar.v1.Program
+	21, // 28: quasar.v1.EquivalentRequest.b:type_name -> quasar.v1.Program
+	21, // 29: quasar.v1.CompareRequest.a:type_name -> quasar.v1.Program
+	21, // 30: quasar.v1.CompareRequest.b:type_name -> quasar.v1.Program
+	75, // 31: quasar.v1.CompareResponse.differences:type_name -> quasar.v1.CompareResponse.Difference
+	21, // 32: quasar.v1.DrawRequest.program:type_name -> quasar.v1.Program
+	1,  // 33: quasar.v1.DrawRequest.format:type_name -> quasar.v1.DrawFormat
+	21, // 34: quasar.v1.AnalyzeRequest.program:type_name -> quasar.v1.Program
+	76, // 35: quasar.v1.AnalyzeResponse.gates:type_name -> quasar.v1.AnalyzeResponse.GatesEntry
+	21, // 36: quasar.v1.OptimizeRequest.program:type_name -> quasar.v1.Program
+	29, // 37: quasar.v1.OptimizeResponse.before:type_name -> quasar.v1.AnalyzeResponse
+	29, // 38: quasar.v1.OptimizeResponse.after:type_name -> quasar.v1.AnalyzeResponse
+	21, // 39: quasar.v1.TranspileRequest.program:type_name -> quasar.v1.Program
+	34, // 40: quasar.v1.TranspileRequest.coupling_map:type_name -> quasar.v1.Edge
+	29, // 41: quasar.v1.TranspileResponse.metrics:type_name -> quasar.v1.AnalyzeResponse
+	35, // 42: quasar.v1.TranspileResponse.routing:type_name -> quasar.v1.Routing
+	78, // 43: quasar.v1.ShareResponse.created_at:type_name -> google.protobuf.Timestamp
+	78, // 44: quasar.v1.EditResponse.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 45: quasar.v1.Job.status:type_name -> quasar.v1.JobStatus
+	78, // 46: quasar.v1.Job.created_at:type_name -> google.protobuf.Timestamp
+	78, // 47: quasar.v1.Job.started_at:type_name -> google.protobuf.Timestamp
+	78, // 48: quasar.v1.Job.finished_at:type_name -> google.protobuf.Timestamp
+	5,  // 49: quasar.v1.Job.result:type_name -> quasar.v1.SimulateResponse
+	4,  // 50: quasar.v1.SubmitRequest.request:type_name -> quasar.v1.SimulateRequest
+	78, // 51: quasar.v1.SubmitResponse.created_at:type_name -> google.protobuf.Timestamp
+	40, // 52: quasar.v1.GetJobResponse.job:type_name -> quasar.v1.Job
+	40, // 53: quasar.v1.CancelJobResponse.job:type_name -> quasar.v1.Job
+	40, // 54: quasar.v1.ListJobsResponse.jobs:type_name -> quasar.v1.Job
+	34, // 55: quasar.v1.ValidateRequest.coupling_map:type_name -> quasar.v1.Edge
+	77, // 56: quasar.v1.ValidateRequest.includes:type_name -> quasar.v1.ValidateRequest.IncludesEntry
+	35, // 57: quasar.v1.ValidateResponse.routing:type_name -> quasar.v1.Routing
+	55, // 58: quasar.v1.SimulateRequest.Inputs.values:type_name -> quasar.v1.SimulateRequest.Inputs.ValuesEntry
+	56, // 59: quasar.v1.SimulateResponse.State.amplitude:type_name -> quasar.v1.SimulateResponse.Amplitude
+	58, // 60: quasar.v1.SimulateResponse.Classical.bits:type_name -> quasar.v1.SimulateResponse.Bits
+	64, // 61: quasar.v1.SimulateResponse.Analysis.bloch:type_name -> quasar.v1.SimulateResponse.Analysis.Bloch
+	65, // 62: quasar.v1.SimulateResponse.Result.inputs:type_name -> quasar.v1.SimulateResponse.Result.InputsEntry
+	57, // 63: quasar.v1.SimulateResponse.Result.states:type_name -> quasar.v1.SimulateResponse.State
+	66, // 64: quasar.v1.SimulateResponse.Result.counts:type_name -> quasar.v1.SimulateResponse.Result.CountsEntry
+	67, // 65: quasar.v1.SimulateResponse.Result.classical:type_name -> quasar.v1.SimulateResponse.Result.ClassicalEntry
+	60, // 66: quasar.v1.SimulateResponse.Result.analysis:type_name -> quasar.v1.SimulateResponse.Analysis
+	59, // 67: quasar.v1.SimulateResponse.ClassicalEntry.value:type_name -> quasar.v1.SimulateResponse.Classical
+	59, // 68: quasar.v1.SimulateResponse.Result.ClassicalEntry.value:type_name -> quasar.v1.SimulateResponse.Classical
+	59, // 69: quasar.v1.Session.ClassicalEntry.value:type_name -> quasar.v1.SimulateResponse.Classical
+	4,  // 70: quasar.v1.QuasarService.Simulate:input_type -> quasar.v1.SimulateRequest
+	6,  // 71: quasar.v1.QuasarService.SimulateStream:input_type -> quasar.v1.SimulateStreamRequest
+	8,  // 72: quasar.v1.QuasarService.StartSession:input_type -> quasar.v1.StartSessionRequest
+	11, // 73: quasar.v1.QuasarService.Step:input_type -> quasar.v1.StepRequest
+	13, // 74: quasar.v1.QuasarService.Continue:input_type -> quasar.v1.ContinueRequest
+	15, // 75: quasar.v1.QuasarService.Inspect:input_type -> quasar.v1.InspectRequest
+	17, // 76: quasar.v1.QuasarService.End:input_type -> quasar.v1.EndRequest
+	19, // 77: quasar.v1.QuasarService.Unitary:input_type -> quasar.v1.UnitaryRequest
+	22, // 78: quasar.v1.QuasarService.Equivalent:input_type -> quasar.v1.EquivalentRequest
+	24, // 79: quasar.v1.QuasarService.Compare:input_type -> quasar.v1.CompareRequest
+	26, // 80: quasar.v1.QuasarService.Draw:input_type -> quasar.v1.DrawRequest
+	28, // 81: quasar.v1.QuasarService.Analyze:input_type -> quasar.v1.AnalyzeRequest
+	30, // 82: quasar.v1.QuasarService.Optimize:input_type -> quasar.v1.OptimizeRequest
+	32, // 83: quasar.v1.QuasarService.Transpile:input_type -> quasar.v1.TranspileRequest
+	36, // 84: quasar.v1.QuasarService.Share:input_type -> quasar.v1.ShareRequest
+	38, // 85: quasar.v1.QuasarService.Edit:input_type -> quasar.v1.EditRequest
+	49, // 86: quasar.v1.QuasarService.Validate:input_type -> quasar.v1.ValidateRequest
+	41, // 87: quasar.v1.QuasarService.Submit:input_type -> quasar.v1.SubmitRequest
+	43, // 88: quasar.v1.QuasarService.GetJob:input_type -> quasar.v1.GetJobRequest
+	45, // 89: quasar.v1.QuasarService.CancelJob:input_type -> quasar.v1.CancelJobRequest
+	47, // 90: quasar.v1.QuasarService.ListJobs:input_type -> quasar.v1.ListJobsRequest
+	5,  // 91: quasar.v1.QuasarService.Simulate:output_type -> quasar.v1.SimulateResponse
+	7,  // 92: quasar.v1.QuasarService.SimulateStream:output_type -> quasar.v1.SimulateStreamResponse
+	10, // 93: quasar.v1.QuasarService.StartSession:output_type -> quasar.v1.StartSessionResponse
+	12, // 94: quasar.v1.QuasarService.Step:output_type -> quasar.v1.StepResponse
+	14, // 95: quasar.v1.QuasarService.Continue:output_type -> quasar.v1.ContinueResponse
+	16, // 96: quasar.v1.QuasarService.Inspect:output_type -> quasar.v1.InspectResponse
+	18, // 97: quasar.v1.QuasarService.End:output_type -> quasar.v1.EndResponse
+	20, // 98: quasar.v1.QuasarService.Unitary:output_type -> quasar.v1.UnitaryResponse
+	23, // 99: quasar.v1.QuasarService.Equivalent:output_type -> quasar.v1.EquivalentResponse
+	25, // 100: quasar.v1.QuasarService.Compare:output_type -> quasar.v1.CompareResponse
+	27, // 101: quasar.v1.QuasarService.Draw:output_type -> quasar.v1.DrawResponse
+	29, // 102: quasar.v1.QuasarService.Analyze:output_type -> quasar.v1.AnalyzeResponse
+	31, // 103: quasar.v1.QuasarService.Optimize:output_type -> quasar.v1.OptimizeResponse
+	33, // 104: quasar.v1.QuasarService.Transpile:output_type -> quasar.v1.TranspileResponse
+	37, // 105: quasar.v1.QuasarService.Share:output_type -> quasar.v1.ShareResponse
+	39, // 106: quasar.v1.QuasarService.Edit:output_type -> quasar.v1.EditResponse
+	50, // 107: quasar.v1.QuasarService.Validate:output_type -> quasar.v1.ValidateResponse
+	42, // 108: quasar.v1.QuasarService.Submit:output_type -> quasar.v1.SubmitResponse
+	44, // 109: quasar.v1.QuasarService.GetJob:output_type -> quasar.v1.GetJobResponse
+	46, // 110: quasar.v1.QuasarService.CancelJob:output_type -> quasar.v1.CancelJobResponse
+	48, // 111: quasar.v1.QuasarService.ListJobs:output_type -> quasar.v1.ListJobsResponse
+	91, // [91:112] is the sub-list for method output_type
+	70, // [70:91] is the sub-list for method input_type
+	70, // [70:70] is the sub-list for extension type_name
+	70, // [70:70] is the sub-list for extension extendee
+	0,  // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_quasar_v1_quasar_proto_init() }
@@ -631,19 +4644,44 @@ func file_quasar_v1_quasar_proto_init() {
 	if File_quasar_v1_quasar_proto != nil {
 		return
 	}
-	file_quasar_v1_quasar_proto_msgTypes[7].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[1].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[3].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[5].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[6].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[8].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[10].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[16].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[19].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[20].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[21].OneofWrappers = []any{
+		(*CompareRequest_B)(nil),
+		(*CompareRequest_Target)(nil),
+	}
+	file_quasar_v1_quasar_proto_msgTypes[30].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[37].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[44].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[47].OneofWrappers = []any{}
+	file_quasar_v1_quasar_proto_msgTypes[56].OneofWrappers = []any{
+		(*SimulateResponse_Classical_Bits)(nil),
+		(*SimulateResponse_Classical_Int)(nil),
+		(*SimulateResponse_Classical_Float)(nil),
+		(*SimulateResponse_Classical_Angle)(nil),
+		(*SimulateResponse_Classical_Bool)(nil),
+	}
+	file_quasar_v1_quasar_proto_msgTypes[57].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quasar_v1_quasar_proto_rawDesc), len(file_quasar_v1_quasar_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      3,
+			NumMessages:   75,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_quasar_v1_quasar_proto_goTypes,
 		DependencyIndexes: file_quasar_v1_quasar_proto_depIdxs,
+		EnumInfos:         file_quasar_v1_quasar_proto_enumTypes,
 		MessageInfos:      file_quasar_v1_quasar_proto_msgTypes,
 	}.Build()
 	File_quasar_v1_quasar_proto = out.File
@@ -35,24 +35,96 @@ const (
 const (
 	// QuasarServiceSimulateProcedure is the fully-qualified name of the QuasarService's Simulate RPC.
 	QuasarServiceSimulateProcedure = "/quasar.v1.QuasarService/Simulate"
+	// QuasarServiceSimulateStreamProcedure is the fully-qualified name of the QuasarService's
+	// SimulateStream RPC.
+	QuasarServiceSimulateStreamProcedure = "/quasar.v1.QuasarService/SimulateStream"
+	// QuasarServiceStartSessionProcedure is the fully-qualified name of the QuasarService's
+	// StartSession RPC.
+	QuasarServiceStartSessionProcedure = "/quasar.v1.QuasarService/StartSession"
+	// QuasarServiceStepProcedure is the fully-qualified name of the QuasarService's Step RPC.
+	QuasarServiceStepProcedure = "/quasar.v1.QuasarService/Step"
+	// QuasarServiceContinueProcedure is the fully-qualified name of the QuasarService's Continue RPC.
+	QuasarServiceContinueProcedure = "/quasar.v1.QuasarService/Continue"
+	// QuasarServiceInspectProcedure is the fully-qualified name of the QuasarService's Inspect RPC.
+	QuasarServiceInspectProcedure = "/quasar.v1.QuasarService/Inspect"
+	// QuasarServiceEndProcedure is the fully-qualified name of the QuasarService's End RPC.
+	QuasarServiceEndProcedure = "/quasar.v1.QuasarService/End"
+	// QuasarServiceUnitaryProcedure is the fully-qualified name of the QuasarService's Unitary RPC.
+	QuasarServiceUnitaryProcedure = "/quasar.v1.QuasarService/Unitary"
+	// QuasarServiceEquivalentProcedure is the fully-qualified name of the QuasarService's Equivalent
+	// RPC.
+	QuasarServiceEquivalentProcedure = "/quasar.v1.QuasarService/Equivalent"
+	// QuasarServiceCompareProcedure is the fully-qualified name of the QuasarService's Compare RPC.
+	QuasarServiceCompareProcedure = "/quasar.v1.QuasarService/Compare"
+	// QuasarServiceDrawProcedure is the fully-qualified name of the QuasarService's Draw RPC.
+	QuasarServiceDrawProcedure = "/quasar.v1.QuasarService/Draw"
+	// QuasarServiceAnalyzeProcedure is the fully-qualified name of the QuasarService's Analyze RPC.
+	QuasarServiceAnalyzeProcedure = "/quasar.v1.QuasarService/Analyze"
+	// QuasarServiceOptimizeProcedure is the fully-qualified name of the QuasarService's Optimize RPC.
+	QuasarServiceOptimizeProcedure = "/quasar.v1.QuasarService/Optimize"
+	// QuasarServiceTranspileProcedure is the fully-qualified name of the QuasarService's Transpile RPC.
+	QuasarServiceTranspileProcedure = "/quasar.v1.QuasarService/Transpile"
 	// QuasarServiceShareProcedure is the fully-qualified name of the QuasarService's Share RPC.
 	QuasarServiceShareProcedure = "/quasar.v1.QuasarService/Share"
 	// QuasarServiceEditProcedure is the fully-qualified name of the QuasarService's Edit RPC.
 	QuasarServiceEditProcedure = "/quasar.v1.QuasarService/Edit"
 	// QuasarServiceValidateProcedure is the fully-qualified name of the QuasarService's Validate RPC.
 	QuasarServiceValidateProcedure = "/quasar.v1.QuasarService/Validate"
+	// QuasarServiceSubmitProcedure is the fully-qualified name of the QuasarService's Submit RPC.
+	QuasarServiceSubmitProcedure = "/quasar.v1.QuasarService/Submit"
+	// QuasarServiceGetJobProcedure is the fully-qualified name of the QuasarService's GetJob RPC.
+	QuasarServiceGetJobProcedure = "/quasar.v1.QuasarService/GetJob"
+	// QuasarServiceCancelJobProcedure is the fully-qualified name of the QuasarService's CancelJob RPC.
+	QuasarServiceCancelJobProcedure = "/quasar.v1.QuasarService/CancelJob"
+	// QuasarServiceListJobsProcedure is the fully-qualified name of the QuasarService's ListJobs RPC.
+	QuasarServiceListJobsProcedure = "/quasar.v1.QuasarService/ListJobs"
 )
 
 // QuasarServiceClient is a client for the quasar.v1.QuasarService service.
 type QuasarServiceClient interface {
 	// Simulate simulates the quantum circuit defined in the code and returns the resulting states.
 	Simulate(context.Context, *connect.Request[v1.SimulateRequest]) (*connect.Response[v1.SimulateResponse], error)
+	// SimulateStream simulates the quantum circuit and streams the state after each executed statement.
+	SimulateStream(context.Context, *connect.Request[v1.SimulateStreamRequest]) (*connect.ServerStreamForClient[v1.SimulateStreamResponse], error)
+	// StartSession starts a debug session and returns the state before the first statement.
+	StartSession(context.Context, *connect.Request[v1.StartSessionRequest]) (*connect.Response[v1.StartSessionResponse], error)
+	// Step executes the next statements of the debug session.
+	// Step and Continue fail with DEADLINE_EXCEEDED if they run too long, and the session stays at the last pause.
+	Step(context.Context, *connect.Request[v1.StepRequest]) (*connect.Response[v1.StepResponse], error)
+	// Continue executes the debug session until the breakpoint line.
+	Continue(context.Context, *connect.Request[v1.ContinueRequest]) (*connect.Response[v1.ContinueResponse], error)
+	// Inspect returns the current value of the register in the debug session.
+	Inspect(context.Context, *connect.Request[v1.InspectRequest]) (*connect.Response[v1.InspectResponse], error)
+	// End ends the debug session.
+	End(context.Context, *connect.Request[v1.EndRequest]) (*connect.Response[v1.EndResponse], error)
+	// Unitary returns the unitary matrix of the circuit or the user-defined gate.
+	Unitary(context.Context, *connect.Request[v1.UnitaryRequest]) (*connect.Response[v1.UnitaryResponse], error)
+	// Equivalent reports whether the two programs implement the same unitary up to global phase.
+	Equivalent(context.Context, *connect.Request[v1.EquivalentRequest]) (*connect.Response[v1.EquivalentResponse], error)
+	// Compare compares the final states of the two programs, or of the program and the target state vector.
+	Compare(context.Context, *connect.Request[v1.CompareRequest]) (*connect.Response[v1.CompareResponse], error)
+	// Draw renders the circuit diagram of the program.
+	Draw(context.Context, *connect.Request[v1.DrawRequest]) (*connect.Response[v1.DrawResponse], error)
+	// Analyze reports the number of qubits, the depth and the gate counts of the program.
+	Analyze(context.Context, *connect.Request[v1.AnalyzeRequest]) (*connect.Response[v1.AnalyzeResponse], error)
+	// Optimize cancels, merges and removes gates, and returns the optimized program.
+	Optimize(context.Context, *connect.Request[v1.OptimizeRequest]) (*connect.Response[v1.OptimizeResponse], error)
+	// Transpile decomposes every gate, including user-defined and multi-controlled gates, into the basis gates.
+	Transpile(context.Context, *connect.Request[v1.TranspileRequest]) (*connect.Response[v1.TranspileResponse], error)
 	// Share shares the quantum circuit defined in the code and returns the share ID and creation time.
 	Share(context.Context, *connect.Request[v1.ShareRequest]) (*connect.Response[v1.ShareResponse], error)
 	// Edit edits the quantum circuit identified by the given ID and returns the updated code and creation time.
 	Edit(context.Context, *connect.Request[v1.EditRequest]) (*connect.Response[v1.EditResponse], error)
 	// Validate validates the quantum circuit defined in the code and returns any errors found.
 	Validate(context.Context, *connect.Request[v1.ValidateRequest]) (*connect.Response[v1.ValidateResponse], error)
+	// Submit enqueues the simulation as an asynchronous job and returns the job ID.
+	Submit(context.Context, *connect.Request[v1.SubmitRequest]) (*connect.Response[v1.SubmitResponse], error)
+	// GetJob returns the status of the job and its result once it has succeeded.
+	GetJob(context.Context, *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.GetJobResponse], error)
+	// CancelJob cancels the queued or running job, and a running simulation stops at its next operation.
+	CancelJob(context.Context, *connect.Request[v1.CancelJobRequest]) (*connect.Response[v1.CancelJobResponse], error)
+	// ListJobs returns the most recently submitted jobs of the owner.
+	ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error)
 }
 
 // NewQuasarServiceClient constructs a client for the quasar.v1.QuasarService service. By default,
@@ -72,6 +144,84 @@ func NewQuasarServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(quasarServiceMethods.ByName("Simulate")),
 			connect.WithClientOptions(opts...),
 		),
+		simulateStream: connect.NewClient[v1.SimulateStreamRequest, v1.SimulateStreamResponse](
+			httpClient,
+			baseURL+QuasarServiceSimulateStreamProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("SimulateStream")),
+			connect.WithClientOptions(opts...),
+		),
+		startSession: connect.NewClient[v1.StartSessionRequest, v1.StartSessionResponse](
+			httpClient,
+			baseURL+QuasarServiceStartSessionProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("StartSession")),
+			connect.WithClientOptions(opts...),
+		),
+		step: connect.NewClient[v1.StepRequest, v1.StepResponse](
+			httpClient,
+			baseURL+QuasarServiceStepProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Step")),
+			connect.WithClientOptions(opts...),
+		),
+		_continue: connect.NewClient[v1.ContinueRequest, v1.ContinueResponse](
+			httpClient,
+			baseURL+QuasarServiceContinueProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Continue")),
+			connect.WithClientOptions(opts...),
+		),
+		inspect: connect.NewClient[v1.InspectRequest, v1.InspectResponse](
+			httpClient,
+			baseURL+QuasarServiceInspectProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Inspect")),
+			connect.WithClientOptions(opts...),
+		),
+		end: connect.NewClient[v1.EndRequest, v1.EndResponse](
+			httpClient,
+			baseURL+QuasarServiceEndProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("End")),
+			connect.WithClientOptions(opts...),
+		),
+		unitary: connect.NewClient[v1.UnitaryRequest, v1.UnitaryResponse](
+			httpClient,
+			baseURL+QuasarServiceUnitaryProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Unitary")),
+			connect.WithClientOptions(opts...),
+		),
+		equivalent: connect.NewClient[v1.EquivalentRequest, v1.EquivalentResponse](
+			httpClient,
+			baseURL+QuasarServiceEquivalentProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Equivalent")),
+			connect.WithClientOptions(opts...),
+		),
+		compare: connect.NewClient[v1.CompareRequest, v1.CompareResponse](
+			httpClient,
+			baseURL+QuasarServiceCompareProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Compare")),
+			connect.WithClientOptions(opts...),
+		),
+		draw: connect.NewClient[v1.DrawRequest, v1.DrawResponse](
+			httpClient,
+			baseURL+QuasarServiceDrawProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Draw")),
+			connect.WithClientOptions(opts...),
+		),
+		analyze: connect.NewClient[v1.AnalyzeRequest, v1.AnalyzeResponse](
+			httpClient,
+			baseURL+QuasarServiceAnalyzeProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Analyze")),
+			connect.WithClientOptions(opts...),
+		),
+		optimize: connect.NewClient[v1.OptimizeRequest, v1.OptimizeResponse](
+			httpClient,
+			baseURL+QuasarServiceOptimizeProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Optimize")),
+			connect.WithClientOptions(opts...),
+		),
+		transpile: connect.NewClient[v1.TranspileRequest, v1.TranspileResponse](
+			httpClient,
+			baseURL+QuasarServiceTranspileProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Transpile")),
+			connect.WithClientOptions(opts...),
+		),
 		share: connect.NewClient[v1.ShareRequest, v1.ShareResponse](
 			httpClient,
 			baseURL+QuasarServiceShareProcedure,
@@ -90,15 +240,56 @@ func NewQuasarServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(quasarServiceMethods.ByName("Validate")),
 			connect.WithClientOptions(opts...),
 		),
+		submit: connect.NewClient[v1.SubmitRequest, v1.SubmitResponse](
+			httpClient,
+			baseURL+QuasarServiceSubmitProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("Submit")),
+			connect.WithClientOptions(opts...),
+		),
+		getJob: connect.NewClient[v1.GetJobRequest, v1.GetJobResponse](
+			httpClient,
+			baseURL+QuasarServiceGetJobProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("GetJob")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelJob: connect.NewClient[v1.CancelJobRequest, v1.CancelJobResponse](
+			httpClient,
+			baseURL+QuasarServiceCancelJobProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("CancelJob")),
+			connect.WithClientOptions(opts...),
+		),
+		listJobs: connect.NewClient[v1.ListJobsRequest, v1.ListJobsResponse](
+			httpClient,
+			baseURL+QuasarServiceListJobsProcedure,
+			connect.WithSchema(quasarServiceMethods.ByName("ListJobs")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // quasarServiceClient implements QuasarServiceClient.
 type quasarServiceClient struct {
-	simulate *connect.Client[v1.SimulateRequest, v1.SimulateResponse]
-	share    *connect.Client[v1.ShareRequest, v1.ShareResponse]
-	edit     *connect.Client[v1.EditRequest, v1.EditResponse]
-	validate *connect.Client[v1.ValidateRequest, v1.ValidateResponse]
+	simulate       *connect.Client[v1.SimulateRequest, v1.SimulateResponse]
+	simulateStream *connect.Client[v1.SimulateStreamRequest, v1.SimulateStreamResponse]
+	startSession   *connect.Client[v1.StartSessionRequest, v1.StartSessionResponse]
+	step           *connect.Client[v1.StepRequest, v1.StepResponse]
+	_continue      *connect.Client[v1.ContinueRequest, v1.ContinueResponse]
+	inspect        *connect.Client[v1.InspectRequest, v1.InspectResponse]
+	end            *connect.Client[v1.EndRequest, v1.EndResponse]
+	unitary        *connect.Client[v1.UnitaryRequest, v1.UnitaryResponse]
+	equivalent     *connect.Client[v1.EquivalentRequest, v1.EquivalentResponse]
+	compare        *connect.Client[v1.CompareRequest, v1.CompareResponse]
+	draw           *connect.Client[v1.DrawRequest, v1.DrawResponse]
+	analyze        *connect.Client[v1.AnalyzeRequest, v1.AnalyzeResponse]
+	optimize       *connect.Client[v1.OptimizeRequest, v1.OptimizeResponse]
+	transpile      *connect.Client[v1.TranspileRequest, v1.TranspileResponse]
+	share          *connect.Client[v1.ShareRequest, v1.ShareResponse]
+	edit           *connect.Client[v1.EditRequest, v1.EditResponse]
+	validate       *connect.Client[v1.ValidateRequest, v1.ValidateResponse]
+	submit         *connect.Client[v1.SubmitRequest, v1.SubmitResponse]
+	getJob         *connect.Client[v1.GetJobRequest, v1.GetJobResponse]
+	cancelJob      *connect.Client[v1.CancelJobRequest, v1.CancelJobResponse]
+	listJobs       *connect.Client[v1.ListJobsRequest, v1.ListJobsResponse]
 }
 
 // Simulate calls quasar.v1.QuasarService.Simulate.
@@ -106,6 +297,71 @@ func (c *quasarServiceClient) Simulate(ctx context.Context, req *connect.Request
 	return c.simulate.CallUnary(ctx, req)
 }
 
+// SimulateStream calls quasar.v1.QuasarService.SimulateStream.
+func (c *quasarServiceClient) SimulateStream(ctx context.Context, req *connect.Request[v1.SimulateStreamRequest]) (*connect.ServerStreamForClient[v1.SimulateStreamResponse], error) {
+	return c.simulateStream.CallServerStream(ctx, req)
+}
+
+// StartSession calls quasar.v1.QuasarService.StartSession.
+func (c *quasarServiceClient) StartSession(ctx context.Context, req *connect.Request[v1.StartSessionRequest]) (*connect.Response[v1.StartSessionResponse], error) {
+	return c.startSession.CallUnary(ctx, req)
+}
+
+// Step calls quasar.v1.QuasarService.Step.
+func (c *quasarServiceClient) Step(ctx context.Context, req *connect.Request[v1.StepRequest]) (*connect.Response[v1.StepResponse], error) {
+	return c.step.CallUnary(ctx, req)
+}
+
+// Continue calls quasar.v1.QuasarService.Continue.
+func (c *quasarServiceClient) Continue(ctx context.Context, req *connect.Request[v1.ContinueRequest]) (*connect.Response[v1.ContinueResponse], error) {
+	return c._continue.CallUnary(ctx, req)
+}
+
+// Inspect calls quasar.v1.QuasarService.Inspect.
+func (c *quasarServiceClient) Inspect(ctx context.Context, req *connect.Request[v1.InspectRequest]) (*connect.Response[v1.InspectResponse], error) {
+	return c.inspect.CallUnary(ctx, req)
+}
+
+// End calls quasar.v1.QuasarService.End.
+func (c *quasarServiceClient) End(ctx context.Context, req *connect.Request[v1.EndRequest]) (*connect.Response[v1.EndResponse], error) {
+	return c.end.CallUnary(ctx, req)
+}
+
+// Unitary calls quasar.v1.QuasarService.Unitary.
+func (c *quasarServiceClient) Unitary(ctx context.Context, req *connect.Request[v1.UnitaryRequest]) (*connect.Response[v1.UnitaryResponse], error) {
+	return c.unitary.CallUnary(ctx, req)
+}
+
+// Equivalent calls quasar.v1.QuasarService.Equivalent.
+func (c *quasarServiceClient) Equivalent(ctx context.Context, req *connect.Request[v1.EquivalentRequest]) (*connect.Response[v1.EquivalentResponse], error) {
+	return c.equivalent.CallUnary(ctx, req)
+}
+
+// Compare calls quasar.v1.QuasarService.Compare.
+func (c *quasarServiceClient) Compare(ctx context.Context, req *connect.Request[v1.CompareRequest]) (*connect.Response[v1.CompareResponse], error) {
+	return c.compare.CallUnary(ctx, req)
+}
+
+// Draw calls quasar.v1.QuasarService.Draw.
+func (c *quasarServiceClient) Draw(ctx context.Context, req *connect.Request[v1.DrawRequest]) (*connect.Response[v1.DrawResponse], error) {
+	return c.draw.CallUnary(ctx, req)
+}
+
+// Analyze calls quasar.v1.QuasarService.Analyze.
+func (c *quasarServiceClient) Analyze(ctx context.Context, req *connect.Request[v1.AnalyzeRequest]) (*connect.Response[v1.AnalyzeResponse], error) {
+	return c.analyze.CallUnary(ctx, req)
+}
+
+// Optimize calls quasar.v1.QuasarService.Optimize.
+func (c *quasarServiceClient) Optimize(ctx context.Context, req *connect.Request[v1.OptimizeRequest]) (*connect.Response[v1.OptimizeResponse], error) {
+	return c.optimize.CallUnary(ctx, req)
+}
+
+// Transpile calls quasar.v1.QuasarService.Transpile.
+func (c *quasarServiceClient) Transpile(ctx context.Context, req *connect.Request[v1.TranspileRequest]) (*connect.Response[v1.TranspileResponse], error) {
+	return c.transpile.CallUnary(ctx, req)
+}
+
 // Share calls quasar.v1.QuasarService.Share.
 func (c *quasarServiceClient) Share(ctx context.Context, req *connect.Request[v1.ShareRequest]) (*connect.Response[v1.ShareResponse], error) {
 	return c.share.CallUnary(ctx, req)
@@ -121,16 +377,71 @@ func (c *quasarServiceClient) Validate(ctx context.Context, req *connect.Request
 	return c.validate.CallUnary(ctx, req)
 }
 
+// Submit calls quasar.v1.QuasarService.Submit.
+func (c *quasarServiceClient) Submit(ctx context.Context, req *connect.Request[v1.SubmitRequest]) (*connect.Response[v1.SubmitResponse], error) {
+	return c.submit.CallUnary(ctx, req)
+}
+
+// GetJob calls quasar.v1.QuasarService.GetJob.
+func (c *quasarServiceClient) GetJob(ctx context.Context, req *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.GetJobResponse], error) {
+	return c.getJob.CallUnary(ctx, req)
+}
+
+// CancelJob calls quasar.v1.QuasarService.CancelJob.
+func (c *quasarServiceClient) CancelJob(ctx context.Context, req *connect.Request[v1.CancelJobRequest]) (*connect.Response[v1.CancelJobResponse], error) {
+	return c.cancelJob.CallUnary(ctx, req)
+}
+
+// ListJobs calls quasar.v1.QuasarService.ListJobs.
+func (c *quasarServiceClient) ListJobs(ctx context.Context, req *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error) {
+	return c.listJobs.CallUnary(ctx, req)
+}
+
 // QuasarServiceHandler is an implementation of the quasar.v1.QuasarService service.
 type QuasarServiceHandler interface {
 	// Simulate simulates the quantum circuit defined in the code and returns the resulting states.
 	Simulate(context.Context, *connect.Request[v1.SimulateRequest]) (*connect.Response[v1.SimulateResponse], error)
+	// SimulateStream simulates the quantum circuit and streams the state after each executed statement.
+	SimulateStream(context.Context, *connect.Request[v1.SimulateStreamRequest], *connect.ServerStream[v1.SimulateStreamResponse]) error
+	// StartSession starts a debug session and returns the state before the first statement.
+	StartSession(context.Context, *connect.Request[v1.StartSessionRequest]) (*connect.Response[v1.StartSessionResponse], error)
+	// Step executes the next statements of the debug session.
+	// Step and Continue fail with DEADLINE_EXCEEDED if they run too long, and the session stays at the last pause.
+	Step(context.Context, *connect.Request[v1.StepRequest]) (*connect.Response[v1.StepResponse], error)
+	// Continue executes the debug session until the breakpoint line.
+	Continue(context.Context, *connect.Request[v1.ContinueRequest]) (*connect.Response[v1.ContinueResponse], error)
+	// Inspect returns the current value of the register in the debug session.
+	Inspect(context.Context, *connect.Request[v1.InspectRequest]) (*connect.Response[v1.InspectResponse], error)
+	// End ends the debug session.
+	End(context.Context, *connect.Request[v1.EndRequest]) (*connect.Response[v1.EndResponse], error)
+	// Unitary returns the unitary matrix of the circuit or the user-defined gate.
+	Unitary(context.Context, *connect.Request[v1.UnitaryRequest]) (*connect.Response[v1.UnitaryResponse], error)
+	// Equivalent reports whether the two programs implement the same unitary up to global phase.
+	Equivalent(context.Context, *connect.Request[v1.EquivalentRequest]) (*connect.Response[v1.EquivalentResponse], error)
+	// Compare compares the final states of the two programs, or of the program and the target state vector.
+	Compare(context.Context, *connect.Request[v1.CompareRequest]) (*connect.Response[v1.CompareResponse], error)
+	// Draw renders the circuit diagram of the program.
+	Draw(context.Context, *connect.Request[v1.DrawRequest]) (*connect.Response[v1.DrawResponse], error)
+	// Analyze reports the number of qubits, the depth and the gate counts of the program.
+	Analyze(context.Context, *connect.Request[v1.AnalyzeRequest]) (*connect.Response[v1.AnalyzeResponse], error)
+	// Optimize cancels, merges and removes gates, and returns the optimized program.
+	Optimize(context.Context, *connect.Request[v1.OptimizeRequest]) (*connect.Response[v1.OptimizeResponse], error)
+	// Transpile decomposes every gate, including user-defined and multi-controlled gates, into the basis gates.
+	Transpile(context.Context, *connect.Request[v1.TranspileRequest]) (*connect.Response[v1.TranspileResponse], error)
 	// Share shares the quantum circuit defined in the code and returns the share ID and creation time.
 	Share(context.Context, *connect.Request[v1.ShareRequest]) (*connect.Response[v1.ShareResponse], error)
 	// Edit edits the quantum circuit identified by the given ID and returns the updated code and creation time.
 	Edit(context.Context, *connect.Request[v1.EditRequest]) (*connect.Response[v1.EditResponse], error)
 	// Validate validates the quantum circuit defined in the code and returns any errors found.
 	Validate(context.Context, *connect.Request[v1.ValidateRequest]) (*connect.Response[v1.ValidateResponse], error)
+	// Submit enqueues the simulation as an asynchronous job and returns the job ID.
+	Submit(context.Context, *connect.Request[v1.SubmitRequest]) (*connect.Response[v1.SubmitResponse], error)
+	// GetJob returns the status of the job and its result once it has succeeded.
+	GetJob(context.Context, *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.GetJobResponse], error)
+	// CancelJob cancels the queued or running job, and a running simulation stops at its next operation.
+	CancelJob(context.Context, *connect.Request[v1.CancelJobRequest]) (*connect.Response[v1.CancelJobResponse], error)
+	// ListJobs returns the most recently submitted jobs of the owner.
+	ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error)
 }
 
 // NewQuasarServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -146,6 +457,84 @@ func NewQuasarServiceHandler(svc QuasarServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(quasarServiceMethods.ByName("Simulate")),
 		connect.WithHandlerOptions(opts...),
 	)
+	quasarServiceSimulateStreamHandler := connect.NewServerStreamHandler(
+		QuasarServiceSimulateStreamProcedure,
+		svc.SimulateStream,
+		connect.WithSchema(quasarServiceMethods.ByName("SimulateStream")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceStartSessionHandler := connect.NewUnaryHandler(
+		QuasarServiceStartSessionProcedure,
+		svc.StartSession,
+		connect.WithSchema(quasarServiceMethods.ByName("StartSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceStepHandler := connect.NewUnaryHandler(
+		QuasarServiceStepProcedure,
+		svc.Step,
+		connect.WithSchema(quasarServiceMethods.ByName("Step")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceContinueHandler := connect.NewUnaryHandler(
+		QuasarServiceContinueProcedure,
+		svc.Continue,
+		connect.WithSchema(quasarServiceMethods.ByName("Continue")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceInspectHandler := connect.NewUnaryHandler(
+		QuasarServiceInspectProcedure,
+		svc.Inspect,
+		connect.WithSchema(quasarServiceMethods.ByName("Inspect")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceEndHandler := connect.NewUnaryHandler(
+		QuasarServiceEndProcedure,
+		svc.End,
+		connect.WithSchema(quasarServiceMethods.ByName("End")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceUnitaryHandler := connect.NewUnaryHandler(
+		QuasarServiceUnitaryProcedure,
+		svc.Unitary,
+		connect.WithSchema(quasarServiceMethods.ByName("Unitary")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceEquivalentHandler := connect.NewUnaryHandler(
+		QuasarServiceEquivalentProcedure,
+		svc.Equivalent,
+		connect.WithSchema(quasarServiceMethods.ByName("Equivalent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceCompareHandler := connect.NewUnaryHandler(
+		QuasarServiceCompareProcedure,
+		svc.Compare,
+		connect.WithSchema(quasarServiceMethods.ByName("Compare")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceDrawHandler := connect.NewUnaryHandler(
+		QuasarServiceDrawProcedure,
+		svc.Draw,
+		connect.WithSchema(quasarServiceMethods.ByName("Draw")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceAnalyzeHandler := connect.NewUnaryHandler(
+		QuasarServiceAnalyzeProcedure,
+		svc.Analyze,
+		connect.WithSchema(quasarServiceMethods.ByName("Analyze")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceOptimizeHandler := connect.NewUnaryHandler(
+		QuasarServiceOptimizeProcedure,
+		svc.Optimize,
+		connect.WithSchema(quasarServiceMethods.ByName("Optimize")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceTranspileHandler := connect.NewUnaryHandler(
+		QuasarServiceTranspileProcedure,
+		svc.Transpile,
+		connect.WithSchema(quasarServiceMethods.ByName("Transpile")),
+		connect.WithHandlerOptions(opts...),
+	)
 	quasarServiceShareHandler := connect.NewUnaryHandler(
 		QuasarServiceShareProcedure,
 		svc.Share,
@@ -164,16 +553,74 @@ func NewQuasarServiceHandler(svc QuasarServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(quasarServiceMethods.ByName("Validate")),
 		connect.WithHandlerOptions(opts...),
 	)
+	quasarServiceSubmitHandler := connect.NewUnaryHandler(
+		QuasarServiceSubmitProcedure,
+		svc.Submit,
+		connect.WithSchema(quasarServiceMethods.ByName("Submit")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceGetJobHandler := connect.NewUnaryHandler(
+		QuasarServiceGetJobProcedure,
+		svc.GetJob,
+		connect.WithSchema(quasarServiceMethods.ByName("GetJob")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceCancelJobHandler := connect.NewUnaryHandler(
+		QuasarServiceCancelJobProcedure,
+		svc.CancelJob,
+		connect.WithSchema(quasarServiceMethods.ByName("CancelJob")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quasarServiceListJobsHandler := connect.NewUnaryHandler(
+		QuasarServiceListJobsProcedure,
+		svc.ListJobs,
+		connect.WithSchema(quasarServiceMethods.ByName("ListJobs")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/quasar.v1.QuasarService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case QuasarServiceSimulateProcedure:
 			quasarServiceSimulateHandler.ServeHTTP(w, r)
+		case QuasarServiceSimulateStreamProcedure:
+			quasarServiceSimulateStreamHandler.ServeHTTP(w, r)
+		case QuasarServiceStartSessionProcedure:
+			quasarServiceStartSessionHandler.ServeHTTP(w, r)
+		case QuasarServiceStepProcedure:
+			quasarServiceStepHandler.ServeHTTP(w, r)
+		case QuasarServiceContinueProcedure:
+			quasarServiceContinueHandler.ServeHTTP(w, r)
+		case QuasarServiceInspectProcedure:
+			quasarServiceInspectHandler.ServeHTTP(w, r)
+		case QuasarServiceEndProcedure:
+			quasarServiceEndHandler.ServeHTTP(w, r)
+		case QuasarServiceUnitaryProcedure:
+			quasarServiceUnitaryHandler.ServeHTTP(w, r)
+		case QuasarServiceEquivalentProcedure:
+			quasarServiceEquivalentHandler.ServeHTTP(w, r)
+		case QuasarServiceCompareProcedure:
+			quasarServiceCompareHandler.ServeHTTP(w, r)
+		case QuasarServiceDrawProcedure:
+			quasarServiceDrawHandler.ServeHTTP(w, r)
+		case QuasarServiceAnalyzeProcedure:
+			quasarServiceAnalyzeHandler.ServeHTTP(w, r)
+		case QuasarServiceOptimizeProcedure:
+			quasarServiceOptimizeHandler.ServeHTTP(w, r)
+		case QuasarServiceTranspileProcedure:
+			quasarServiceTranspileHandler.ServeHTTP(w, r)
 		case QuasarServiceShareProcedure:
 			quasarServiceShareHandler.ServeHTTP(w, r)
 		case QuasarServiceEditProcedure:
 			quasarServiceEditHandler.ServeHTTP(w, r)
 		case QuasarServiceValidateProcedure:
 			quasarServiceValidateHandler.ServeHTTP(w, r)
+		case QuasarServiceSubmitProcedure:
+			quasarServiceSubmitHandler.ServeHTTP(w, r)
+		case QuasarServiceGetJobProcedure:
+			quasarServiceGetJobHandler.ServeHTTP(w, r)
+		case QuasarServiceCancelJobProcedure:
+			quasarServiceCancelJobHandler.ServeHTTP(w, r)
+		case QuasarServiceListJobsProcedure:
+			quasarServiceListJobsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -187,6 +634,58 @@ func (UnimplementedQuasarServiceHandler) Simulate(context.Context, *connect.Requ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Simulate is not implemented"))
 }
 
+func (UnimplementedQuasarServiceHandler) SimulateStream(context.Context, *connect.Request[v1.SimulateStreamRequest], *connect.ServerStream[v1.SimulateStreamResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.SimulateStream is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) StartSession(context.Context, *connect.Request[v1.StartSessionRequest]) (*connect.Response[v1.StartSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.StartSession is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Step(context.Context, *connect.Request[v1.StepRequest]) (*connect.Response[v1.StepResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Step is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Continue(context.Context, *connect.Request[v1.ContinueRequest]) (*connect.Response[v1.ContinueResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Continue is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Inspect(context.Context, *connect.Request[v1.InspectRequest]) (*connect.Response[v1.InspectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Inspect is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) End(context.Context, *connect.Request[v1.EndRequest]) (*connect.Response[v1.EndResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.End is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Unitary(context.Context, *connect.Request[v1.UnitaryRequest]) (*connect.Response[v1.UnitaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Unitary is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Equivalent(context.Context, *connect.Request[v1.EquivalentRequest]) (*connect.Response[v1.EquivalentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Equivalent is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Compare(context.Context, *connect.Request[v1.CompareRequest]) (*connect.Response[v1.CompareResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Compare is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Draw(context.Context, *connect.Request[v1.DrawRequest]) (*connect.Response[v1.DrawResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Draw is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Analyze(context.Context, *connect.Request[v1.AnalyzeRequest]) (*connect.Response[v1.AnalyzeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Analyze is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Optimize(context.Context, *connect.Request[v1.OptimizeRequest]) (*connect.Response[v1.OptimizeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Optimize is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) Transpile(context.Context, *connect.Request[v1.TranspileRequest]) (*connect.Response[v1.TranspileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Transpile is not implemented"))
+}
+
 func (UnimplementedQuasarServiceHandler) Share(context.Context, *connect.Request[v1.ShareRequest]) (*connect.Response[v1.ShareResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Share is not implemented"))
 }
@@ -198,3 +697,19 @@ func (UnimplementedQuasarServiceHandler) Edit(context.Context, *connect.Request[
 func (UnimplementedQuasarServiceHandler) Validate(context.Context, *connect.Request[v1.ValidateRequest]) (*connect.Response[v1.ValidateResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Validate is not implemented"))
 }
+
+func (UnimplementedQuasarServiceHandler) Submit(context.Context, *connect.Request[v1.SubmitRequest]) (*connect.Response[v1.SubmitResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.Submit is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) GetJob(context.Context, *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.GetJobResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.GetJob is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) CancelJob(context.Context, *connect.Request[v1.CancelJobRequest]) (*connect.Response[v1.CancelJobResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.CancelJob is not implemented"))
+}
+
+func (UnimplementedQuasarServiceHandler) ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("quasar.v1.QuasarService.ListJobs is not implemented"))
+}
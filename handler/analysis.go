@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itsubaki/quasar/density"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/packed"
+)
+
+const maxSubsystem = 6
+
+var ErrAnalysisNotSupported = errors.New("analysis is only supported by the state vector and density matrix backends")
+
+// Analyze returns the Bloch vector of each qubit, and the reduced density matrix and the entanglement entropy of the subsystem.
+// reduced returns the reduced density matrix of the qubits, and index maps the declared qubits to them.
+func Analyze(
+	reduced func(keep []int) ([]complex128, error),
+	index []int,
+	subsystem []int32,
+	round func(float64) float64,
+) (*quasarv1.SimulateResponse_Analysis, error) {
+	if len(subsystem) > maxSubsystem {
+		return nil, fmt.Errorf("subsystem size exceeds %d: %w", maxSubsystem, density.ErrInvalidSubsystem)
+	}
+
+	bloch := make([]*quasarv1.SimulateResponse_Analysis_Bloch, len(index))
+	for i, q := range index {
+		rho, err := reduced([]int{q})
+		if err != nil {
+			return nil, err
+		}
+
+		x, y, z := density.Bloch(rho)
+		bloch[i] = &quasarv1.SimulateResponse_Analysis_Bloch{
+			X: round(x),
+			Y: round(y),
+			Z: round(z),
+		}
+	}
+
+	out := &quasarv1.SimulateResponse_Analysis{
+		Bloch: bloch,
+	}
+
+	if len(subsystem) == 0 {
+		return out, nil
+	}
+
+	keep := make([]int, len(subsystem))
+	for i, q := range subsystem {
+		if q < 0 || int(q) >= len(index) {
+			return nil, fmt.Errorf("qubit=%d: %w", q, density.ErrInvalidSubsystem)
+		}
+
+		keep[i] = index[q]
+	}
+
+	rho, err := reduced(keep)
+	if err != nil {
+		return nil, err
+	}
+
+	out.ReducedDensityMatrix = packed.Encode(rho)
+	out.Entropy = new(round(density.Entropy(rho)))
+	return out, nil
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/density"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleQuasarService_Simulate_analysis() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+qubit r;
+h q[0];
+cx q[0], q[1];
+U(pi, 0, pi) r;
+`
+
+	resp, err := (&handler.QuasarService{}).Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code: code,
+		Analysis: &quasarv1.SimulateRequest_Analysis{
+			Subsystem: []int32{0},
+		},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, b := range resp.Msg.Analysis.Bloch {
+		fmt.Println(b.X, b.Y, b.Z)
+	}
+
+	fmt.Println(resp.Msg.Analysis.GetEntropy())
+
+	// Output:
+	// 0 0 0
+	// 0 0 0
+	// 0 0 -1
+	// 1
+}
+
+func TestAnalyze(t *testing.T) {
+	// |0> on qubit 1, and (|00> + |11>)/sqrt(2) on qubits 0 and 2
+	amp := make([]complex128, 8)
+	amp[0b000], amp[0b101] = complex(1/math.Sqrt2, 0), complex(1/math.Sqrt2, 0)
+	reduced := func(keep []int) ([]complex128, error) {
+		return density.Reduced(amp, keep)
+	}
+
+	round := func(v float64) float64 {
+		return math.Round(v*1e6) / 1e6
+	}
+
+	cases := []struct {
+		index     []int
+		subsystem []int32
+		z         []float64
+		entropy   *float64
+		err       error
+	}{
+		{[]int{0, 1, 2}, nil, []float64{0, 1, 0}, nil, nil},
+		{[]int{2, 0, 1}, []int32{0}, []float64{0, 0, 1}, new(1.0), nil},
+		{[]int{0, 1, 2}, []int32{0, 2}, []float64{0, 1, 0}, new(0.0), nil},
+		{[]int{0, 1, 2}, []int32{1}, []float64{0, 1, 0}, new(0.0), nil},
+		{[]int{0, 1, 2}, []int32{3}, nil, nil, density.ErrInvalidSubsystem},
+		{[]int{0, 1, 2}, []int32{0, 0}, nil, nil, density.ErrInvalidSubsystem},
+		{[]int{0, 1, 2}, []int32{0, 1, 2, 0, 1, 2, 0}, nil, nil, density.ErrInvalidSubsystem},
+	}
+
+	for _, c := range cases {
+		got, err := handler.Analyze(reduced, c.index, c.subsystem, round)
+		if !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		for i, b := range got.Bloch {
+			if b.X != 0 || b.Y != 0 || b.Z != c.z[i] {
+				t.Errorf("got=%v, want=%v", b, c.z[i])
+			}
+		}
+
+		if (got.Entropy == nil) != (c.entropy == nil) || (c.entropy != nil && got.GetEntropy() != *c.entropy) {
+			t.Errorf("got=%v, want=%v", got.Entropy, c.entropy)
+		}
+
+		if (len(got.ReducedDensityMatrix) == 0) != (len(c.subsystem) == 0) {
+			t.Errorf("got=%v", got.ReducedDensityMatrix)
+		}
+	}
+}
+
+func TestQuasarService_Simulate_analysis(t *testing.T) {
+	cases := []struct {
+		backend quasarv1.Backend
+		noise   *quasarv1.NoiseModel
+		z       float64
+		err     bool
+	}{
+		{quasarv1.Backend_BACKEND_STATEVECTOR, nil, -1, false},
+		{quasarv1.Backend_BACKEND_DENSITY_MATRIX, nil, -1, false},
+		{quasarv1.Backend_BACKEND_DENSITY_MATRIX, &quasarv1.NoiseModel{BitFlip: 0.25}, -0.5, false},
+		{quasarv1.Backend_BACKEND_STABILIZER, nil, 0, true},
+		{quasarv1.Backend_BACKEND_MPS, nil, 0, true},
+	}
+
+	for _, c := range cases {
+		resp, err := (&handler.QuasarService{}).Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:     "qubit q;\nU(pi, 0, pi) q;",
+			Backend:  c.backend,
+			Noise:    c.noise,
+			Analysis: &quasarv1.SimulateRequest_Analysis{},
+		}))
+		if c.err {
+			if connect.CodeOf(err) != connect.CodeInvalidArgument {
+				t.Errorf("got=%v", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("simulate: %v", err)
+		}
+
+		if got := resp.Msg.Analysis.Bloch[0].Z; got != c.z {
+			t.Errorf("got=%v, want=%v", got, c.z)
+		}
+	}
+}
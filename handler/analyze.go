@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/metrics"
+)
+
+const (
+	maxAnalyzeQubits = 1024
+	maxAnalyzeOps    = 100000
+)
+
+// Analyze reports the metrics of the program without simulating it.
+// The number of qubits is not limited by MaxQubits, so the metrics can be used for admission.
+// Both branches on measured bits are counted, and the body of a while loop on measured bits is counted once.
+func (s *QuasarService) Analyze(
+	ctx context.Context,
+	req *connect.Request[quasarv1.AnalyzeRequest],
+) (*connect.Response[quasarv1.AnalyzeResponse], error) {
+	if req.Msg.Program == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	c, err := s.compile(ctx, req.Msg.Program, nil,
+		compiler.WithMaxQubits(maxAnalyzeQubits),
+		compiler.WithMaxOps(maxAnalyzeOps),
+		compiler.WithBranches(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(Metrics(c)), nil
+}
+
+// Metrics returns the metrics of the circuit.
+func Metrics(c *circuit.Circuit) *quasarv1.AnalyzeResponse {
+	m := metrics.Analyze(c)
+	gates := make(map[string]int32, len(m.Gates))
+	for k, v := range m.Gates {
+		gates[k] = int32(v)
+	}
+
+	return &quasarv1.AnalyzeResponse{
+		Qubits:        int32(m.Qubits),
+		Depth:         int32(m.Depth),
+		Gates:         gates,
+		TwoQubitGates: int32(m.TwoQubitGates),
+		TCount:        int32(m.TCount),
+		Clifford:      m.Clifford,
+	}
+}
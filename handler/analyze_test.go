@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleQuasarService_Analyze() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[3] q;
+bit[3] c;
+h q[0];
+cx q[0], q[1];
+cx q[1], q[2];
+c = measure q;
+`
+
+	resp, err := (&handler.QuasarService{}).Analyze(context.Background(), connect.NewRequest(&quasarv1.AnalyzeRequest{
+		Program: &quasarv1.Program{Code: code},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.Qubits, resp.Msg.Depth, resp.Msg.TwoQubitGates, resp.Msg.TCount, resp.Msg.Clifford)
+	fmt.Println(resp.Msg.Gates)
+
+	// Output:
+	// 3 4 2 0 true
+	// map[cx:2 h:1 measure:3]
+}
+
+func TestQuasarService_Analyze(t *testing.T) {
+	cases := []struct {
+		code     string
+		qubits   int32
+		depth    int32
+		tcount   int32
+		clifford bool
+	}{
+		{"OPENQASM 3.0;", 0, 0, 0, true},
+		{"qubit[100] q;", 100, 0, 0, true},
+		{"qubit[2] q; U(0, 0, pi/4) q; inv @ U(0, 0, pi/4) q[0];", 2, 2, 3, false},
+		{"gate t q { U(0, 0, pi/4) q; } qubit q; pow(2) @ t q;", 1, 2, 2, false},
+		{"qubit[2] q; ctrl @ U(0, 0, pi/4) q[0], q[1];", 2, 1, 0, false},
+		{"qubit q; bit c = measure q; if (c == 1) { U(0, 0, pi/4) q; } else { U(pi, 0, pi) q; }", 1, 3, 1, false},
+		{"qubit q; bit c = measure q; while (c) { reset q; c = measure q; }", 1, 3, 0, true},
+	}
+
+	for _, c := range cases {
+		resp, err := (&handler.QuasarService{}).Analyze(context.Background(), connect.NewRequest(&quasarv1.AnalyzeRequest{
+			Program: &quasarv1.Program{Code: c.code},
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", c.code, err)
+		}
+
+		if resp.Msg.Qubits != c.qubits || resp.Msg.Depth != c.depth || resp.Msg.TCount != c.tcount || resp.Msg.Clifford != c.clifford {
+			t.Errorf("%s: got=%v", c.code, resp.Msg)
+		}
+	}
+}
+
+func TestQuasarService_Analyze_error(t *testing.T) {
+	cases := []*quasarv1.AnalyzeRequest{
+		{},
+		{Program: &quasarv1.Program{}},
+		{Program: &quasarv1.Program{Code: "qubit q; foo q;"}},
+		{Program: &quasarv1.Program{Code: "qubit[1025] q;"}},
+		{Program: &quasarv1.Program{Code: "qubit q; for int i in [0:100000] { U(0, 0, 0) q; }"}},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{}).Analyze(context.Background(), connect.NewRequest(c)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
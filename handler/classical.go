@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"github.com/itsubaki/qasm/environ"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+)
+
+// Classical returns the classical registers of the circuit and the output variables of the program.
+// If no output variable is declared, all variables are treated as outputs.
+func Classical(c *circuit.Circuit, env *compiler.Env, decl *Declarations) map[string]*quasarv1.SimulateResponse_Classical {
+	out := make(map[string]*quasarv1.SimulateResponse_Classical)
+	for _, reg := range c.CRegs {
+		values := make([]int32, len(reg.Index))
+		for i, j := range reg.Index {
+			values[i] = int32(env.Bits[j])
+		}
+
+		out[reg.Name] = &quasarv1.SimulateResponse_Classical{
+			Value: &quasarv1.SimulateResponse_Classical_Bits{
+				Bits: &quasarv1.SimulateResponse_Bits{
+					Values: values,
+				},
+			},
+		}
+	}
+
+	variables(out, env.Variables, decl)
+	return out
+}
+
+// Visited returns the classical registers and the output variables of the environment of the visitor.
+// If no output variable is declared, all variables are treated as outputs.
+func Visited(env *environ.Environ, decl *Declarations) map[string]*quasarv1.SimulateResponse_Classical {
+	out := make(map[string]*quasarv1.SimulateResponse_Classical)
+	for name, bits := range env.ClassicalBit {
+		values := make([]int32, len(bits))
+		for i, b := range bits {
+			values[i] = int32(b)
+		}
+
+		out[name] = &quasarv1.SimulateResponse_Classical{
+			Value: &quasarv1.SimulateResponse_Classical_Bits{
+				Bits: &quasarv1.SimulateResponse_Bits{
+					Values: values,
+				},
+			},
+		}
+	}
+
+	variables(out, env.Variable, decl)
+	return out
+}
+
+// variables adds the output variables to out.
+func variables(out map[string]*quasarv1.SimulateResponse_Classical, vars map[string]any, decl *Declarations) {
+	for name, v := range vars {
+		if len(decl.Output) > 0 && !decl.Output[name] {
+			continue
+		}
+
+		switch val := v.(type) {
+		case int64:
+			out[name] = &quasarv1.SimulateResponse_Classical{
+				Value: &quasarv1.SimulateResponse_Classical_Int{Int: val},
+			}
+		case float64:
+			if decl.Angle[name] {
+				out[name] = &quasarv1.SimulateResponse_Classical{
+					Value: &quasarv1.SimulateResponse_Classical_Angle{Angle: val},
+				}
+
+				continue
+			}
+
+			out[name] = &quasarv1.SimulateResponse_Classical{
+				Value: &quasarv1.SimulateResponse_Classical_Float{Float: val},
+			}
+		case bool:
+			out[name] = &quasarv1.SimulateResponse_Classical{
+				Value: &quasarv1.SimulateResponse_Classical_Bool{Bool: val},
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleClassical() {
+	code := `
+	OPENQASM 3.0;
+
+gate x q { U(pi, 0, pi) q; }
+
+qubit[2] q;
+bit[2] c;
+angle theta = pi / 2;
+output int n;
+
+x q[1];
+c = measure q;
+n = 3;
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code: code,
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.Classical["c"].GetBits().GetValues())
+	fmt.Println(resp.Msg.Classical["n"].GetInt())
+	fmt.Println(resp.Msg.Classical["theta"])
+
+	// Output:
+	// [0 1]
+	// 3
+	// <nil>
+}
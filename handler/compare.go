@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/packed"
+	"github.com/itsubaki/quasar/statevector"
+)
+
+var ErrInvalidTarget = errors.New("invalid target state")
+
+// Compare compares the final state of a with the final state of b, or with the target state vector.
+// The programs are simulated in order with the same seed, so measurements are reproducible.
+func (s *QuasarService) Compare(
+	ctx context.Context,
+	req *connect.Request[quasarv1.CompareRequest],
+) (*connect.Response[quasarv1.CompareResponse], error) {
+	if req.Msg.A == nil || req.Msg.Other == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	r, err := newRounding(req.Msg.Precision, req.Msg.Epsilon)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	seed := rand.Uint64()
+	if req.Msg.Seed != nil {
+		seed = req.Msg.GetSeed()
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	c, a, err := s.final(ctx, req.Msg.A, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []complex128
+	switch {
+	case req.Msg.GetB() != nil:
+		_, state, err := s.final(ctx, req.Msg.GetB(), rng)
+		if err != nil {
+			return nil, err
+		}
+
+		b = state.Amplitude
+	default:
+		target, err := packed.Decode(req.Msg.GetTarget())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %w", ErrInvalidTarget, err))
+		}
+
+		var norm float64
+		for _, v := range target {
+			norm += real(v)*real(v) + imag(v)*imag(v)
+		}
+
+		if math.Abs(norm-1) > 1e-6 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("norm=%v: %w", norm, ErrInvalidTarget))
+		}
+
+		b = target
+	}
+
+	fidelity, err := statevector.Fidelity(a.Amplitude, b)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	distance, err := statevector.TraceDistance(a.Amplitude, b)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	diffs := make([]*quasarv1.CompareResponse_Difference, 0)
+	for k, pa := range a.Probabilities() {
+		pb := real(b[k])*real(b[k]) + imag(b[k])*imag(b[k])
+		if r.negligible(pa) && r.negligible(pb) {
+			continue
+		}
+
+		diffs = append(diffs, &quasarv1.CompareResponse_Difference{
+			BinaryString: BinaryString(k, c.Qubits, c.Index()),
+			A:            r.round(pa),
+			B:            r.round(pb),
+			Diff:         r.round(r.round(pb) - r.round(pa)),
+		})
+	}
+
+	return connect.NewResponse(&quasarv1.CompareResponse{
+		Qubits:        int32(c.Qubits),
+		Fidelity:      r.round(fidelity),
+		TraceDistance: r.round(distance),
+		Differences:   diffs,
+	}), nil
+}
+
+// final returns the final state of the program on the state vector machine of the compiler, as Simulate runs it,
+// so that the program can branch on its measured bits.
+func (s *QuasarService) final(ctx context.Context, p *quasarv1.Program, rng *rand.Rand) (*circuit.Circuit, *statevector.State, error) {
+	sv := &statevector.Machine{
+		State: statevector.New(0),
+		Rand:  rng.Float64,
+	}
+
+	w := s.backend(quasarv1.Backend_BACKEND_STATEVECTOR, 0, 1)
+	c, err := s.compile(ctx, p, &w, compiler.WithMaxQubits(s.MaxQubits), compiler.WithMachine(sv))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	return c, sv.State, nil
+}
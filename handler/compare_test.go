@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/packed"
+)
+
+func ExampleQuasarService_Compare() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+cx q[0], q[1];
+`
+
+	// (|00> + |11>)/sqrt(2)
+	target := packed.Encode([]complex128{complex(1/math.Sqrt2, 0), 0, 0, complex(1/math.Sqrt2, 0)})
+
+	resp, err := (&handler.QuasarService{}).Compare(context.Background(), connect.NewRequest(&quasarv1.CompareRequest{
+		A:     &quasarv1.Program{Code: code},
+		Other: &quasarv1.CompareRequest_Target{Target: target},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.Fidelity, resp.Msg.TraceDistance)
+	for _, d := range resp.Msg.Differences {
+		fmt.Println(d.BinaryString, d.A, d.B, d.Diff)
+	}
+
+	// Output:
+	// 1 0
+	// [00] 0.5 0.5 0
+	// [11] 0.5 0.5 0
+}
+
+func TestQuasarService_Compare(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		fidelity float64
+		distance float64
+		diffs    []float64
+	}{
+		{"qubit q;\nU(pi/2, 0, pi) q;", "qubit q;\nU(pi/2, 0, pi) q;", 1, 0, []float64{0, 0}},
+		{"qubit q;\nU(pi/2, 0, pi) q;", "qubit q;\nU(pi, 0, pi) q;", 0.5, 0.707107, []float64{-0.5, 0.5}},
+		{"qubit q;", "qubit q;\nU(pi, 0, pi) q;", 0, 1, []float64{-1, 1}},
+		{"qubit q;\nU(pi/2, 0, pi) q;", "qubit q;\nU(pi/2, 0, pi) q;\nU(0, 0, pi) q;", 0, 1, []float64{0, 0}},
+		{"qubit[2] q; bit c; U(pi, 0, pi) q[0]; c = measure q[0]; if (c == 1) { U(pi, 0, pi) q[1]; }", "qubit[2] q; U(pi, 0, pi) q;", 1, 0, []float64{0}},
+	}
+
+	for _, c := range cases {
+		resp, err := (&handler.QuasarService{}).Compare(context.Background(), connect.NewRequest(&quasarv1.CompareRequest{
+			A:     &quasarv1.Program{Code: c.a},
+			Other: &quasarv1.CompareRequest_B{B: &quasarv1.Program{Code: c.b}},
+		}))
+		if err != nil {
+			t.Fatalf("compare: %v", err)
+		}
+
+		if resp.Msg.Fidelity != c.fidelity || resp.Msg.TraceDistance != c.distance {
+			t.Errorf("got=%v, %v, want=%v, %v", resp.Msg.Fidelity, resp.Msg.TraceDistance, c.fidelity, c.distance)
+		}
+
+		diffs := make([]float64, len(resp.Msg.Differences))
+		for i, d := range resp.Msg.Differences {
+			diffs[i] = d.Diff
+		}
+
+		if fmt.Sprint(diffs) != fmt.Sprint(c.diffs) {
+			t.Errorf("got=%v, want=%v", diffs, c.diffs)
+		}
+	}
+}
+
+func TestQuasarService_Compare_error(t *testing.T) {
+	a := &quasarv1.Program{Code: "qubit q;"}
+
+	cases := []*quasarv1.CompareRequest{
+		{},
+		{A: a},
+		{A: a, Other: &quasarv1.CompareRequest_B{B: &quasarv1.Program{Code: "qubit[2] q;"}}},
+		{A: a, Other: &quasarv1.CompareRequest_Target{Target: packed.Encode([]complex128{1, 1})}},
+		{A: a, Other: &quasarv1.CompareRequest_Target{Target: packed.Encode([]complex128{1, 0, 0, 0})}},
+		{A: a, Other: &quasarv1.CompareRequest_Target{Target: []byte{1, 2, 3}}},
+		{A: a, Other: &quasarv1.CompareRequest_Target{Target: packed.Encode([]complex128{1, 0})}, Precision: new(int32(16))},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{}).Compare(context.Background(), connect.NewRequest(c)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
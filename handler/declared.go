@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	gen "github.com/itsubaki/qasm/gen/parser"
+)
+
+type Declarations struct {
+	Angle  map[string]bool
+	Output map[string]bool
+	Input  map[string]string
+	Width  map[string]int // the width of the int, uint and bit inputs, if it is an integer literal
+}
+
+func Declared(program antlr.Tree) *Declarations {
+	decl := &Declarations{
+		Angle:  make(map[string]bool),
+		Output: make(map[string]bool),
+		Input:  make(map[string]string),
+		Width:  make(map[string]int),
+	}
+
+	Walk(program, func(tree antlr.Tree) {
+		switch ctx := tree.(type) {
+		case *gen.ClassicalDeclarationStatementContext:
+			if ctx.ScalarType() != nil && ctx.ScalarType().ANGLE() != nil {
+				decl.Angle[ctx.Identifier().GetText()] = true
+			}
+		case *gen.IoDeclarationStatementContext:
+			if ctx.ScalarType() != nil && ctx.ScalarType().ANGLE() != nil {
+				decl.Angle[ctx.Identifier().GetText()] = true
+			}
+
+			if ctx.OUTPUT() != nil {
+				decl.Output[ctx.Identifier().GetText()] = true
+			}
+
+			if ctx.INPUT() != nil && ctx.ScalarType() != nil {
+				// e.g. int[32] -> int
+				name := ctx.Identifier().GetText()
+				typ, _, _ := strings.Cut(ctx.ScalarType().GetText(), "[")
+				decl.Input[name] = typ
+
+				switch d := ctx.ScalarType().Designator(); {
+				case d != nil:
+					if w, err := strconv.Atoi(d.Expression().GetText()); err == nil {
+						decl.Width[name] = w
+					}
+				case typ == "bit":
+					decl.Width[name] = 1
+				}
+			}
+		}
+	})
+
+	return decl
+}
+
+func Walk(tree antlr.Tree, fn func(tree antlr.Tree)) {
+	fn(tree)
+	for _, c := range tree.GetChildren() {
+		Walk(c, fn)
+	}
+}
@@ -0,0 +1,36 @@
+package handler_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleDeclared() {
+	code := `
+	OPENQASM 3.0;
+
+angle theta = pi / 2;
+output angle phi;
+input int[32] n;
+input bit b;
+	`
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		panic(err)
+	}
+
+	decl := handler.Declared(program)
+	fmt.Println(decl.Angle)
+	fmt.Println(decl.Output)
+	fmt.Println(decl.Input)
+	fmt.Println(decl.Width)
+
+	// Output:
+	// map[phi:true theta:true]
+	// map[phi:true]
+	// map[b:bit n:int]
+	// map[b:1 n:32]
+}
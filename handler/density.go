@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/quasar/density"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/packed"
+)
+
+// Noise returns the noise model of the request. A nil model is noiseless.
+func Noise(m *quasarv1.NoiseModel) density.Noise {
+	return density.Noise{
+		Depolarizing:     m.GetDepolarizing(),
+		AmplitudeDamping: m.GetAmplitudeDamping(),
+		PhaseDamping:     m.GetPhaseDamping(),
+		BitFlip:          m.GetBitFlip(),
+		ReadoutError:     m.GetReadoutError(),
+	}
+}
+
+// simulateDensity runs the program on the density matrix with the noise model of the request.
+func (sim *simulation) simulateDensity(values map[string]float64, inputs map[string]any) (*quasarv1.SimulateResponse_Result, error) {
+	machine := func() *density.Machine {
+		return &density.Machine{
+			State:     density.New(0),
+			Noise:     sim.noise,
+			Rand:      sim.rng.Float64,
+			MaxQubits: sim.maxQubits,
+		}
+	}
+
+	rho := machine()
+	c, env, err := sim.run(rho, inputs, sim.maxQubits)
+	if err != nil {
+		return nil, err
+	}
+
+	// probabilities of the computational basis states
+	diag := rho.Diagonal()
+	states := make([]*quasarv1.SimulateResponse_State, 0)
+	for k, p := range diag {
+		if !sim.req.Dense && sim.r.negligible(p) {
+			continue
+		}
+
+		states = append(states, &quasarv1.SimulateResponse_State{
+			BinaryString: BinaryString(k, c.Qubits, c.Index()),
+			Probability:  sim.r.round(p),
+		})
+	}
+
+	for i := range diag {
+		diag[i] = sim.r.round(diag[i])
+	}
+
+	var matrix []byte
+	if sim.req.Packed {
+		matrix = packed.Encode(rho.Rho)
+	}
+
+	// expectation values
+	index := slices.Concat(c.Index()...)
+	expectations := make([]float64, len(sim.observables))
+	for i, h := range sim.observables {
+		e, err := h.DensityExpectation(rho.Rho, index)
+		if err != nil {
+			return nil, err
+		}
+
+		expectations[i] = e
+	}
+
+	// reduced density matrices
+	var analysis *quasarv1.SimulateResponse_Analysis
+	if sim.req.Analysis != nil {
+		analysis, err = Analyze(func(keep []int) ([]complex128, error) {
+			return density.PartialTrace(rho.Rho, keep)
+		}, index, sim.req.Analysis.Subsystem, sim.r.round)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// measurement counts
+	var counts map[string]int32
+	if sim.shots > 0 {
+		// the shots are sampled from a single run if the measurements are terminal
+		var final *density.State
+		if terminal(c, env) {
+			final, err = density.Run(unmeasured(c), sim.noise, sim.rng.Float64, sim.maxQubits)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		counts = make(map[string]int32)
+		for range sim.shots {
+			if err := sim.ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			sampled, qc := final, c
+			if final == nil {
+				m := machine()
+				qc, _, err = sim.run(m, inputs, sim.maxQubits)
+				if err != nil {
+					return nil, err
+				}
+
+				sampled = m.State
+			}
+
+			k := sampled.Sample(sim.rng.Float64, sim.noise.ReadoutError)
+			counts[strings.Join(BinaryString(k, qc.Qubits, qc.Index()), " ")]++
+		}
+	}
+
+	return &quasarv1.SimulateResponse_Result{
+		Inputs:        values,
+		States:        states,
+		Counts:        counts,
+		Classical:     Classical(c, env, sim.decl),
+		Expectations:  expectations,
+		Diagonal:      diag,
+		DensityMatrix: matrix,
+		Analysis:      analysis,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/compiler"
+	"github.com/itsubaki/quasar/draw"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+)
+
+const (
+	maxDrawQubits = 64
+	maxDrawOps    = 10000
+)
+
+var ErrInvalidFormat = errors.New("invalid format")
+
+// Draw renders the circuit diagram of the program.
+// The circuit is not simulated, so the number of qubits is not limited by MaxQubits.
+// Both branches on measured bits are drawn, labeled with their conditions.
+func (s *QuasarService) Draw(
+	ctx context.Context,
+	req *connect.Request[quasarv1.DrawRequest],
+) (*connect.Response[quasarv1.DrawResponse], error) {
+	if req.Msg.Program == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	c, err := s.compile(ctx, req.Msg.Program, nil,
+		compiler.WithMaxQubits(maxDrawQubits),
+		compiler.WithMaxOps(maxDrawOps),
+		compiler.WithBranches(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	var diagram string
+	switch req.Msg.Format {
+	case quasarv1.DrawFormat_DRAW_FORMAT_UNSPECIFIED, quasarv1.DrawFormat_DRAW_FORMAT_ASCII:
+		diagram = draw.ASCII(c, req.Msg.Expand)
+	case quasarv1.DrawFormat_DRAW_FORMAT_SVG:
+		diagram = draw.SVG(c, req.Msg.Expand)
+	case quasarv1.DrawFormat_DRAW_FORMAT_LATEX:
+		diagram = draw.Quantikz(c, req.Msg.Expand)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrInvalidFormat)
+	}
+
+	return connect.NewResponse(&quasarv1.DrawResponse{
+		Diagram: diagram,
+	}), nil
+}
@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleQuasarService_Draw() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+bit[2] c;
+h q[0];
+cx q[0], q[1];
+c = measure q;
+`
+
+	resp, err := (&handler.QuasarService{}).Draw(context.Background(), connect.NewRequest(&quasarv1.DrawRequest{
+		Program: &quasarv1.Program{Code: code},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(resp.Msg.Diagram)
+
+	// Output:
+	// q[0]: -[h]---*---[M]--
+	//              |
+	// q[1]: ------(+)--[M]--
+}
+
+func TestQuasarService_Draw(t *testing.T) {
+	code := `
+gate g(a) x, y { U(a, 0, 0) x; ctrl @ U(pi, 0, pi) x, y; }
+qubit[3] q;
+negctrl @ inv @ g(pi/2) q[0], q[1], q[2];
+`
+
+	cases := []struct {
+		format quasarv1.DrawFormat
+		expand bool
+		want   string
+	}{
+		{quasarv1.DrawFormat_DRAW_FORMAT_ASCII, false, "[g(pi/2)^-1]"},
+		{quasarv1.DrawFormat_DRAW_FORMAT_ASCII, true, "(+)"},
+		{quasarv1.DrawFormat_DRAW_FORMAT_SVG, false, "g(pi/2)^-1</text>"},
+		{quasarv1.DrawFormat_DRAW_FORMAT_LATEX, false, `\gate[2]{\mathrm{g}(\pi/2)^{\dagger}}`},
+		{quasarv1.DrawFormat_DRAW_FORMAT_LATEX, true, `\octrl{`},
+	}
+
+	for _, c := range cases {
+		resp, err := (&handler.QuasarService{}).Draw(context.Background(), connect.NewRequest(&quasarv1.DrawRequest{
+			Program: &quasarv1.Program{Code: code},
+			Format:  c.format,
+			Expand:  c.expand,
+		}))
+		if err != nil {
+			t.Fatalf("draw: %v", err)
+		}
+
+		if !strings.Contains(resp.Msg.Diagram, c.want) {
+			t.Errorf("got=%v, want=%v", resp.Msg.Diagram, c.want)
+		}
+	}
+}
+
+func TestQuasarService_Draw_branches(t *testing.T) {
+	code := "qubit q; bit c = measure q; if (c == 1) { U(pi, 0, pi) q; } else { reset q; }"
+	resp, err := (&handler.QuasarService{}).Draw(context.Background(), connect.NewRequest(&quasarv1.DrawRequest{
+		Program: &quasarv1.Program{Code: code},
+	}))
+	if err != nil {
+		t.Fatalf("draw: %v", err)
+	}
+
+	for _, want := range []string{"[M]", "[x if c==1]", "[|0> if !(c==1)]"} {
+		if !strings.Contains(resp.Msg.Diagram, want) {
+			t.Errorf("got=%v, want=%v", resp.Msg.Diagram, want)
+		}
+	}
+}
+
+func TestQuasarService_Draw_error(t *testing.T) {
+	cases := []*quasarv1.DrawRequest{
+		{},
+		{Program: &quasarv1.Program{}},
+		{Program: &quasarv1.Program{Code: "qubit q; foo q;"}},
+		{Program: &quasarv1.Program{Code: "bit c;"}},
+		{Program: &quasarv1.Program{Code: "qubit[65] q;"}},
+		{Program: &quasarv1.Program{Code: "qubit q;"}, Format: quasarv1.DrawFormat(42)},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{}).Draw(context.Background(), connect.NewRequest(c)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
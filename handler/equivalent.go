@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+)
+
+var (
+	ErrProgramNotFound  = errors.New("program not found")
+	ErrInvalidTolerance = errors.New("invalid tolerance")
+)
+
+// Equivalent reports whether the two programs implement the same unitary up to global phase.
+// They are equivalent if the process fidelity is within tolerance of 1.
+func (s *QuasarService) Equivalent(
+	ctx context.Context,
+	req *connect.Request[quasarv1.EquivalentRequest],
+) (*connect.Response[quasarv1.EquivalentResponse], error) {
+	if req.Msg.A == nil || req.Msg.B == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	tol := epsilon
+	if req.Msg.Tolerance != nil {
+		tol = req.Msg.GetTolerance()
+	}
+
+	if tol < 0 || tol > 1 || math.IsNaN(tol) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("tolerance must be between 0 and 1: %w", ErrInvalidTolerance))
+	}
+
+	// the matrix has as many entries as the state vector of 2n qubits
+	limit := maxUnitary
+	if s.MaxQubits > 0 {
+		limit = min(limit, s.MaxQubits/2)
+	}
+
+	w := &workload{qubits: limit, memory: matrixBytes, runs: 1}
+	a, err := s.compile(ctx, req.Msg.A, w, compiler.WithMaxQubits(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	b, err := s.compile(ctx, req.Msg.B, w, compiler.WithMaxQubits(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	fidelity, counterexample, err := circuit.Equivalent(a, b)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp := &quasarv1.EquivalentResponse{
+		Equivalent: 1-fidelity <= tol,
+		Qubits:     int32(a.Qubits),
+		Fidelity:   fidelity,
+	}
+
+	if !resp.Equivalent && counterexample != nil {
+		basis := make([]string, len(counterexample.Basis))
+		for i, k := range counterexample.Basis {
+			basis[i] = fmt.Sprintf("%0*b", a.Qubits, k)
+		}
+
+		resp.Counterexample = new(strings.Join(basis, "+"))
+		resp.CounterexampleFidelity = new(counterexample.Fidelity)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// compile compiles the program, or the shared snippet if the code is empty, with the inputs of the program.
+// If the program is to be simulated, the workload is checked by preflight and the operations are limited by the service.
+func (s *QuasarService) compile(ctx context.Context, p *quasarv1.Program, w *workload, opts ...compiler.Option) (*circuit.Circuit, error) {
+	code, err := s.snippet(ctx, p.Code, p.SnippetId)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err = s.source(ctx, code, p.Includes, p.Imports)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	inputs, err := Bind(Declared(program), p.Inputs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if w != nil {
+		if err := s.preflight(program, inputs, *w); err != nil {
+			return nil, connect.NewError(errorCode(err), err)
+		}
+
+		opts = append([]compiler.Option{compiler.WithMaxOps(s.MaxOps)}, opts...)
+	}
+
+	c, err := compiler.Compile(program, append([]compiler.Option{compiler.WithContext(ctx), compiler.WithInputs(inputs)}, opts...)...)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,144 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/store"
+)
+
+func ExampleQuasarService_Equivalent() {
+	hzh := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate z q { U(0, 0, pi) q; }
+
+qubit q;
+h q;
+z q;
+h q;
+`
+
+	x := `
+OPENQASM 3.0;
+qubit q;
+U(pi, 0, pi) q;
+`
+
+	resp, err := (&handler.QuasarService{}).Equivalent(context.Background(), connect.NewRequest(&quasarv1.EquivalentRequest{
+		A: &quasarv1.Program{Code: hzh},
+		B: &quasarv1.Program{Code: x},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%v %.4f\n", resp.Msg.Equivalent, resp.Msg.Fidelity)
+
+	// Output:
+	// true 1.0000
+}
+
+func TestQuasarService_Equivalent(t *testing.T) {
+	snippets := &store.MemoryStore{}
+	if err := snippets.Put(context.Background(), "abcd", &store.Snippet{
+		Code:      "qubit[2] q;\nctrl @ U(pi, 0, pi) q[0], q[1];",
+		CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	cases := []struct {
+		a, b           *quasarv1.Program
+		tolerance      *float64
+		equivalent     bool
+		fidelity       float64
+		counterexample string
+		stateFidelity  float64
+	}{
+		{
+			&quasarv1.Program{SnippetId: "abcd"},
+			&quasarv1.Program{Code: "qubit[2] q;\nctrl @ U(pi, 0, pi) q[0], q[1];\ngphase(pi/4);"},
+			nil, true, 1, "", 0,
+		},
+		{
+			&quasarv1.Program{Code: "qubit q;\nU(0, 0, pi) q;"},
+			&quasarv1.Program{Code: "qubit q;"},
+			nil, false, 0, "0+1", 0,
+		},
+		{
+			&quasarv1.Program{Code: "qubit q;\nU(pi/2, 0, pi) q;"},
+			&quasarv1.Program{Code: "qubit q;"},
+			nil, false, 0, "0", 0.5,
+		},
+		{
+			&quasarv1.Program{SnippetId: "abcd"},
+			&quasarv1.Program{Code: "qubit[2] q;\nctrl @ U(pi, 0, pi) q[1], q[0];"},
+			nil, false, 0.0625, "01", 0,
+		},
+		{
+			&quasarv1.Program{Code: "input float theta;\nqubit q;\nU(0, 0, theta) q;", Inputs: map[string]float64{"theta": 0.001}},
+			&quasarv1.Program{Code: "qubit q;"},
+			new(1e-3), true, 0.99999975, "", 0,
+		},
+		{
+			&quasarv1.Program{Code: "OPENQASM 2.0;\ninclude \"qelib1.inc\";\nqreg q[3];\nccx q[0], q[1], q[2];\ncu3(0.1, 0.2, 0.3) q[0], q[1];\ncswap q[2], q[0], q[1];"},
+			&quasarv1.Program{Code: "OPENQASM 3.0;\nqubit[3] q;\nctrl(2) @ U(pi, 0, pi) q[0], q[1], q[2];\nctrl @ U(0.1, 0.2, 0.3) q[0], q[1];\nctrl @ U(pi, 0, pi) q[1], q[0];\nctrl(2) @ U(pi, 0, pi) q[2], q[0], q[1];\nctrl @ U(pi, 0, pi) q[1], q[0];"},
+			nil, true, 1, "", 0,
+		},
+	}
+
+	svc := &handler.QuasarService{Store: snippets}
+	for _, c := range cases {
+		resp, err := svc.Equivalent(context.Background(), connect.NewRequest(&quasarv1.EquivalentRequest{
+			A:         c.a,
+			B:         c.b,
+			Tolerance: c.tolerance,
+		}))
+		if err != nil {
+			t.Fatalf("equivalent: %v", err)
+		}
+
+		if resp.Msg.Equivalent != c.equivalent || fmt.Sprintf("%.8f", resp.Msg.Fidelity) != fmt.Sprintf("%.8f", c.fidelity) {
+			t.Errorf("got=%v, %v, want=%v, %v", resp.Msg.Equivalent, resp.Msg.Fidelity, c.equivalent, c.fidelity)
+		}
+
+		if resp.Msg.GetCounterexample() != c.counterexample || fmt.Sprintf("%.8f", resp.Msg.GetCounterexampleFidelity()) != fmt.Sprintf("%.8f", c.stateFidelity) {
+			t.Errorf("got=%v, %v, want=%v, %v", resp.Msg.GetCounterexample(), resp.Msg.GetCounterexampleFidelity(), c.counterexample, c.stateFidelity)
+		}
+	}
+}
+
+func TestQuasarService_Equivalent_error(t *testing.T) {
+	svc := &handler.QuasarService{Store: &store.MemoryStore{}}
+
+	cases := []struct {
+		req  *quasarv1.EquivalentRequest
+		code connect.Code
+	}{
+		{&quasarv1.EquivalentRequest{}, connect.CodeInvalidArgument},
+		{&quasarv1.EquivalentRequest{A: &quasarv1.Program{}, B: &quasarv1.Program{Code: "qubit q;"}}, connect.CodeInvalidArgument},
+		{&quasarv1.EquivalentRequest{A: &quasarv1.Program{SnippetId: "notfound"}, B: &quasarv1.Program{Code: "qubit q;"}}, connect.CodeNotFound},
+		{&quasarv1.EquivalentRequest{A: &quasarv1.Program{Code: "qubit q;"}, B: &quasarv1.Program{Code: "qubit[2] q;"}}, connect.CodeInvalidArgument},
+		{&quasarv1.EquivalentRequest{A: &quasarv1.Program{Code: "qubit q;"}, B: &quasarv1.Program{Code: "qubit q;\nbit c;\nc = measure q;"}}, connect.CodeInvalidArgument},
+		{&quasarv1.EquivalentRequest{A: &quasarv1.Program{Code: "qubit q;"}, B: &quasarv1.Program{Code: "qubit q;"}, Tolerance: new(-1.0)}, connect.CodeInvalidArgument},
+	}
+
+	for _, c := range cases {
+		if _, err := svc.Equivalent(context.Background(), connect.NewRequest(c.req)); connect.CodeOf(err) != c.code {
+			t.Errorf("got=%v, want=%v", err, c.code)
+		}
+	}
+
+	if _, err := (&handler.QuasarService{MaxOps: 10}).Equivalent(context.Background(), connect.NewRequest(&quasarv1.EquivalentRequest{
+		A: &quasarv1.Program{Code: "qubit q;\nfor int i in [0:20] { U(0, 0, 0) q; }"},
+		B: &quasarv1.Program{Code: "qubit q;"},
+	})); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("got=%v", err)
+	}
+}
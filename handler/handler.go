@@ -6,14 +6,52 @@ import (
 	"log/slog"
 	"net/http"
 	_ "net/http/pprof"
+	"runtime"
 
 	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 	"github.com/itsubaki/quasar/gen/quasar/v1/quasarv1connect"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 )
 
-func New(maxQubits int, store Store) (http.Handler, error) {
+type Option func(*QuasarService)
+
+// WithMaxBytes limits the memory of the state of a simulation.
+func WithMaxBytes(n int64) Option {
+	return func(s *QuasarService) {
+		s.MaxBytes = n
+	}
+}
+
+// WithMaxOps limits the estimated number of operations of a simulation.
+func WithMaxOps(n int) Option {
+	return func(s *QuasarService) {
+		s.MaxOps = n
+	}
+}
+
+// WithIncludes allows the programs to include the files by name, e.g. {"mygates.inc": "gate ..."}.
+// The files of a request cannot replace them.
+func WithIncludes(files map[string]string) Option {
+	return func(s *QuasarService) {
+		s.Includes = files
+	}
+}
+
+// Handler serves the QuasarService and runs its jobs.
+type Handler struct {
+	http.Handler
+	pool *Pool
+}
+
+// Close cancels the queued and running jobs and waits for the workers.
+// It should be called after the server has shut down.
+func (h *Handler) Close() {
+	h.pool.Close()
+}
+
+func New(maxQubits int, store Store, jobStore JobStore, opts ...Option) (*Handler, error) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -29,17 +67,36 @@ func New(maxQubits int, store Store) (http.Handler, error) {
 		}
 	})
 
+	svc := &QuasarService{
+		MaxQubits: maxQubits,
+		Store:     store,
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	svc.Pool = NewPool(jobStore, runtime.NumCPU(), queueSize, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		resp, err := svc.Simulate(ctx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Msg, nil
+	})
+
 	mux.Handle(quasarv1connect.NewQuasarServiceHandler(
-		&QuasarService{
-			MaxQubits: maxQubits,
-			Store:     store,
-		},
+		svc,
 		connect.WithInterceptors(
 			Recover(),
+			RecoverStream(),
 		),
 	))
 
-	return h2c.NewHandler(mux, &http2.Server{}), nil
+	return &Handler{
+		Handler: h2c.NewHandler(mux, &http2.Server{}),
+		pool:    svc.Pool,
+	}, nil
 }
 
 func Recover() connect.UnaryInterceptorFunc {
@@ -61,3 +118,37 @@ func Recover() connect.UnaryInterceptorFunc {
 		}
 	}
 }
+
+// RecoverStream returns the interceptor that recovers from the panics of the streaming handlers.
+// The unary handlers are recovered by Recover.
+func RecoverStream() connect.Interceptor {
+	return &recoverStream{}
+}
+
+type recoverStream struct{}
+
+func (*recoverStream) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+func (*recoverStream) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (*recoverStream) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx,
+					"recovered",
+					slog.Any("panic", r),
+					slog.String("procedure", conn.Spec().Procedure),
+				)
+
+				err = connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected: %v", r))
+			}
+		}()
+
+		return next(ctx, conn)
+	}
+}
@@ -14,13 +14,15 @@ import (
 	"connectrpc.com/connect"
 	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/store"
 )
 
 func ExampleNew_root() {
-	h, err := handler.New(5, nil)
+	h, err := handler.New(5, nil, &store.MemoryJobStore{})
 	if err != nil {
 		panic(err)
 	}
+	defer h.Close()
 
 	s := httptest.NewServer(h)
 	defer s.Close()
@@ -51,10 +53,11 @@ func ExampleNew_root() {
 }
 
 func ExampleNew_status() {
-	h, err := handler.New(5, nil)
+	h, err := handler.New(5, nil, &store.MemoryJobStore{})
 	if err != nil {
 		panic(err)
 	}
+	defer h.Close()
 
 	s := httptest.NewServer(h)
 	defer s.Close()
@@ -131,3 +134,30 @@ func TestRecover(t *testing.T) {
 		}
 	}
 }
+
+type conn struct {
+	connect.StreamingHandlerConn
+}
+
+func (conn) Spec() connect.Spec {
+	return connect.Spec{Procedure: "/quasar.v1.QuasarService/SimulateStream"}
+}
+
+func TestRecoverStream(t *testing.T) {
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	next := handler.RecoverStream().WrapStreamingHandler(func(context.Context, connect.StreamingHandlerConn) error {
+		panic("stream panic")
+	})
+
+	connectErr, ok := errors.AsType[*connect.Error](next(context.Background(), conn{}))
+	if !ok || connectErr.Code() != connect.CodeInternal {
+		t.Fatalf("got=%v", connectErr)
+	}
+
+	if got := connectErr.Message(); got != "unexpected: stream panic" {
+		t.Errorf("message=%v", got)
+	}
+}
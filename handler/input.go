@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInputNotDeclared = errors.New("input not declared")
+	ErrInputNotBound    = errors.New("input not bound")
+	ErrInvalidInput     = errors.New("invalid input")
+)
+
+// Bind converts the input values to the declared types.
+// Every declared input must be bound, and every bound value must be declared.
+// The values of int, uint and bit inputs must be integers in the range of the type and its width.
+// The value of a bit input is written to the bits, the first bit is the least significant.
+func Bind(decl *Declarations, inputs map[string]float64) (map[string]any, error) {
+	for name := range inputs {
+		if _, ok := decl.Input[name]; !ok {
+			return nil, fmt.Errorf("%s: %w", name, ErrInputNotDeclared)
+		}
+	}
+
+	bound := make(map[string]any)
+	for name, typ := range decl.Input {
+		v, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", name, ErrInputNotBound)
+		}
+
+		switch typ {
+		case "int", "uint", "bit":
+			if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 || (typ != "int" && v < 0) {
+				return nil, fmt.Errorf("%s=%v: %s: %w", name, v, typ, ErrInvalidInput)
+			}
+
+			if w, ok := decl.Width[name]; ok && !fits(v, typ, w) {
+				return nil, fmt.Errorf("%s=%v: %s[%d]: %w", name, v, typ, w, ErrInvalidInput)
+			}
+
+			bound[name] = int64(v)
+		case "bool":
+			bound[name] = v != 0
+		default:
+			bound[name] = v
+		}
+	}
+
+	return bound, nil
+}
+
+// fits returns true if the integer v is in the range of the type of the width.
+// e.g. int[8] is in [-128, 128), and uint[8] and bit[8] are in [0, 256).
+func fits(v float64, typ string, w int) bool {
+	if w < 1 || w >= 64 {
+		return true
+	}
+
+	if typ == "int" {
+		return v >= -math.Ldexp(1, w-1) && v < math.Ldexp(1, w-1)
+	}
+
+	return v < math.Ldexp(1, w)
+}
@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleBind() {
+	decl := &handler.Declarations{
+		Input: map[string]string{
+			"theta": "angle",
+			"n":     "int",
+			"flag":  "bool",
+		},
+	}
+
+	bound, err := handler.Bind(decl, map[string]float64{
+		"theta": 1.5,
+		"n":     3,
+		"flag":  1,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(bound["theta"], bound["n"], bound["flag"])
+
+	// Output:
+	// 1.5 3 true
+}
+
+func TestBind(t *testing.T) {
+	cases := []struct {
+		input  map[string]string
+		width  map[string]int
+		values map[string]float64
+		err    error
+	}{
+		{
+			input:  map[string]string{"theta": "angle"},
+			values: map[string]float64{},
+			err:    handler.ErrInputNotBound,
+		},
+		{
+			input:  map[string]string{},
+			values: map[string]float64{"theta": 1.0},
+			err:    handler.ErrInputNotDeclared,
+		},
+		{
+			input:  map[string]string{"n": "int"},
+			values: map[string]float64{"n": 1.5},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"n": "uint"},
+			values: map[string]float64{"n": -1},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"n": "int"},
+			values: map[string]float64{"n": math.Inf(1)},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"n": "int"},
+			values: map[string]float64{"n": -2},
+		},
+		{
+			input:  map[string]string{"n": "uint"},
+			width:  map[string]int{"n": 2},
+			values: map[string]float64{"n": 1000},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"n": "uint"},
+			width:  map[string]int{"n": 2},
+			values: map[string]float64{"n": 3},
+		},
+		{
+			input:  map[string]string{"n": "int"},
+			width:  map[string]int{"n": 8},
+			values: map[string]float64{"n": -129},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"n": "int"},
+			width:  map[string]int{"n": 8},
+			values: map[string]float64{"n": -128},
+		},
+		{
+			input:  map[string]string{"b": "bit"},
+			width:  map[string]int{"b": 1},
+			values: map[string]float64{"b": 2},
+			err:    handler.ErrInvalidInput,
+		},
+		{
+			input:  map[string]string{"b": "bit"},
+			width:  map[string]int{"b": 2},
+			values: map[string]float64{"b": 0.5},
+			err:    handler.ErrInvalidInput,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := handler.Bind(&handler.Declarations{Input: c.input, Width: c.width}, c.values)
+		if !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+	}
+}
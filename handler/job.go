@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/store"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	queueSize = 100
+	listLimit = 20
+	maxList   = 100
+)
+
+var (
+	ErrQueueFull     = errors.New("job queue is full")
+	ErrPoolClosed    = errors.New("job pool is closed")
+	ErrInvalidLimit  = errors.New("invalid limit")
+	ErrJobNotFound   = errors.New("job not found")
+	ErrOwnerNotFound = errors.New("owner not found")
+)
+
+var (
+	_ JobStore = (*store.MemoryJobStore)(nil)
+	_ JobStore = (*store.FirestoreJobStore)(nil)
+)
+
+type JobStore interface {
+	Put(ctx context.Context, job *store.Job) error
+	Get(ctx context.Context, id string) (*store.Job, error)
+	List(ctx context.Context, owner string, limit int) ([]*store.Job, error)
+}
+
+// RunFunc runs the simulation of a job.
+type RunFunc func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error)
+
+// Pool runs the submitted jobs on a bounded number of workers.
+// Cancellation is local to the pool, so a job can only be canceled by the instance that accepted it.
+type Pool struct {
+	Store  JobStore
+	run    RunFunc
+	queue  chan *task
+	cancel map[string]context.CancelFunc
+	closed bool
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+type task struct {
+	ctx context.Context
+	job *store.Job
+	req *quasarv1.SimulateRequest
+}
+
+// NewPool returns a pool with the given number of workers and queue size.
+func NewPool(store JobStore, workers, size int, run RunFunc) *Pool {
+	p := &Pool{
+		Store:  store,
+		run:    run,
+		queue:  make(chan *task, size),
+		cancel: make(map[string]context.CancelFunc),
+	}
+
+	for range max(workers, 1) {
+		p.wg.Go(func() {
+			for t := range p.queue {
+				p.process(t)
+			}
+		})
+	}
+
+	return p
+}
+
+// Submit enqueues the request of the owner and returns the queued job.
+// It returns ErrQueueFull if the queue is full, or ErrPoolClosed once the pool is closed.
+func (p *Pool) Submit(ctx context.Context, owner string, req *quasarv1.SimulateRequest) (*store.Job, error) {
+	job := &store.Job{
+		ID:        rand.Text(),
+		Owner:     owner,
+		Status:    store.JobQueued,
+		CreatedAt: time.Now(),
+	}
+
+	if err := p.Store.Put(ctx, job); err != nil {
+		return nil, fmt.Errorf("put: %w", err)
+	}
+
+	queued := *job
+	jctx, cancel := context.WithCancel(context.Background())
+	if err := p.enqueue(&task{ctx: jctx, job: job, req: req}, cancel); err != nil {
+		cancel()
+
+		job.Status = store.JobFailed
+		job.Error = err.Error()
+		job.FinishedAt = time.Now()
+		p.put(context.WithoutCancel(ctx), job)
+
+		return nil, err
+	}
+
+	return &queued, nil
+}
+
+// enqueue sends the task to the workers without blocking and keeps its cancel until it is released.
+func (p *Pool) enqueue(t *task, cancel context.CancelFunc) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.queue <- t:
+		p.cancel[t.job.ID] = cancel
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Cancel cancels the queued or running job and returns it.
+// The canceled status is recorded once the worker has stopped.
+func (p *Pool) Cancel(ctx context.Context, id string) (*store.Job, error) {
+	job, err := p.Store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	cancel, ok := p.cancel[id]
+	p.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+
+	return job, nil
+}
+
+// Close stops accepting jobs and waits for the workers.
+// The queued and running jobs are canceled.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+
+	p.closed = true
+	for _, cancel := range p.cancel {
+		cancel()
+	}
+	p.mu.Unlock()
+
+	close(p.queue)
+	p.wg.Wait()
+}
+
+func (p *Pool) process(t *task) {
+	defer p.release(t.job.ID)
+
+	// the job outlives the submit request
+	ctx := context.Background()
+	job := t.job
+
+	if t.ctx.Err() != nil {
+		job.Status = store.JobCanceled
+		job.FinishedAt = time.Now()
+		p.put(ctx, job)
+		return
+	}
+
+	job.Status = store.JobRunning
+	job.StartedAt = time.Now()
+	p.put(ctx, job)
+
+	resp, err := p.simulate(t.ctx, job.ID, t.req)
+	job.FinishedAt = time.Now()
+
+	switch {
+	case t.ctx.Err() != nil:
+		job.Status = store.JobCanceled
+	case err != nil:
+		job.Status = store.JobFailed
+		job.Error = err.Error()
+		if connectErr, ok := errors.AsType[*connect.Error](err); ok {
+			job.Error = connectErr.Message()
+		}
+	default:
+		result, err := proto.Marshal(resp)
+		if err != nil {
+			job.Status = store.JobFailed
+			job.Error = ErrSomethingWentWrong.Error()
+			break
+		}
+
+		job.Status = store.JobSucceeded
+		job.Result = result
+	}
+
+	if err := p.put(ctx, job); err != nil && job.Status != store.JobFailed {
+		// e.g. the result is too large for the store, so the job would stay running
+		job.Status = store.JobFailed
+		job.Result = nil
+		job.Error = ErrSomethingWentWrong.Error()
+		p.put(ctx, job)
+	}
+}
+
+// simulate runs the request and recovers from a panic of the run, so a single job cannot crash the server.
+func (p *Pool) simulate(ctx context.Context, id string, req *quasarv1.SimulateRequest) (resp *quasarv1.SimulateResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx,
+				"recovered",
+				slog.Any("panic", r),
+				slog.String("id", id),
+			)
+
+			resp, err = nil, ErrSomethingWentWrong
+		}
+	}()
+
+	return p.run(ctx, req)
+}
+
+func (p *Pool) put(ctx context.Context, job *store.Job) error {
+	if err := p.Store.Put(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "put job",
+			slog.String("id", job.ID),
+			slog.String("status", string(job.Status)),
+			slog.Any("error", err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *Pool) release(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cancel, ok := p.cancel[id]; ok {
+		cancel()
+		delete(p.cancel, id)
+	}
+}
+
+func (s *QuasarService) Submit(
+	ctx context.Context,
+	req *connect.Request[quasarv1.SubmitRequest],
+) (*connect.Response[quasarv1.SubmitResponse], error) {
+	if len(strings.TrimSpace(req.Msg.GetRequest().GetCode())) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
+	}
+
+	if len(req.Msg.Request.Code) > maxSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("code size exceeds %d bytes", maxSize))
+	}
+
+	job, err := s.Pool.Submit(ctx, Owner(req.Msg.Owner), req.Msg.Request)
+	if err != nil {
+		if errors.Is(err, ErrQueueFull) {
+			return nil, connect.NewError(connect.CodeResourceExhausted, ErrQueueFull)
+		}
+
+		if errors.Is(err, ErrPoolClosed) {
+			return nil, connect.NewError(connect.CodeUnavailable, ErrPoolClosed)
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	return connect.NewResponse(&quasarv1.SubmitResponse{
+		Id:        job.ID,
+		CreatedAt: timestamppb.New(job.CreatedAt),
+	}), nil
+}
+
+func (s *QuasarService) GetJob(
+	ctx context.Context,
+	req *connect.Request[quasarv1.GetJobRequest],
+) (*connect.Response[quasarv1.GetJobResponse], error) {
+	if len(req.Msg.Id) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrIDNotFound)
+	}
+
+	job, err := s.Pool.Store.Get(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNoSuchEntity) {
+			return nil, connect.NewError(connect.CodeNotFound, ErrJobNotFound)
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	out, err := Job(job)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	return connect.NewResponse(&quasarv1.GetJobResponse{
+		Job: out,
+	}), nil
+}
+
+func (s *QuasarService) CancelJob(
+	ctx context.Context,
+	req *connect.Request[quasarv1.CancelJobRequest],
+) (*connect.Response[quasarv1.CancelJobResponse], error) {
+	if len(req.Msg.Id) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrIDNotFound)
+	}
+
+	job, err := s.Pool.Cancel(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNoSuchEntity) {
+			return nil, connect.NewError(connect.CodeNotFound, ErrJobNotFound)
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	out, err := Job(job)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	return connect.NewResponse(&quasarv1.CancelJobResponse{
+		Job: out,
+	}), nil
+}
+
+func (s *QuasarService) ListJobs(
+	ctx context.Context,
+	req *connect.Request[quasarv1.ListJobsRequest],
+) (*connect.Response[quasarv1.ListJobsResponse], error) {
+	if len(req.Msg.Owner) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrOwnerNotFound)
+	}
+
+	limit := listLimit
+	if req.Msg.Limit != nil {
+		limit = int(req.Msg.GetLimit())
+	}
+
+	if limit < 1 || limit > maxList {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("limit must be between 1 and %d: %w", maxList, ErrInvalidLimit))
+	}
+
+	jobs, err := s.Pool.Store.List(ctx, Owner(req.Msg.Owner), limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	out := make([]*quasarv1.Job, len(jobs))
+	for i, job := range jobs {
+		j, err := Job(job)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+		}
+
+		out[i] = j
+	}
+
+	return connect.NewResponse(&quasarv1.ListJobsResponse{
+		Jobs: out,
+	}), nil
+}
+
+// Owner returns the stored key of the owner, so the store does not keep the secret of the caller.
+// An empty owner stays empty.
+func Owner(owner string) string {
+	if len(owner) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(owner))
+	return hex.EncodeToString(sum[:])
+}
+
+// Job returns the job message of the stored job.
+func Job(job *store.Job) (*quasarv1.Job, error) {
+	out := &quasarv1.Job{
+		Id:        job.ID,
+		Status:    JobStatus(job.Status),
+		CreatedAt: timestamppb.New(job.CreatedAt),
+	}
+
+	if !job.StartedAt.IsZero() {
+		out.StartedAt = timestamppb.New(job.StartedAt)
+	}
+
+	if !job.FinishedAt.IsZero() {
+		out.FinishedAt = timestamppb.New(job.FinishedAt)
+	}
+
+	if job.Error != "" {
+		out.Error = &job.Error
+	}
+
+	if len(job.Result) > 0 {
+		var result quasarv1.SimulateResponse
+		if err := proto.Unmarshal(job.Result, &result); err != nil {
+			return nil, fmt.Errorf("unmarshal: %w", err)
+		}
+
+		out.Result = &result
+	}
+
+	return out, nil
+}
+
+// JobStatus returns the job status enum of the stored status.
+func JobStatus(status store.JobStatus) quasarv1.JobStatus {
+	switch status {
+	case store.JobQueued:
+		return quasarv1.JobStatus_JOB_STATUS_QUEUED
+	case store.JobRunning:
+		return quasarv1.JobStatus_JOB_STATUS_RUNNING
+	case store.JobSucceeded:
+		return quasarv1.JobStatus_JOB_STATUS_SUCCEEDED
+	case store.JobFailed:
+		return quasarv1.JobStatus_JOB_STATUS_FAILED
+	case store.JobCanceled:
+		return quasarv1.JobStatus_JOB_STATUS_CANCELED
+	default:
+		return quasarv1.JobStatus_JOB_STATUS_UNSPECIFIED
+	}
+}
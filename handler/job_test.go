@@ -0,0 +1,379 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/store"
+)
+
+func wait(t *testing.T, s handler.JobStore, id string) *store.Job {
+	t.Helper()
+
+	for range 500 {
+		job, err := s.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+
+		if job.Status.Done() {
+			return job
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("timeout: %v", id)
+	return nil
+}
+
+func TestPool(t *testing.T) {
+	cases := []struct {
+		err    error
+		status store.JobStatus
+		msg    string
+	}{
+		{nil, store.JobSucceeded, ""},
+		{errors.New("something"), store.JobFailed, "something"},
+		{connect.NewError(connect.CodeInvalidArgument, handler.ErrQubitsNotFound), store.JobFailed, "qubits not found"},
+	}
+
+	for _, c := range cases {
+		s := &store.MemoryJobStore{}
+		p := handler.NewPool(s, 1, 1, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+			if c.err != nil {
+				return nil, c.err
+			}
+
+			return &quasarv1.SimulateResponse{
+				Stabilizers: []string{req.Code},
+			}, nil
+		})
+
+		job, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{Code: "+ZZ"})
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+
+		if job.Status != store.JobQueued {
+			t.Errorf("got=%v, want=%v", job.Status, store.JobQueued)
+		}
+
+		done := wait(t, s, job.ID)
+		if done.Status != c.status {
+			t.Errorf("got=%v, want=%v", done.Status, c.status)
+		}
+
+		if done.Error != c.msg {
+			t.Errorf("got=%v, want=%v", done.Error, c.msg)
+		}
+
+		if done.StartedAt.IsZero() || done.FinishedAt.Before(done.StartedAt) {
+			t.Errorf("started_at=%v, finished_at=%v", done.StartedAt, done.FinishedAt)
+		}
+
+		out, err := handler.Job(done)
+		if err != nil {
+			t.Fatalf("job: %v", err)
+		}
+
+		if c.err == nil && out.GetResult().GetStabilizers()[0] != "+ZZ" {
+			t.Errorf("got=%v", out.GetResult())
+		}
+
+		p.Close()
+	}
+}
+
+func TestPool_Cancel(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	started := make(chan struct{})
+	p := handler.NewPool(s, 1, 2, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	defer p.Close()
+
+	running, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	queued, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	<-started
+	for _, id := range []string{queued.ID, running.ID} {
+		if _, err := p.Cancel(context.Background(), id); err != nil {
+			t.Fatalf("cancel: %v", err)
+		}
+	}
+
+	for _, id := range []string{queued.ID, running.ID} {
+		if got := wait(t, s, id); got.Status != store.JobCanceled {
+			t.Errorf("got=%v, want=%v", got.Status, store.JobCanceled)
+		}
+	}
+
+	if _, err := p.Cancel(context.Background(), "notfound"); !errors.Is(err, store.ErrNoSuchEntity) {
+		t.Errorf("got=%v, want=%v", err, store.ErrNoSuchEntity)
+	}
+}
+
+func TestPool_queueFull(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	block := make(chan struct{})
+	p := handler.NewPool(s, 1, 1, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		<-block
+		return &quasarv1.SimulateResponse{}, nil
+	})
+
+	defer p.Close()
+	defer close(block)
+
+	var err error
+	for range 3 {
+		if _, err = p.Submit(context.Background(), "", &quasarv1.SimulateRequest{}); err != nil {
+			break
+		}
+	}
+
+	if !errors.Is(err, handler.ErrQueueFull) {
+		t.Errorf("got=%v, want=%v", err, handler.ErrQueueFull)
+	}
+}
+
+func TestPool_closed(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	p := handler.NewPool(s, 1, 1, nil)
+	p.Close()
+	p.Close()
+
+	if _, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{}); !errors.Is(err, handler.ErrPoolClosed) {
+		t.Errorf("got=%v, want=%v", err, handler.ErrPoolClosed)
+	}
+}
+
+// failing fails to put the jobs with a result.
+type failing struct {
+	store.MemoryJobStore
+}
+
+func (s *failing) Put(ctx context.Context, job *store.Job) error {
+	if len(job.Result) > 0 {
+		return errors.New("too large")
+	}
+
+	return s.MemoryJobStore.Put(ctx, job)
+}
+
+func TestPool_putFailed(t *testing.T) {
+	s := &failing{}
+	p := handler.NewPool(s, 1, 1, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		return &quasarv1.SimulateResponse{
+			Stabilizers: []string{"+ZZ"},
+		}, nil
+	})
+	defer p.Close()
+
+	job, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	done := wait(t, s, job.ID)
+	if done.Status != store.JobFailed {
+		t.Errorf("got=%v, want=%v", done.Status, store.JobFailed)
+	}
+
+	if done.Error != handler.ErrSomethingWentWrong.Error() {
+		t.Errorf("got=%v, want=%v", done.Error, handler.ErrSomethingWentWrong)
+	}
+}
+
+func TestPool_panic(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	p := handler.NewPool(s, 1, 2, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		if req.Code == "panic" {
+			panic("unexpected")
+		}
+
+		return &quasarv1.SimulateResponse{}, nil
+	})
+	defer p.Close()
+
+	panicked, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{Code: "panic"})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	done := wait(t, s, panicked.ID)
+	if done.Status != store.JobFailed {
+		t.Errorf("got=%v, want=%v", done.Status, store.JobFailed)
+	}
+
+	if done.Error != handler.ErrSomethingWentWrong.Error() {
+		t.Errorf("got=%v, want=%v", done.Error, handler.ErrSomethingWentWrong)
+	}
+
+	// the pool keeps running the next jobs
+	job, err := p.Submit(context.Background(), "", &quasarv1.SimulateRequest{})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	if got := wait(t, s, job.ID); got.Status != store.JobSucceeded {
+		t.Errorf("got=%v, want=%v", got.Status, store.JobSucceeded)
+	}
+}
+
+func TestQuasarService_CancelJob(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	svc := &handler.QuasarService{}
+	svc.Pool = handler.NewPool(s, 1, 1, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		resp, err := svc.Simulate(ctx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Msg, nil
+	})
+	defer svc.Pool.Close()
+
+	resp, err := svc.Submit(context.Background(), connect.NewRequest(&quasarv1.SubmitRequest{
+		Request: &quasarv1.SimulateRequest{
+			Code: "qubit q; int i = 0; while (true) { i += 1; }",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	for range 500 {
+		job, err := s.Get(context.Background(), resp.Msg.Id)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+
+		if job.Status == store.JobRunning {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := svc.CancelJob(context.Background(), connect.NewRequest(&quasarv1.CancelJobRequest{
+		Id: resp.Msg.Id,
+	})); err != nil {
+		t.Fatalf("cancel job: %v", err)
+	}
+
+	if got := wait(t, s, resp.Msg.Id); got.Status != store.JobCanceled {
+		t.Errorf("got=%v, want=%v", got.Status, store.JobCanceled)
+	}
+}
+
+func TestQuasarService_Submit(t *testing.T) {
+	s := &store.MemoryJobStore{}
+	svc := &handler.QuasarService{}
+	svc.Pool = handler.NewPool(s, 1, 10, func(ctx context.Context, req *quasarv1.SimulateRequest) (*quasarv1.SimulateResponse, error) {
+		resp, err := svc.Simulate(ctx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Msg, nil
+	})
+	defer svc.Pool.Close()
+
+	resp, err := svc.Submit(context.Background(), connect.NewRequest(&quasarv1.SubmitRequest{
+		Request: &quasarv1.SimulateRequest{
+			Code: "qubit q; x q;",
+		},
+		Owner: "owner",
+	}))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	wait(t, s, resp.Msg.Id)
+
+	job, err := svc.GetJob(context.Background(), connect.NewRequest(&quasarv1.GetJobRequest{
+		Id: resp.Msg.Id,
+	}))
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+
+	if job.Msg.Job.Status != quasarv1.JobStatus_JOB_STATUS_SUCCEEDED {
+		t.Fatalf("got=%v, error=%v", job.Msg.Job.Status, job.Msg.Job.GetError())
+	}
+
+	if got := job.Msg.Job.Result.States[0].BinaryString[0]; got != "1" {
+		t.Errorf("got=%v", got)
+	}
+
+	for _, c := range []struct {
+		owner string
+		want  int
+	}{
+		{"owner", 1},
+		{"other", 0},
+	} {
+		list, err := svc.ListJobs(context.Background(), connect.NewRequest(&quasarv1.ListJobsRequest{
+			Owner: c.owner,
+		}))
+		if err != nil {
+			t.Fatalf("list jobs: %v", err)
+		}
+
+		if len(list.Msg.Jobs) != c.want {
+			t.Errorf("owner=%v, got=%v", c.owner, list.Msg.Jobs)
+		}
+
+		if c.want > 0 && list.Msg.Jobs[0].Id != resp.Msg.Id {
+			t.Errorf("got=%v, want=%v", list.Msg.Jobs[0].Id, resp.Msg.Id)
+		}
+	}
+}
+
+func TestQuasarService_Job_invalid(t *testing.T) {
+	svc := &handler.QuasarService{
+		Pool: handler.NewPool(&store.MemoryJobStore{}, 1, 1, nil),
+	}
+	defer svc.Pool.Close()
+
+	if _, err := svc.Submit(context.Background(), connect.NewRequest(&quasarv1.SubmitRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("submit: got=%v", err)
+	}
+
+	if _, err := svc.GetJob(context.Background(), connect.NewRequest(&quasarv1.GetJobRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("get job: got=%v", err)
+	}
+
+	if _, err := svc.GetJob(context.Background(), connect.NewRequest(&quasarv1.GetJobRequest{Id: "notfound"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("get job: got=%v", err)
+	}
+
+	if _, err := svc.CancelJob(context.Background(), connect.NewRequest(&quasarv1.CancelJobRequest{Id: "notfound"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("cancel job: got=%v", err)
+	}
+
+	for _, limit := range []int32{0, 101} {
+		if _, err := svc.ListJobs(context.Background(), connect.NewRequest(&quasarv1.ListJobsRequest{Owner: "owner", Limit: &limit})); !errors.Is(err, handler.ErrInvalidLimit) {
+			t.Errorf("list jobs: got=%v", err)
+		}
+	}
+
+	if _, err := svc.ListJobs(context.Background(), connect.NewRequest(&quasarv1.ListJobsRequest{})); !errors.Is(err, handler.ErrOwnerNotFound) {
+		t.Errorf("list jobs: got=%v", err)
+	}
+}
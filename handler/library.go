@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/include"
+	"github.com/itsubaki/quasar/qasm2"
+	"github.com/itsubaki/quasar/store"
+)
+
+const (
+	scheme     = "quasar:" // include "quasar:AMOYU8a1VLEfWjqf";
+	maxImports = 32        // shared snippets of a program
+	maxLibrary = 256       // cached snippets
+)
+
+var (
+	ErrInvalidImport  = errors.New("invalid import")
+	ErrTooManyImports = errors.New("too many imports")
+)
+
+// snippetID matches the IDs generated by GenID.
+var snippetID = regexp.MustCompile(`^[A-Za-z0-9_=-]+$`)
+
+// Library caches the definitions of the shared snippets included by the programs.
+// A snippet never changes, since its ID is the hash of the code. The zero value caches up to the default number of snippets.
+type Library struct {
+	Max int
+	m   map[string]string
+	mu  sync.Mutex
+}
+
+// Get returns the definitions of the snippet.
+func (l *Library) Get(id string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	defs, ok := l.m[id]
+	return defs, ok
+}
+
+// Add adds the definitions of the snippet, evicting an arbitrary snippet if the library is full.
+func (l *Library) Add(id, defs string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.m == nil {
+		l.m = make(map[string]string)
+	}
+
+	if _, ok := l.m[id]; !ok && len(l.m) >= cmp.Or(l.Max, maxLibrary) {
+		for k := range l.m {
+			delete(l.m, k)
+			break
+		}
+	}
+
+	l.m[id] = defs
+}
+
+// Len returns the number of the cached snippets.
+func (l *Library) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.m)
+}
+
+// lookup returns the function to look up the includes of a program.
+// The files of the server come first, then the shared snippets by "quasar:<id>", and then the files of the request.
+func (s *QuasarService) lookup(ctx context.Context, includes map[string]string) include.Func {
+	var imported int
+	return func(name string) (string, bool, error) {
+		if contents, ok := s.Includes[name]; ok {
+			return contents, true, nil
+		}
+
+		id, ok := strings.CutPrefix(name, scheme)
+		if !ok {
+			contents, ok := includes[name]
+			return contents, ok, nil
+		}
+
+		if imported++; imported > maxImports {
+			return "", false, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("max=%d: %w", maxImports, ErrTooManyImports))
+		}
+
+		return s.definitions(ctx, id)
+	}
+}
+
+// definitions returns the gate and def definitions of the shared snippet, and false if it is not found.
+func (s *QuasarService) definitions(ctx context.Context, id string) (string, bool, error) {
+	if defs, ok := s.Library.Get(id); ok {
+		return defs, true, nil
+	}
+
+	if s.Store == nil || !snippetID.MatchString(id) {
+		return "", false, nil
+	}
+
+	snippet, err := s.Store.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNoSuchEntity) {
+			return "", false, nil
+		}
+
+		return "", false, connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+	}
+
+	// the position of the errors is in the snippet
+	code, err := qasm2.Translate(snippet.Code)
+	if err != nil {
+		return "", false, err
+	}
+
+	defs := include.Definitions(code)
+	s.Library.Add(id, defs)
+	return defs, true, nil
+}
+
+// imports returns the include names of the shared snippets.
+func imports(ids []string) ([]string, error) {
+	if len(ids) > maxImports {
+		return nil, fmt.Errorf("max=%d: %w", maxImports, ErrTooManyImports)
+	}
+
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		if !snippetID.MatchString(id) {
+			return nil, fmt.Errorf("%q: %w", id, ErrInvalidImport)
+		}
+
+		names[i] = scheme + id
+	}
+
+	return names, nil
+}
@@ -0,0 +1,211 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/store"
+)
+
+type countingStore struct {
+	store.MemoryStore
+	gets int
+}
+
+func (s *countingStore) Get(ctx context.Context, id string) (*store.Snippet, error) {
+	s.gets++
+	return s.MemoryStore.Get(ctx, id)
+}
+
+func ExampleQuasarService_Simulate_imports() {
+	lib := `
+OPENQASM 3.0;
+include "stdgates.inc";
+
+gate bell a, b {
+  h a;
+  cx a, b;
+}
+
+qubit[2] q;
+bell q[0], q[1];
+`
+
+	svc := &handler.QuasarService{
+		Store: &store.MemoryStore{},
+	}
+
+	shared, err := svc.Share(context.Background(), connect.NewRequest(&quasarv1.ShareRequest{
+		Code: lib,
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	code := `
+OPENQASM 3.0;
+qubit[3] q;
+bell q[0], q[1];
+`
+
+	resp, err := svc.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:      code,
+		Precision: new(int32(2)),
+		Imports:   []string{shared.Msg.Id},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range resp.Msg.States {
+		fmt.Println(s.BinaryString, s.Amplitude.Real, s.Probability)
+	}
+
+	// Output:
+	// [000] 0.71 0.5
+	// [110] 0.71 0.5
+}
+
+func TestQuasarService_Simulate_include(t *testing.T) {
+	s := &countingStore{}
+	if err := s.Put(t.Context(), "lib", &store.Snippet{Code: "include \"stdgates.inc\"; gate bell a, b { h a; cx a, b; } qubit q;"}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	svc := &handler.QuasarService{
+		Store: s,
+	}
+
+	for range 2 {
+		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code: "OPENQASM 3.0;\ninclude \"quasar:lib\";\nqubit[2] q;\nbell q[0], q[1];",
+		}))
+		if err != nil {
+			t.Fatalf("simulate: %v", err)
+		}
+
+		// the qubit of the snippet is not declared
+		if len(resp.Msg.States) != 2 || resp.Msg.States[1].BinaryString[0] != "11" {
+			t.Errorf("got=%v", resp.Msg.States)
+		}
+	}
+
+	// cached
+	if s.gets != 1 || svc.Library.Len() != 1 {
+		t.Errorf("gets=%d, len=%d", s.gets, svc.Library.Len())
+	}
+}
+
+func TestQuasarService_Simulate_importsError(t *testing.T) {
+	cases := []struct {
+		imports []string
+		errMsg  string
+	}{
+		{
+			imports: []string{`foo"; bar`},
+			errMsg:  `invalid_argument: "foo\"; bar": invalid import`,
+		},
+		{
+			imports: make([]string, 33),
+			errMsg:  "invalid_argument: max=32: too many imports",
+		},
+		{
+			imports: []string{"missing"},
+			errMsg:  `invalid_argument: line 1:22 include "quasar:missing" not found`,
+		},
+	}
+
+	svc := &handler.QuasarService{
+		Store: &store.MemoryStore{},
+	}
+
+	for _, c := range cases {
+		_, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:    "OPENQASM 3.0;\nqubit q;",
+			Imports: c.imports,
+		}))
+		if err == nil || err.Error() != c.errMsg {
+			t.Errorf("got=%v, want=%v", err, c.errMsg)
+		}
+	}
+}
+
+func TestQuasarService_Validate_include(t *testing.T) {
+	s := &store.MemoryStore{}
+	for id, code := range map[string]string{
+		"a":      "include \"quasar:b\";",
+		"b":      "\ninclude \"quasar:a\";",
+		"broken": "OPENQASM 2.0;\nqreg q[1];\nctrl @ x q[0];",
+	} {
+		if err := s.Put(t.Context(), id, &store.Snippet{Code: code}); err != nil {
+			t.Fatalf("put: %v", err)
+		}
+	}
+
+	cases := []struct {
+		code   string
+		line   int32
+		column int32
+		msg    string
+	}{
+		{
+			code:   "OPENQASM 3.0;\ninclude \"quasar:missing\";",
+			line:   2,
+			column: 8,
+			msg:    `include "quasar:missing" not found`,
+		},
+		{
+			code:   "OPENQASM 3.0;\ninclude \"quasar:a\";",
+			line:   2,
+			column: 8,
+			msg:    `quasar:a: line 1:8 quasar:b: line 2:8 include "quasar:a" is cyclic`,
+		},
+		{
+			code:   "OPENQASM 3.0;\n\ninclude \"quasar:broken\";",
+			line:   3,
+			column: 8,
+			msg:    `quasar:broken: line 3:0 "ctrl" is not supported in OpenQASM 2.0`,
+		},
+	}
+
+	svc := &handler.QuasarService{
+		Store: s,
+	}
+
+	for _, c := range cases {
+		resp, err := svc.Validate(t.Context(), connect.NewRequest(&quasarv1.ValidateRequest{
+			Code: c.code,
+		}))
+		if err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+
+		if resp.Msg.Valid || resp.Msg.GetLine() != c.line || resp.Msg.GetColumn() != c.column || !strings.Contains(resp.Msg.GetMessage(), c.msg) {
+			t.Errorf("got=%v, want=%d:%d %s", resp.Msg, c.line, c.column, c.msg)
+		}
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	l := &handler.Library{Max: 2}
+	l.Add("a", "gate a q { x q; }")
+	l.Add("b", "gate b q { y q; }")
+	l.Add("b", "gate b q { y q; }")
+	if l.Len() != 2 {
+		t.Errorf("got=%d", l.Len())
+	}
+
+	l.Add("c", "gate c q { z q; }")
+	if l.Len() != 2 {
+		t.Errorf("got=%d", l.Len())
+	}
+
+	if defs, ok := l.Get("c"); !ok || defs != "gate c q { z q; }" {
+		t.Errorf("got=%v, %v", defs, ok)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/mps"
+	"github.com/itsubaki/quasar/pauli"
+)
+
+// simulateMPS runs the program on the matrix product state with the max bond dimension and the truncation threshold of the request.
+// The response has the truncation error and the bond dimension instead of the states.
+func (sim *simulation) simulateMPS(values map[string]float64, inputs map[string]any) (*quasarv1.SimulateResponse_Result, error) {
+	machine := func() *mps.Machine {
+		return &mps.Machine{
+			State: mps.New(0, sim.bond, sim.threshold),
+			Rand:  sim.rng.Float64,
+		}
+	}
+
+	state := machine()
+	c, env, err := sim.run(state, inputs, maxMPS)
+	if err != nil {
+		return nil, err
+	}
+
+	// expectation values
+	index := slices.Concat(c.Index()...)
+	expectations := make([]float64, len(sim.observables))
+	for i, h := range sim.observables {
+		if h.Qubits() != len(index) {
+			return nil, fmt.Errorf("qubits=%d, pauli=%d: %w", len(index), h.Qubits(), pauli.ErrInvalidLength)
+		}
+
+		for _, t := range h {
+			expectations[i] += t.Coef * state.Expectation(t.Pauli, index)
+		}
+	}
+
+	// measurement counts
+	var counts map[string]int32
+	if sim.shots > 0 {
+		// rerun only if the final state depends on the measurement outcomes
+		rerun := slices.ContainsFunc(c.Ops, func(op circuit.Op) bool {
+			return op.Kind == circuit.Measure || op.Kind == circuit.Reset
+		})
+
+		counts = make(map[string]int32)
+		for range sim.shots {
+			if err := sim.ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			sampled, qc := state, c
+			if rerun {
+				sampled = machine()
+				qc, _, err = sim.run(sampled, inputs, maxMPS)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			bits := sampled.Sample(sim.rng.Float64)
+			counts[strings.Join(Registers(bits, qc.Index()), " ")]++
+		}
+	}
+
+	return &quasarv1.SimulateResponse_Result{
+		Inputs:          values,
+		States:          make([]*quasarv1.SimulateResponse_State, 0),
+		Counts:          counts,
+		Classical:       Classical(c, env, sim.decl),
+		Expectations:    expectations,
+		TruncationError: state.TruncationError,
+		BondDimension:   int32(state.BondDimension()),
+	}, nil
+}
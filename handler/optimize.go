@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/optimize"
+)
+
+const maxOptimizeOps = 10000
+
+// Optimize rewrites the program into an OpenQASM 3 program of U gates with the same unitary up to global phase.
+// User-defined gates, subroutines and loops are expanded.
+// The branches on measured bits are kept in their if and while statements.
+func (s *QuasarService) Optimize(
+	ctx context.Context,
+	req *connect.Request[quasarv1.OptimizeRequest],
+) (*connect.Response[quasarv1.OptimizeResponse], error) {
+	if req.Msg.Program == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	c, err := s.compile(ctx, req.Msg.Program, nil,
+		compiler.WithMaxQubits(maxAnalyzeQubits),
+		compiler.WithMaxOps(maxOptimizeOps),
+		compiler.WithBranches(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	optimized := optimize.Optimize(c)
+	return connect.NewResponse(&quasarv1.OptimizeResponse{
+		Code:   circuit.QASM(optimized),
+		Before: Metrics(c),
+		After:  Metrics(optimized),
+	}), nil
+}
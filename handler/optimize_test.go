@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleQuasarService_Optimize() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate t q { U(0, 0, pi/4) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+h q[0];
+t q[0];
+cx q[0], q[1];
+t q[0];
+`
+
+	resp, err := (&handler.QuasarService{}).Optimize(context.Background(), connect.NewRequest(&quasarv1.OptimizeRequest{
+		Program: &quasarv1.Program{Code: code},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(resp.Msg.Code)
+	fmt.Println(resp.Msg.Before.Gates, resp.Msg.Before.Depth)
+	fmt.Println(resp.Msg.After.Gates, resp.Msg.After.Depth)
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit[2] q;
+	//
+	// U(0, 0, pi/2) q[0];
+	// ctrl @ U(pi, 0, pi) q[0], q[1];
+	// map[cx:1 h:2 t:2] 5
+	// map[cx:1 s:1] 2
+}
+
+func TestQuasarService_Optimize(t *testing.T) {
+	cases := []string{
+		"qubit q; U(pi/2, 0, pi) q; U(pi/2, 0, pi) q;",
+		"qubit[2] q; U(0.1, 0.2, 0.3) q; ctrl @ U(0, 0, 0.5) q[0], q[1]; ctrl @ U(0, 0, -0.2) q[1], q[0]; U(0.3, 0.2, 0.1) q[1];",
+		"qubit[3] q; for int i in [0:1] { ctrl @ U(pi, 0, pi) q[i], q[i+1]; U(0, 0, pi/4) q[i]; ctrl @ U(pi, 0, pi) q[i], q[i+1]; }",
+		"qubit[2] q; negctrl @ U(pi, 0, pi) q[0], q[1]; gphase(pi/3); U(pi, 0, pi) q[1]; negctrl @ U(pi, 0, pi) q[0], q[1];",
+		"qubit[2] q; inv @ U(0, 0, pi/2) q[0]; U(0, 0, 0) q[1]; U(0, 0, pi/2) q[0];",
+	}
+
+	svc := &handler.QuasarService{}
+	for _, code := range cases {
+		resp, err := svc.Optimize(context.Background(), connect.NewRequest(&quasarv1.OptimizeRequest{
+			Program: &quasarv1.Program{Code: code},
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+
+		eq, err := svc.Equivalent(context.Background(), connect.NewRequest(&quasarv1.EquivalentRequest{
+			A: &quasarv1.Program{Code: code},
+			B: &quasarv1.Program{Code: resp.Msg.Code},
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", resp.Msg.Code, err)
+		}
+
+		if !eq.Msg.Equivalent {
+			t.Errorf("%s: got=%v", code, resp.Msg.Code)
+		}
+
+		if count(resp.Msg.After.Gates) > count(resp.Msg.Before.Gates) || resp.Msg.After.Depth > resp.Msg.Before.Depth {
+			t.Errorf("%s: before=%v, after=%v", code, resp.Msg.Before, resp.Msg.After)
+		}
+	}
+}
+
+func TestQuasarService_Optimize_branches(t *testing.T) {
+	code := `
+qubit[2] q;
+bit c = measure q[0];
+if (c == 1) {
+    U(pi, 0, pi) q[1];
+    U(pi, 0, pi) q[1];
+    U(0, 0, pi/4) q[0];
+} else {
+    U(0, 0, pi/4) q[0];
+    U(0, 0, pi/4) q[0];
+}
+while (c) {
+    reset q[0];
+    c = measure q[0];
+}
+`
+
+	want := `OPENQASM 3.0;
+
+qubit[2] q;
+bit c;
+
+c = measure q[0];
+if (c==1) {
+    U(0, 0, pi/4) q[0];
+} else {
+    U(0, 0, pi/2) q[0];
+}
+while (c) {
+    reset q[0];
+    c = measure q[0];
+}
+`
+
+	svc := &handler.QuasarService{}
+	resp, err := svc.Optimize(context.Background(), connect.NewRequest(&quasarv1.OptimizeRequest{
+		Program: &quasarv1.Program{Code: code},
+	}))
+	if err != nil {
+		t.Fatalf("optimize: %v", err)
+	}
+
+	if resp.Msg.Code != want {
+		t.Errorf("got=%v, want=%v", resp.Msg.Code, want)
+	}
+
+	again, err := svc.Optimize(context.Background(), connect.NewRequest(&quasarv1.OptimizeRequest{
+		Program: &quasarv1.Program{Code: resp.Msg.Code},
+	}))
+	if err != nil {
+		t.Fatalf("optimize: %v", err)
+	}
+
+	if again.Msg.Code != want {
+		t.Errorf("got=%v, want=%v", again.Msg.Code, want)
+	}
+}
+
+func count(gates map[string]int32) int32 {
+	var n int32
+	for _, v := range gates {
+		n += v
+	}
+
+	return n
+}
+
+func TestQuasarService_Optimize_error(t *testing.T) {
+	cases := []*quasarv1.OptimizeRequest{
+		{},
+		{Program: &quasarv1.Program{}},
+		{Program: &quasarv1.Program{Code: "qubit q; foo q;"}},
+		{Program: &quasarv1.Program{Code: "qubit q; for int i in [0:10000] { U(0, 0, 0) q; }"}},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{}).Optimize(context.Background(), connect.NewRequest(c)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
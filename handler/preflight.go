@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"connectrpc.com/connect"
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+)
+
+var ErrResourceExhausted = errors.New("resource exhausted")
+
+// workload is what a program needs to run.
+type workload struct {
+	qubits int               // max qubits, or 0 for no limit
+	memory func(n int) int64 // memory of the state of n qubits
+	runs   int               // the program runs once per shot of each sweep point
+	bond   int               // max bond dimension of the matrix product state, or 0
+}
+
+// backend returns the workload of the backend.
+// The qubits are limited by the backend, or by the service for the state vector and the density matrix.
+// The matrix product state has bonds of up to the bond dimension.
+func (s *QuasarService) backend(backend quasarv1.Backend, bond, runs int) workload {
+	switch backend {
+	case quasarv1.Backend_BACKEND_STABILIZER:
+		return workload{qubits: maxClifford, memory: tableauBytes, runs: runs}
+	case quasarv1.Backend_BACKEND_MPS:
+		return workload{qubits: maxMPS, memory: func(n int) int64 { return mpsBytes(n, bond) }, runs: runs, bond: bond}
+	case quasarv1.Backend_BACKEND_DENSITY_MATRIX:
+		return workload{qubits: s.MaxQubits, memory: matrixBytes, runs: runs}
+	default:
+		return workload{qubits: s.MaxQubits, memory: stateBytes, runs: runs}
+	}
+}
+
+// preflight estimates the resources of the program from its declarations and loop bounds,
+// and returns an error if they exceed the limits of the workload or the service before anything runs.
+// The operations are counted once per run.
+// Each operation on two or more qubits of the matrix product state costs SVDs of bond^3.
+func (s *QuasarService) preflight(program antlr.Tree, inputs map[string]any, w workload) error {
+	r := compiler.Estimate(program, compiler.WithInputs(inputs))
+	if w.qubits > 0 && r.Qubits > w.qubits {
+		return fmt.Errorf("need=%d, max=%d: %w", r.Qubits, w.qubits, compiler.ErrTooManyQubits)
+	}
+
+	if bytes := w.memory(r.Qubits); s.MaxBytes > 0 && bytes > s.MaxBytes {
+		return fmt.Errorf("need=%d bytes for %d qubits, max=%d: %w", bytes, r.Qubits, s.MaxBytes, ErrResourceExhausted)
+	}
+
+	runs := max(w.runs, 1)
+	if ops := compiler.Mul(r.Ops, runs); s.MaxOps > 0 && ops > s.MaxOps {
+		return fmt.Errorf("need=%d operations, max=%d: %w", ops, s.MaxOps, ErrResourceExhausted)
+	}
+
+	if work := compiler.Mul(compiler.Mul(r.Multi, runs), w.bond*w.bond*w.bond); w.bond > 0 && work > maxMPSWork {
+		return fmt.Errorf("need=%d bond^3 multi-qubit operations, max=%d: %w", work, maxMPSWork, ErrResourceExhausted)
+	}
+
+	return nil
+}
+
+// tableauBytes returns the memory of the tableau of n qubits, 2n rows of 2n bits and a sign.
+func tableauBytes(n int) int64 {
+	rows := int64(2 * n)
+	return rows * (rows + 1)
+}
+
+// mpsBytes returns the memory of the matrix product state of n qubits, n tensors of 2 * bond^2 amplitudes.
+func mpsBytes(n, bond int) int64 {
+	return int64(n) * 32 * int64(bond) * int64(bond)
+}
+
+// matrixBytes returns the memory of the density matrix or the unitary of n qubits, the state vector of 2n qubits.
+func matrixBytes(n int) int64 {
+	return stateBytes(2 * min(n, math.MaxInt/2))
+}
+
+// stateBytes returns the memory of the state vector of n qubits, 2^n * 16 bytes, or math.MaxInt64 if it overflows.
+func stateBytes(n int) int64 {
+	if n > 58 {
+		return math.MaxInt64
+	}
+
+	return 16 << n
+}
+
+// errorCode returns CodeResourceExhausted if the limits of the service are exceeded, or CodeInvalidArgument otherwise.
+func errorCode(err error) connect.Code {
+	if errors.Is(err, ErrResourceExhausted) {
+		return connect.CodeResourceExhausted
+	}
+
+	return connect.CodeInvalidArgument
+}
@@ -0,0 +1,129 @@
+package handler_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func TestQuasarService_Simulate_preflight(t *testing.T) {
+	cases := []struct {
+		svc     *handler.QuasarService
+		code    string
+		backend quasarv1.Backend
+		shots   int32
+		sweep   int
+		bond    *int32
+		want    connect.Code
+		errMsg  string
+	}{
+		{
+			svc:    &handler.QuasarService{MaxQubits: 10},
+			code:   "qubit[11] q; U(0, 0, pi/4) q[0];",
+			want:   connect.CodeInvalidArgument,
+			errMsg: "need=11, max=10: too many qubits",
+		},
+		{
+			svc:     &handler.QuasarService{},
+			code:    "qubit[1001] q; U(0, 0, pi) q[0];",
+			backend: quasarv1.Backend_BACKEND_STABILIZER,
+			want:    connect.CodeInvalidArgument,
+			errMsg:  "need=1001, max=1000: too many qubits",
+		},
+		{
+			svc:     &handler.QuasarService{MaxBytes: 1024},
+			code:    "qubit[2] q;",
+			backend: quasarv1.Backend_BACKEND_MPS,
+			want:    connect.CodeResourceExhausted,
+			errMsg:  "need=262144 bytes for 2 qubits",
+		},
+		{
+			svc:    &handler.QuasarService{MaxBytes: 1024},
+			code:   "qubit[7] q; U(0, 0, pi/4) q[0];",
+			want:   connect.CodeResourceExhausted,
+			errMsg: "need=2048 bytes for 7 qubits",
+		},
+		{
+			svc:  &handler.QuasarService{MaxBytes: 1024},
+			code: "qubit[6] q;",
+		},
+		{
+			svc:     &handler.QuasarService{MaxBytes: 1024},
+			code:    "qubit[4] q;",
+			backend: quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+			want:    connect.CodeResourceExhausted,
+		},
+		{
+			svc:  &handler.QuasarService{MaxOps: 100},
+			code: "qubit q; for int i in [0:100] { U(0, 0, 0) q; }",
+			want: connect.CodeResourceExhausted,
+		},
+		{
+			svc:  &handler.QuasarService{MaxOps: 100},
+			code: "qubit q; for int i in [0:99] { U(0, 0, 0) q; }",
+		},
+		{
+			svc:    &handler.QuasarService{MaxOps: 100},
+			code:   "qubit q; for int i in [0:10] { U(0, 0, 0) q; } measure q;",
+			shots:  10,
+			want:   connect.CodeResourceExhausted,
+			errMsg: "operations, max=100",
+		},
+		{
+			svc:    &handler.QuasarService{MaxOps: 100},
+			code:   "qubit q; for int i in [0:10] { U(0, 0, 0) q; }",
+			sweep:  10,
+			want:   connect.CodeResourceExhausted,
+			errMsg: "operations, max=100",
+		},
+		{
+			svc:     &handler.QuasarService{},
+			code:    "qubit[2] q; for int i in [0:256] { ctrl @ U(pi, 0, pi) q[0], q[1]; }",
+			backend: quasarv1.Backend_BACKEND_MPS,
+			bond:    new(int32(256)),
+			want:    connect.CodeResourceExhausted,
+			errMsg:  "bond^3 multi-qubit operations",
+		},
+		{
+			svc:     &handler.QuasarService{},
+			code:    "qubit[2] q; for int i in [0:256] { ctrl @ U(pi, 0, pi) q[0], q[1]; }",
+			backend: quasarv1.Backend_BACKEND_MPS,
+			bond:    new(int32(16)),
+		},
+		{
+			svc:  &handler.QuasarService{MaxBytes: 1024},
+			code: "qubit[100000000] q; U(0, 0, 0) q[0];",
+			want: connect.CodeResourceExhausted,
+		},
+	}
+
+	for _, c := range cases {
+		sweep := make([]*quasarv1.SimulateRequest_Inputs, c.sweep)
+		for i := range sweep {
+			sweep[i] = &quasarv1.SimulateRequest_Inputs{}
+		}
+
+		_, err := c.svc.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:             c.code,
+			Backend:          c.backend,
+			Shots:            new(c.shots),
+			Sweep:            sweep,
+			MaxBondDimension: c.bond,
+		}))
+		if c.want == 0 {
+			if err != nil {
+				t.Errorf("%s: %v", c.code, err)
+			}
+
+			continue
+		}
+
+		if connect.CodeOf(err) != c.want || !strings.Contains(err.Error(), c.errMsg) {
+			t.Errorf("%s: got=%v", c.code, err)
+		}
+	}
+}
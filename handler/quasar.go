@@ -8,33 +8,55 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"strings"
 	"time"
 
 	"connectrpc.com/connect"
-	"github.com/itsubaki/q"
-	"github.com/itsubaki/qasm/environ"
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/itsubaki/qasm/listener"
 	"github.com/itsubaki/qasm/parser"
-	"github.com/itsubaki/qasm/visitor"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	"github.com/itsubaki/quasar/density"
 	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/include"
+	"github.com/itsubaki/quasar/lexer"
+	"github.com/itsubaki/quasar/pauli"
+	"github.com/itsubaki/quasar/qasm2"
 	"github.com/itsubaki/quasar/store"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	salt    = "quasar salt\n"
-	maxSize = 64 * 1024
+	salt         = "quasar salt\n"
+	maxSize      = 64 * 1024
+	maxShots     = 10000
+	maxSweep     = 100
+	maxTotal     = 100000 // shots over all sweep points
+	maxPrecision = 15
+	maxClifford  = 1000
+	maxMPS       = 64
+	maxBond      = 256
+	maxMPSWork   = 1 << 32 // bond^3 times the operations on two or more qubits
 )
 
 var (
-	scale   = math.Pow(10, 6)
-	epsilon = 1e-6
+	precision = 6
+	epsilon   = 1e-6
+	bond      = 64
 )
 
 var (
 	ErrQubitsNotFound     = errors.New("qubits not found")
 	ErrCodeNotFound       = errors.New("code not found")
+	ErrInvalidShots       = errors.New("invalid shots")
+	ErrInvalidPrecision   = errors.New("invalid precision")
+	ErrInvalidEpsilon     = errors.New("invalid epsilon")
+	ErrNoiseNotSupported  = errors.New("noise is only supported by the density matrix backend")
+	ErrInvalidBond        = errors.New("invalid bond dimension")
+	ErrInvalidThreshold   = errors.New("invalid truncation threshold")
 	ErrIDNotFound         = errors.New("id not found")
 	ErrNoSuchEntity       = errors.New("no such entity")
 	ErrSomethingWentWrong = errors.New("something went wrong")
@@ -50,9 +72,17 @@ type Store interface {
 	Get(ctx context.Context, id string) (*store.Snippet, error)
 }
 
+// QuasarService serves the quasar API.
+// MaxQubits, MaxBytes and MaxOps limit the qubits, the memory of the state and the operations of a simulation, or 0 for no limit.
 type QuasarService struct {
 	MaxQubits int
+	MaxBytes  int64
+	MaxOps    int
+	Includes  map[string]string
+	Library   Library
 	Store     Store
+	Pool      *Pool
+	Sessions  Sessions
 }
 
 func (s *QuasarService) Simulate(
@@ -63,57 +93,225 @@ func (s *QuasarService) Simulate(
 		return nil, connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
 	}
 
-	program, err := parser.Parse(req.Msg.Code)
+	shots := int(req.Msg.GetShots())
+	if shots < 0 || shots > maxShots {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("shots must be between 0 and %d: %w", maxShots, ErrInvalidShots))
+	}
+
+	r, err := newRounding(req.Msg.Precision, req.Msg.Epsilon)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	// quantum simulator
-	qsim := q.New()
-	env := environ.New()
-	v := visitor.New(qsim, env,
-		visitor.WithMaxQubits(s.MaxQubits),
-	)
+	if len(req.Msg.Sweep) > maxSweep {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("sweep size exceeds %d", maxSweep))
+	}
 
-	if err := v.Run(program); err != nil {
+	if len(req.Msg.Sweep)*shots > maxTotal {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("sweep size times shots exceeds %d: %w", maxTotal, ErrInvalidShots))
+	}
+
+	noise := Noise(req.Msg.Noise)
+	if err := noise.Validate(); err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	if len(env.Qubit) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	maxBondDimension := bond
+	if req.Msg.MaxBondDimension != nil {
+		maxBondDimension = int(req.Msg.GetMaxBondDimension())
+	}
+
+	if maxBondDimension < 1 || maxBondDimension > maxBond {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("max bond dimension must be between 1 and %d: %w", maxBond, ErrInvalidBond))
 	}
 
-	round := func(v float64) float64 {
-		return math.Round(v*scale) / scale
+	threshold := req.Msg.GetTruncationThreshold()
+	if threshold < 0 || threshold >= 1 || math.IsNaN(threshold) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("truncation threshold must be in [0, 1): %w", ErrInvalidThreshold))
+	}
+
+	backend := req.Msg.Backend
+	if req.Msg.Noise != nil && backend != quasarv1.Backend_BACKEND_DENSITY_MATRIX {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrNoiseNotSupported)
+	}
+
+	if req.Msg.Analysis != nil && (backend == quasarv1.Backend_BACKEND_STABILIZER || backend == quasarv1.Backend_BACKEND_MPS) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrAnalysisNotSupported)
+	}
+
+	observables := make([]pauli.Hamiltonian, len(req.Msg.Observables))
+	for i, o := range req.Msg.Observables {
+		h, err := pauli.Parse(o)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		observables[i] = h
+	}
+
+	code, err := s.source(ctx, req.Msg.Code, req.Msg.Includes, req.Msg.Imports)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	decl := Declared(program)
+
+	seed := rand.Uint64()
+	if req.Msg.Seed != nil {
+		seed = req.Msg.GetSeed()
+	}
+
+	sim := &simulation{
+		ctx:         ctx,
+		req:         req.Msg,
+		program:     program,
+		decl:        decl,
+		rng:         rand.New(rand.NewPCG(seed, seed)), // random source shared by the simulator and sampling
+		r:           r,
+		noise:       noise,
+		observables: observables,
+		shots:       shots,
+		bond:        maxBondDimension,
+		threshold:   threshold,
+		maxQubits:   s.MaxQubits,
+		maxOps:      s.MaxOps,
+	}
+
+	// the program may run once per shot of each sweep point
+	runs := max(shots, 1) * max(len(req.Msg.Sweep), 1)
+
+	simulate := func(values map[string]float64) (*quasarv1.SimulateResponse_Result, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		inputs, err := Bind(decl, values)
+		if err != nil {
+			return nil, err
+		}
+
+		backend := backend
+		if err := s.preflight(program, inputs, s.backend(backend, maxBondDimension, runs)); err != nil {
+			// clifford circuits too large for the state vector
+			if !s.clifford(sim, backend, inputs, runs) {
+				return nil, err
+			}
+
+			backend = quasarv1.Backend_BACKEND_STABILIZER
+		}
+
+		switch backend {
+		case quasarv1.Backend_BACKEND_DENSITY_MATRIX:
+			return sim.simulateDensity(values, inputs)
+		case quasarv1.Backend_BACKEND_MPS:
+			return sim.simulateMPS(values, inputs)
+		case quasarv1.Backend_BACKEND_STABILIZER:
+			return sim.simulateStabilizer(values, inputs)
+		default:
+			return sim.simulateStatevector(values, inputs)
+		}
 	}
 
-	// quantum state
-	qstate := qsim.Qubit().State(env.Index()...)
+	// parameter sweep
+	if len(req.Msg.Sweep) > 0 {
+		sweep := make([]*quasarv1.SimulateResponse_Result, len(req.Msg.Sweep))
+		for i, in := range req.Msg.Sweep {
+			result, err := simulate(in.Values)
+			if ctx.Err() != nil {
+				return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+			}
+
+			if err != nil {
+				return nil, connect.NewError(errorCode(err), err)
+			}
 
-	// build response
-	states := make([]*quasarv1.SimulateResponse_State, 0)
-	for _, s := range qstate {
-		prob := round(s.Probability())
-		if prob < epsilon {
-			continue
+			sweep[i] = result
 		}
 
-		// prob > 0.000000
-		states = append(states, &quasarv1.SimulateResponse_State{
-			BinaryString: s.BinaryString(),
-			Probability:  prob,
-			Amplitude: &quasarv1.SimulateResponse_Amplitude{
-				Real: round(real(s.Amplitude())),
-				Imag: round(imag(s.Amplitude())),
-			},
-		})
+		return connect.NewResponse(&quasarv1.SimulateResponse{
+			Sweep: sweep,
+		}), nil
+	}
+
+	result, err := simulate(req.Msg.Inputs)
+	if ctx.Err() != nil {
+		return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+	}
+
+	if err != nil {
+		return nil, connect.NewError(errorCode(err), err)
 	}
 
 	return connect.NewResponse(&quasarv1.SimulateResponse{
-		States: states,
+		States:          result.States,
+		Counts:          result.Counts,
+		Classical:       result.Classical,
+		Expectations:    result.Expectations,
+		Packed:          result.Packed,
+		Diagonal:        result.Diagonal,
+		DensityMatrix:   result.DensityMatrix,
+		Stabilizers:     result.Stabilizers,
+		TruncationError: result.TruncationError,
+		BondDimension:   result.BondDimension,
+		Analysis:        result.Analysis,
 	}), nil
 }
 
+// simulation is a Simulate request. Each backend runs it in its own file.
+type simulation struct {
+	ctx         context.Context
+	req         *quasarv1.SimulateRequest
+	program     antlr.Tree
+	decl        *Declarations
+	rng         *rand.Rand
+	r           *rounding
+	noise       density.Noise
+	observables []pauli.Hamiltonian
+	shots       int
+	bond        int     // max bond dimension of the matrix product state
+	threshold   float64 // truncation threshold of the matrix product state
+	maxQubits   int
+	maxOps      int
+}
+
+// run interprets the program with the compiler, running the operations on the machine as they are emitted.
+func (sim *simulation) run(m compiler.Machine, inputs map[string]any, maxQubits int) (*circuit.Circuit, *compiler.Env, error) {
+	cc := compiler.New(
+		compiler.WithContext(sim.ctx),
+		compiler.WithInputs(inputs),
+		compiler.WithMaxQubits(maxQubits),
+		compiler.WithMaxOps(sim.maxOps),
+		compiler.WithMachine(m),
+	)
+
+	c, err := cc.Compile(sim.program)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, nil, ErrQubitsNotFound
+	}
+
+	return c, cc.Env(), nil
+}
+
+// state returns the basis state of the amplitude rounded to the precision of the request.
+func (sim *simulation) state(binaryString []string, amp complex128, prob float64) *quasarv1.SimulateResponse_State {
+	return &quasarv1.SimulateResponse_State{
+		BinaryString: binaryString,
+		Probability:  sim.r.round(prob),
+		Amplitude: &quasarv1.SimulateResponse_Amplitude{
+			Real: sim.r.round(real(amp)),
+			Imag: sim.r.round(imag(amp)),
+		},
+	}
+}
+
 func (s *QuasarService) Share(
 	ctx context.Context,
 	req *connect.Request[quasarv1.ShareRequest],
@@ -173,6 +371,67 @@ func (s *QuasarService) Edit(
 	}), nil
 }
 
+// source returns the code as an OpenQASM 3 program for the parser.
+// OpenQASM 2 programs are translated, the shared snippets of the imports are included,
+// and the includes are resolved from the server libraries, the shared snippets and then the files of the request.
+func (s *QuasarService) source(ctx context.Context, code string, includes map[string]string, ids []string) (string, error) {
+	var size int
+	for _, v := range includes {
+		size += len(v)
+	}
+
+	if size > maxSize {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("includes size exceeds %d bytes", maxSize))
+	}
+
+	names, err := imports(ids)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	code, err = qasm2.Translate(code)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	code, err = include.ResolveFunc(include.Import(code, names...), s.lookup(ctx, includes))
+	if err != nil {
+		if connectErr, ok := errors.AsType[*connect.Error](err); ok {
+			return "", connectErr
+		}
+
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return code, nil
+}
+
+// snippet returns the code, or the code of the shared snippet if code is empty.
+func (s *QuasarService) snippet(ctx context.Context, code, id string) (string, error) {
+	if len(strings.TrimSpace(code)) == 0 && len(id) > 0 {
+		snippet, err := s.Store.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNoSuchEntity) {
+				return "", connect.NewError(connect.CodeNotFound, ErrNoSuchEntity)
+			}
+
+			return "", connect.NewError(connect.CodeInternal, ErrSomethingWentWrong)
+		}
+
+		code = snippet.Code
+	}
+
+	if len(strings.TrimSpace(code)) == 0 {
+		return "", connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
+	}
+
+	if len(code) > maxSize {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("code size exceeds %d bytes", maxSize))
+	}
+
+	return code, nil
+}
+
 func (s *QuasarService) Validate(
 	ctx context.Context,
 	req *connect.Request[quasarv1.ValidateRequest],
@@ -181,24 +440,134 @@ func (s *QuasarService) Validate(
 		return nil, connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
 	}
 
-	if _, err := parser.Parse(req.Msg.Code); err != nil {
+	version := qasm2.Version(req.Msg.Code)
+	code, err := s.source(ctx, req.Msg.Code, req.Msg.Includes, req.Msg.Imports)
+	if sourceErr, ok := errors.AsType[*lexer.Error](err); ok {
+		return connect.NewResponse(&quasarv1.ValidateResponse{
+			Valid:   false,
+			Line:    new(int32(sourceErr.Line)),
+			Column:  new(int32(sourceErr.Column)),
+			Message: &sourceErr.Message,
+			Version: version,
+		}), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := parser.Parse(code); err != nil {
 		if syntaxErr, ok := errors.AsType[*listener.SyntaxError](err); ok {
 			return connect.NewResponse(&quasarv1.ValidateResponse{
 				Valid:   false,
 				Line:    new(int32(syntaxErr.Line)),
 				Column:  new(int32(syntaxErr.Column)),
 				Message: &syntaxErr.Message,
+				Version: version,
 			}), nil
 		}
 
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	if len(req.Msg.CouplingMap) > 0 {
+		resp, err := s.validateRouting(ctx, &quasarv1.Program{Code: req.Msg.Code, Includes: req.Msg.Includes, Imports: req.Msg.Imports}, req.Msg.CouplingMap)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Version = version
+		return connect.NewResponse(resp), nil
+	}
+
 	return connect.NewResponse(&quasarv1.ValidateResponse{
-		Valid: true,
+		Valid:   true,
+		Version: version,
 	}), nil
 }
 
+// Sample returns the index of the basis state sampled from the probabilities.
+func Sample(probabilities []float64, r float64) int {
+	var acc float64
+	for k, p := range probabilities {
+		acc += p
+		if r < acc {
+			return k
+		}
+	}
+
+	return len(probabilities) - 1
+}
+
+// terminal returns true if the measurements do not change the distribution of the final state,
+// so that the shots can be sampled from a single run of the circuit without the measurements.
+// The program must not read a measured bit or reset a qubit, and a measured qubit must not be used afterwards.
+func terminal(c *circuit.Circuit, env *compiler.Env) bool {
+	if env.Feedback {
+		return false
+	}
+
+	measured := make(map[int]bool)
+	for _, op := range c.Ops {
+		switch op.Kind {
+		case circuit.Reset:
+			return false
+		case circuit.Measure:
+			measured[op.Target] = true
+		case circuit.Gate:
+			if slices.ContainsFunc(op.Operands(), func(q int) bool { return measured[q] }) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// unmeasured returns the circuit without the measurements.
+func unmeasured(c *circuit.Circuit) *circuit.Circuit {
+	out := *c
+	out.Ops = slices.DeleteFunc(slices.Clone(c.Ops), func(op circuit.Op) bool {
+		return op.Kind == circuit.Measure
+	})
+
+	return &out
+}
+
+func BinaryString(k, n int, index [][]int) []string {
+	out := make([]string, len(index))
+	for i, reg := range index {
+		var sb strings.Builder
+		for _, j := range reg {
+			if k&(1<<(n-1-j)) != 0 {
+				sb.WriteByte('1')
+				continue
+			}
+
+			sb.WriteByte('0')
+		}
+
+		out[i] = sb.String()
+	}
+
+	return out
+}
+
+// Registers returns the binary string of each register from the bits of each qubit.
+func Registers(bits []int, index [][]int) []string {
+	out := make([]string, len(index))
+	for i, reg := range index {
+		var sb strings.Builder
+		for _, j := range reg {
+			sb.WriteByte(byte('0' + bits[j]))
+		}
+
+		out[i] = sb.String()
+	}
+
+	return out
+}
+
 func GenID(code string, length int) (string, error) {
 	hash := sha256.New()
 	if _, err := io.WriteString(hash, salt); err != nil {
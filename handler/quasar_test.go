@@ -2,14 +2,21 @@ package handler_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"math/cmplx"
+	"slices"
 	"strings"
 	"testing"
 
 	"connectrpc.com/connect"
 	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
 	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/packed"
 	"github.com/itsubaki/quasar/store"
+	"google.golang.org/protobuf/proto"
 )
 
 func ExampleQuasarService_Simulate() {
@@ -70,17 +77,367 @@ qft(q);
 	// [111] (+0.2500 -0.2500): 0.1250
 }
 
+func ExampleQuasarService_Simulate_shots() {
+	code := `
+	OPENQASM 3.0;
+
+gate x q { U(pi, 0, pi) q; }
+
+qubit[2] q;
+x q[0];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:  code,
+		Shots: new(int32(10)),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.Counts)
+
+	// Output:
+	// map[10:10]
+}
+
+func ExampleQuasarService_Simulate_sweep() {
+	code := `
+	OPENQASM 3.0;
+
+input angle theta;
+
+qubit q;
+U(theta, 0, 0) q;
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code: code,
+		Sweep: []*quasarv1.SimulateRequest_Inputs{
+			{Values: map[string]float64{"theta": 0}},
+			{Values: map[string]float64{"theta": math.Pi}},
+		},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, r := range resp.Msg.Sweep {
+		for _, s := range r.States {
+			fmt.Printf("%.4f: %s %.4f\n", r.Inputs["theta"], s.BinaryString, s.Probability)
+		}
+	}
+
+	// Output:
+	// 0.0000: [0] 1.0000
+	// 3.1416: [1] 1.0000
+}
+
+func ExampleQuasarService_Simulate_observables() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+cx q[0], q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code: code,
+		Observables: []string{
+			"ZZ",
+			"0.5*XX + 0.5*ZI",
+			"YY",
+		},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, e := range resp.Msg.Expectations {
+		fmt.Printf("%.4f\n", e)
+	}
+
+	// Output:
+	// 1.0000
+	// 0.5000
+	// -1.0000
+}
+
+func ExampleQuasarService_Simulate_dense() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+cx q[0], q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:      code,
+		Dense:     true,
+		Precision: new(int32(2)),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range resp.Msg.States {
+		fmt.Println(s.BinaryString, s.Amplitude.Real, s.Probability)
+	}
+
+	// Output:
+	// [00] 0.71 0.5
+	// [01] 0 0
+	// [10] 0 0
+	// [11] 0.71 0.5
+}
+
+func ExampleQuasarService_Simulate_qasm2() {
+	code := `
+OPENQASM 2.0;
+include "qelib1.inc";
+
+qreg q[2];
+h q[0];
+cx q[0], q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:      code,
+		Precision: new(int32(2)),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range resp.Msg.States {
+		fmt.Println(s.BinaryString, s.Amplitude.Real, s.Probability)
+	}
+
+	// Output:
+	// [00] 0.71 0.5
+	// [11] 0.71 0.5
+}
+
+func ExampleQuasarService_Simulate_includes() {
+	code := `
+OPENQASM 3.0;
+include "stdgates.inc";
+include "bell.inc";
+
+qubit[2] q;
+bell q[0], q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:      code,
+		Precision: new(int32(2)),
+		Includes: map[string]string{
+			"bell.inc": "gate bell a, b { h a; cx a, b; }",
+		},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range resp.Msg.States {
+		fmt.Println(s.BinaryString, s.Amplitude.Real, s.Probability)
+	}
+
+	// Output:
+	// [00] 0.71 0.5
+	// [11] 0.71 0.5
+}
+
+func ExampleQuasarService_Simulate_packed() {
+	code := `
+	OPENQASM 3.0;
+
+gate x q { U(pi, 0, pi) q; }
+
+qubit[2] q;
+x q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:   code,
+		Packed: true,
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	amp, err := packed.Decode(resp.Msg.Packed)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(resp.Msg.States))
+	for _, a := range amp {
+		fmt.Printf("%.4f\n", cmplx.Abs(a))
+	}
+
+	// Output:
+	// 0
+	// 0.0000
+	// 1.0000
+	// 0.0000
+	// 0.0000
+}
+
+func ExampleQuasarService_Simulate_density() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+cx q[0], q[1];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:    code,
+		Backend: quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+		Noise: &quasarv1.NoiseModel{
+			Depolarizing: 0.1,
+		},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range resp.Msg.States {
+		fmt.Printf("%s: %.4f\n", s.BinaryString, s.Probability)
+	}
+	fmt.Println(resp.Msg.Diagonal)
+
+	// Output:
+	// [00]: 0.4378
+	// [01]: 0.0622
+	// [10]: 0.0622
+	// [11]: 0.4378
+	// [0.437778 0.062222 0.062222 0.437778]
+}
+
+func ExampleQuasarService_Simulate_stabilizer() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[3] q;
+h q[0];
+cx q[0], q[1];
+cx q[1], q[2];
+	`
+
+	service := &handler.QuasarService{}
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:        code,
+		Backend:     quasarv1.Backend_BACKEND_STABILIZER,
+		Shots:       new(int32(100)),
+		Observables: []string{"ZZI", "XXX", "ZII"},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.Stabilizers)
+	fmt.Println(resp.Msg.Expectations)
+	for _, k := range slices.Sorted(maps.Keys(resp.Msg.Counts)) {
+		fmt.Println(k)
+	}
+
+	// Output:
+	// [+XXX +ZZI +IZZ]
+	// [1 1 0]
+	// 000
+	// 111
+}
+
+func ExampleQuasarService_Simulate_mps() {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[40] q;
+h q[0];
+for int i in [0:38] {
+    cx q[i], q[i+1];
+}
+	`
+
+	service := &handler.QuasarService{
+		MaxQubits: 10,
+	}
+
+	resp, err := service.Simulate(context.Background(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:             code,
+		Backend:          quasarv1.Backend_BACKEND_MPS,
+		MaxBondDimension: new(int32(8)),
+		Observables:      []string{strings.Repeat("Z", 40), strings.Repeat("X", 40)},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(resp.Msg.BondDimension)
+	fmt.Println(resp.Msg.TruncationError)
+	fmt.Printf("%.4f\n", resp.Msg.Expectations)
+
+	// Output:
+	// 2
+	// 0
+	// [1.0000 1.0000]
+}
+
+func ExampleBinaryString() {
+	// |0110> with registers q[0, 1] and r[2, 3]
+	fmt.Println(handler.BinaryString(0b0110, 4, [][]int{{0, 1}, {2, 3}}))
+
+	// Output:
+	// [01 10]
+}
+
 func TestQuasarService_Simulate(t *testing.T) {
 	cases := []struct {
-		code   string
-		errMsg string
+		code        string
+		shots       int32
+		observables []string
+		precision   *int32
+		epsilon     *float64
+		backend     quasarv1.Backend
+		noise       *quasarv1.NoiseModel
+		bond        *int32
+		threshold   *float64
+		errMsg      string
 	}{
 		{
 			code:   "invalid",
 			errMsg: "invalid_argument: 1:7: no viable alternative at input 'invalid'",
 		},
 		{
-			code:   "qubit[12] q;",
+			code:   "qubit[12] q; U(0, 0, pi/4) q;",
 			errMsg: "invalid_argument: need=12, max=10: too many qubits",
 		},
 		{
@@ -95,6 +452,77 @@ func TestQuasarService_Simulate(t *testing.T) {
 			code:   "OPENQASM 3.0;",
 			errMsg: "invalid_argument: qubits not found",
 		},
+		{
+			code:   "input angle theta; qubit q;",
+			errMsg: "invalid_argument: theta: input not bound",
+		},
+		{
+			code:        "qubit q;",
+			observables: []string{"ZZ"},
+			errMsg:      "invalid_argument: qubits=1, pauli=2: invalid length",
+		},
+		{
+			code:        "qubit q;",
+			observables: []string{"0.5*"},
+			errMsg:      "invalid_argument: 0.5*: invalid term",
+		},
+		{
+			code:      "qubit q;",
+			precision: new(int32(16)),
+			errMsg:    "invalid_argument: precision must be between 0 and 15: invalid precision",
+		},
+		{
+			code:    "qubit q;",
+			epsilon: new(-1.0),
+			errMsg:  "invalid_argument: epsilon must not be negative: invalid epsilon",
+		},
+		{
+			code:   "qubit q;",
+			shots:  -1,
+			errMsg: "invalid_argument: shots must be between 0 and 10000: invalid shots",
+		},
+		{
+			code:   "qubit q;",
+			shots:  10001,
+			errMsg: "invalid_argument: shots must be between 0 and 10000: invalid shots",
+		},
+		{
+			code:   "qubit q;",
+			noise:  &quasarv1.NoiseModel{Depolarizing: 0.1},
+			errMsg: "invalid_argument: noise is only supported by the density matrix backend",
+		},
+		{
+			code:    "qubit q;",
+			backend: quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+			noise:   &quasarv1.NoiseModel{BitFlip: 1.5},
+			errMsg:  "invalid_argument: bit_flip=1.5: invalid probability",
+		},
+		{
+			code:    "qubit[6] q;",
+			backend: quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+			errMsg:  "invalid_argument: need=12, max=10: too many qubits",
+		},
+		{
+			code:    "qubit q;",
+			backend: quasarv1.Backend_BACKEND_MPS,
+			bond:    new(int32(0)),
+			errMsg:  "invalid_argument: max bond dimension must be between 1 and 256: invalid bond dimension",
+		},
+		{
+			code:      "qubit q;",
+			backend:   quasarv1.Backend_BACKEND_MPS,
+			threshold: new(1.0),
+			errMsg:    "invalid_argument: truncation threshold must be in [0, 1): invalid truncation threshold",
+		},
+		{
+			code:    "qubit[65] q;",
+			backend: quasarv1.Backend_BACKEND_MPS,
+			errMsg:  "invalid_argument: need=65, max=64: too many qubits",
+		},
+		{
+			code:   "include \"foo.inc\";\nqubit q;",
+			errMsg: `invalid_argument: line 1:8 include "foo.inc" not found`,
+		},
 	}
 
 	svc := &handler.QuasarService{
@@ -104,7 +532,15 @@ func TestQuasarService_Simulate(t *testing.T) {
 
 	for _, c := range cases {
 		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
-			Code: c.code,
+			Code:                c.code,
+			Shots:               new(c.shots),
+			Observables:         c.observables,
+			Precision:           c.precision,
+			Epsilon:             c.epsilon,
+			Backend:             c.backend,
+			Noise:               c.noise,
+			MaxBondDimension:    c.bond,
+			TruncationThreshold: c.threshold,
 		}))
 		if err != nil && err.Error() == c.errMsg {
 			continue
@@ -114,6 +550,222 @@ func TestQuasarService_Simulate(t *testing.T) {
 	}
 }
 
+func TestQuasarService_Simulate_seed(t *testing.T) {
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+
+qubit[3] q;
+bit[3] c;
+h q;
+c = measure q;
+	`
+
+	svc := &handler.QuasarService{
+		MaxQubits: 10,
+		Store:     &store.MemoryStore{},
+	}
+
+	simulate := func(seed uint64) *quasarv1.SimulateResponse {
+		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:  code,
+			Shots: new(int32(100)),
+			Seed:  new(seed),
+		}))
+		if err != nil {
+			t.Fatalf("simulate: %v", err)
+		}
+
+		return resp.Msg
+	}
+
+	for _, seed := range []uint64{0, 1, 42} {
+		got, want := simulate(seed), simulate(seed)
+		if !proto.Equal(got, want) {
+			t.Errorf("seed=%d: got=%v, want=%v", seed, got, want)
+		}
+	}
+}
+
+func TestQuasarService_Simulate_sweepShots(t *testing.T) {
+	sweep := make([]*quasarv1.SimulateRequest_Inputs, 11)
+	for i := range sweep {
+		sweep[i] = &quasarv1.SimulateRequest_Inputs{}
+	}
+
+	svc := &handler.QuasarService{}
+	_, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+		Code:  "qubit q;",
+		Shots: new(int32(10000)),
+		Sweep: sweep,
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument || !errors.Is(err, handler.ErrInvalidShots) {
+		t.Errorf("got=%v", err)
+	}
+}
+
+func TestQuasarService_Simulate_shots(t *testing.T) {
+	cases := []struct {
+		code string
+		want []string
+	}{
+		// terminal measurements
+		{"qubit[2] q; bit[2] c; U(pi/2, 0, pi) q[0]; c = measure q;", []string{"00", "10"}},
+		// a gate after the measurement
+		{"qubit q; bit c; U(pi/2, 0, pi) q; c = measure q; U(pi, 0, pi) q;", []string{"0", "1"}},
+		// a reset
+		{"qubit q; U(pi/2, 0, pi) q; reset q;", []string{"0"}},
+	}
+
+	svc := &handler.QuasarService{}
+	for _, c := range cases {
+		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:  c.code,
+			Shots: new(int32(1000)),
+			Seed:  new(uint64(1)),
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", c.code, err)
+		}
+
+		var total int32
+		for _, k := range c.want {
+			total += resp.Msg.Counts[k]
+		}
+
+		if total != 1000 || len(resp.Msg.Counts) != len(c.want) {
+			t.Errorf("%s: got=%v", c.code, resp.Msg.Counts)
+		}
+	}
+}
+
+func TestQuasarService_Simulate_feedback(t *testing.T) {
+	code := `
+	OPENQASM 3.0;
+
+qubit q;
+bit c;
+int n = 0;
+U(pi, 0, pi) q;
+c = measure q;
+if (c == 1) {
+    U(pi, 0, pi) q;
+    n = 1;
+}
+	`
+
+	svc := &handler.QuasarService{}
+	for _, backend := range []quasarv1.Backend{
+		quasarv1.Backend_BACKEND_UNSPECIFIED,
+		quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+		quasarv1.Backend_BACKEND_STABILIZER,
+		quasarv1.Backend_BACKEND_MPS,
+	} {
+		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:    code,
+			Shots:   new(int32(10)),
+			Backend: backend,
+		}))
+		if err != nil {
+			t.Fatalf("%v: %v", backend, err)
+		}
+
+		if resp.Msg.Counts["0"] != 10 {
+			t.Errorf("%v: got=%v", backend, resp.Msg.Counts)
+		}
+
+		if got := resp.Msg.Classical["c"].GetBits().GetValues(); len(got) != 1 || got[0] != 1 {
+			t.Errorf("%v: got=%v", backend, got)
+		}
+
+		if got := resp.Msg.Classical["n"].GetInt(); got != 1 {
+			t.Errorf("%v: got=%v", backend, got)
+		}
+	}
+}
+
+func TestQuasarService_Simulate_parity(t *testing.T) {
+	// the visitor of the default backend and the compiler of the density matrix backend
+	cases := []string{
+		"include \"stdgates.inc\"; qubit[3] q; h q[0]; cx q[0], q[1]; ccx q[0], q[1], q[2];",
+		"include \"stdgates.inc\"; qubit[2] q; for int i in [0:1] { rx(pi/3) q[i]; } ctrl @ s q[0], q[1]; inv @ t q[1];",
+		"include \"stdgates.inc\"; qubit[2] q; h q; pow(2) @ s q[0]; negctrl @ ry(pi/5) q[0], q[1];",
+		"gate g(theta) a, b { U(theta, 0, 0) a; ctrl @ U(pi, 0, pi) a, b; } qubit[3] q; g(pi/3) q[0], q[2]; reset q[0];",
+		"OPENQASM 2.0; include \"qelib1.inc\"; qreg q[2]; h q[0]; cu1(pi/2) q[0], q[1]; u3(pi/4, 0, 0) q[1];",
+	}
+
+	svc := &handler.QuasarService{}
+	for _, code := range cases {
+		var got [2][]string
+		for i, backend := range []quasarv1.Backend{
+			quasarv1.Backend_BACKEND_UNSPECIFIED,
+			quasarv1.Backend_BACKEND_DENSITY_MATRIX,
+		} {
+			resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+				Code:    code,
+				Backend: backend,
+			}))
+			if err != nil {
+				t.Fatalf("%s: %v: %v", code, backend, err)
+			}
+
+			for _, s := range resp.Msg.States {
+				got[i] = append(got[i], fmt.Sprintf("%v %v", s.BinaryString, s.Probability))
+			}
+		}
+
+		if !slices.Equal(got[0], got[1]) {
+			t.Errorf("%s: got=%v, want=%v", code, got[0], got[1])
+		}
+	}
+}
+
+func TestQuasarService_Simulate_stabilizer(t *testing.T) {
+	// clifford circuits too large for the state vector
+	code := `
+	OPENQASM 3.0;
+
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[100] q;
+h q[0];
+for int i in [0:98] {
+    cx q[i], q[i+1];
+}
+	`
+
+	svc := &handler.QuasarService{
+		MaxQubits: 10,
+	}
+
+	// the unspecified backend selects the stabilizer
+	for _, backend := range []quasarv1.Backend{
+		quasarv1.Backend_BACKEND_STABILIZER,
+		quasarv1.Backend_BACKEND_UNSPECIFIED,
+	} {
+		resp, err := svc.Simulate(t.Context(), connect.NewRequest(&quasarv1.SimulateRequest{
+			Code:    code,
+			Shots:   new(int32(10)),
+			Backend: backend,
+		}))
+		if err != nil {
+			t.Fatalf("%v: %v", backend, err)
+		}
+
+		if len(resp.Msg.Stabilizers) != 100 {
+			t.Errorf("%v: got=%d", backend, len(resp.Msg.Stabilizers))
+		}
+
+		for k := range resp.Msg.Counts {
+			if k != strings.Repeat("0", 100) && k != strings.Repeat("1", 100) {
+				t.Errorf("%v: got=%v", backend, k)
+			}
+		}
+	}
+}
+
 func TestQuasarService_Edit(t *testing.T) {
 	cases := []struct {
 		id     string
@@ -200,6 +852,32 @@ func TestQuasarService_Validate(t *testing.T) {
 			line:   1,
 			column: 8,
 		},
+		{
+			code: "OPENQASM 2.0;\ninclude \"qelib1.inc\";\nqreg q[2];\nh q[0];\ncx q[0], q[1];",
+			want: true,
+		},
+		{
+			code:   "OPENQASM 2.0;\nqreg q[2];\nctrl @ x q[0], q[1];",
+			want:   false,
+			line:   3,
+			column: 0,
+		},
+		{
+			code:   "OPENQASM 2.0;\ninclude \"stdgates.inc\";",
+			want:   false,
+			line:   2,
+			column: 8,
+		},
+		{
+			code: "OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\ncx q[0], q[1];",
+			want: true,
+		},
+		{
+			code:   "OPENQASM 3.0;\nqubit q;\ninclude \"foo.inc\";",
+			want:   false,
+			line:   3,
+			column: 8,
+		},
 	}
 
 	svc := &handler.QuasarService{
@@ -232,3 +910,88 @@ func TestQuasarService_Validate(t *testing.T) {
 		}
 	}
 }
+
+func TestQuasarService_Validate_couplingMap(t *testing.T) {
+	line := []*quasarv1.Edge{{A: 0, B: 1}, {A: 1, B: 2}}
+	cases := []struct {
+		code   string
+		edges  []*quasarv1.Edge
+		want   bool
+		swaps  int32
+		final  []int32
+		errMsg string
+	}{
+		{
+			code:  "qubit[3] q; ctrl @ U(pi, 0, pi) q[0], q[1]; ctrl @ U(pi, 0, pi) q[1], q[2];",
+			edges: line,
+			want:  true,
+			final: []int32{0, 1, 2},
+		},
+		{
+			code:  "qubit[3] q; ctrl @ U(pi, 0, pi) q[0], q[2];",
+			edges: line,
+			want:  true,
+			swaps: 1,
+			final: []int32{1, 0, 2},
+		},
+		{
+			code:  "qubit[4] q; U(pi, 0, pi) q;",
+			edges: line,
+			want:  false,
+		},
+		{
+			code:  "qubit[3] q; ctrl @ U(pi, 0, pi) q[0], q[2];",
+			edges: []*quasarv1.Edge{{A: 0, B: 1}, {A: 2, B: 3}},
+			want:  false,
+		},
+		{
+			code:   "qubit[2] q; U(pi, 0, pi) q;",
+			edges:  []*quasarv1.Edge{{A: 1, B: 1}},
+			errMsg: "invalid_argument: [1 1]: invalid edge",
+		},
+		{
+			code:   "qubit[2] q; U(pi, 0, pi) q;",
+			edges:  []*quasarv1.Edge{{A: 0, B: 1 << 30}},
+			errMsg: "invalid_argument: [0 1073741824]: physical qubits must be less than 1024: invalid coupling map",
+		},
+	}
+
+	svc := &handler.QuasarService{
+		MaxQubits: 10,
+		Store:     &store.MemoryStore{},
+	}
+
+	for _, c := range cases {
+		resp, err := svc.Validate(t.Context(), connect.NewRequest(&quasarv1.ValidateRequest{
+			Code:        c.code,
+			CouplingMap: c.edges,
+		}))
+		if err != nil {
+			if err.Error() != c.errMsg {
+				t.Errorf("got=%v, want=%v", err.Error(), c.errMsg)
+			}
+
+			continue
+		}
+
+		if resp.Msg.Valid != c.want {
+			t.Errorf("got=%v, want=%v", resp.Msg.Valid, c.want)
+		}
+
+		if !c.want {
+			if resp.Msg.Message == nil {
+				t.Errorf("message not found")
+			}
+
+			continue
+		}
+
+		if resp.Msg.Routing.Swaps != c.swaps {
+			t.Errorf("got=%v, want=%v", resp.Msg.Routing.Swaps, c.swaps)
+		}
+
+		if !slices.Equal(resp.Msg.Routing.FinalLayout, c.final) {
+			t.Errorf("got=%v, want=%v", resp.Msg.Routing.FinalLayout, c.final)
+		}
+	}
+}
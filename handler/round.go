@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+)
+
+// rounded is the rounding of the sessions, which always use the defaults.
+var rounded = &rounding{
+	precision: precision,
+	epsilon:   epsilon,
+}
+
+// rounding rounds the results and omits the negligible basis states as documented in SimulateRequest.
+type rounding struct {
+	precision int
+	epsilon   float64
+}
+
+// newRounding returns the rounding of the precision and epsilon of a request, or the defaults if they are nil.
+func newRounding(prec *int32, eps *float64) (*rounding, error) {
+	r := &rounding{
+		precision: precision,
+		epsilon:   epsilon,
+	}
+
+	if prec != nil {
+		r.precision = int(*prec)
+	}
+
+	if r.precision < 0 || r.precision > maxPrecision {
+		return nil, fmt.Errorf("precision must be between 0 and %d: %w", maxPrecision, ErrInvalidPrecision)
+	}
+
+	if eps != nil {
+		r.epsilon = *eps
+	}
+
+	if r.epsilon < 0 || math.IsNaN(r.epsilon) {
+		return nil, fmt.Errorf("epsilon must not be negative: %w", ErrInvalidEpsilon)
+	}
+
+	return r, nil
+}
+
+// round rounds v to the precision.
+func (r *rounding) round(v float64) float64 {
+	if r.precision == 0 {
+		return v
+	}
+
+	scale := math.Pow(10, float64(r.precision))
+	return math.Round(v*scale) / scale
+}
+
+// complex rounds the real and imaginary parts of v to the precision.
+func (r *rounding) complex(v complex128) complex128 {
+	return complex(r.round(real(v)), r.round(imag(v)))
+}
+
+// negligible returns true if the rounded probability is less than epsilon.
+func (r *rounding) negligible(p float64) bool {
+	return r.round(p) < r.epsilon
+}
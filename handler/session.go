@@ -0,0 +1,563 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math/bits"
+	mrand "math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/statevector"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	sessionTTL        = 10 * time.Minute
+	sessionTimeout    = 10 * time.Second
+	maxSessions       = 100
+	maxSessionMemory  = 64 << 20  // bytes of the state vector
+	maxSessionsMemory = 1 << 30   // bytes of the state vectors of all sessions
+	maxSessionOps     = 1_000_000 // operations and loop iterations of a session
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrTooManySessions  = errors.New("too many sessions")
+	ErrInvalidCount     = errors.New("invalid count")
+	ErrRegisterNotFound = errors.New("register not found")
+)
+
+var errStopped = errors.New("session stopped")
+
+// position is the position of a statement, or of an operation of a gate body.
+type position struct {
+	line   int
+	column int
+	op     bool
+}
+
+// Session is a debug session.
+// The program is interpreted by the compiler in a coroutine that pauses before each statement,
+// including the statements in loops and subroutines, and before each iteration of a loop.
+// It also pauses before each operation of a gate body, where only Continue stops.
+type Session struct {
+	ID        string
+	machine   *statevector.Machine
+	compiler  *compiler.Compiler
+	decl      *Declarations
+	next      func() (position, bool)
+	stop      func()
+	err       error    // the error of the program
+	at        position // the position of the next statement or operation
+	stmt      position // the position of the last statement
+	steps     int
+	done      bool
+	bytes     int64        // the memory reserved for the state vector
+	expiresAt atomic.Int64 // unix nano
+	sync.Mutex
+}
+
+// Done returns true if all statements have been executed.
+func (s *Session) Done() bool {
+	return s.done
+}
+
+// Step executes the next n statements.
+// It returns the error of the context if it is done before, and the session stays at the last pause.
+func (s *Session) Step(ctx context.Context, n int) error {
+	for range n {
+		if err := s.resume(ctx); err != nil {
+			return err
+		}
+
+		for !s.Done() && s.at.op {
+			if err := s.resume(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Continue resumes the program and stops before the next statement on the line,
+// or before the next operation of a gate body on the line.
+// It runs to the end if line is 0.
+func (s *Session) Continue(ctx context.Context, line int) error {
+	if err := s.resume(ctx); err != nil {
+		return err
+	}
+
+	for !s.Done() && (line == 0 || s.at.line != line) {
+		if err := s.resume(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resume resumes the program until the next pause, or the end of the program.
+// The statement is counted when the program leaves it.
+func (s *Session) resume(ctx context.Context) error {
+	if s.Done() {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !s.at.op {
+		s.steps++
+	}
+
+	return s.advance()
+}
+
+// advance resumes the program until the next pause, or the end of the program.
+func (s *Session) advance() error {
+	at, ok := s.next()
+	if !ok {
+		s.done = true
+		return s.err
+	}
+
+	s.at = at
+	if !at.op {
+		s.stmt = at
+	}
+
+	return nil
+}
+
+// pausing runs the operations on the machine of the session,
+// and pauses before the operations of a gate body, which are at another position than the statement.
+type pausing struct {
+	*statevector.Machine
+	session *Session
+	yield   func(position) bool
+}
+
+func (m *pausing) Run(op circuit.Op) (int, error) {
+	at := position{line: op.Line, column: op.Column, op: true}
+	if op.Line != m.session.stmt.line || op.Column != m.session.stmt.column {
+		if !m.yield(at) {
+			return 0, errStopped
+		}
+	}
+
+	return m.Machine.Run(op)
+}
+
+// close stops the program of the session.
+func (s *Session) close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stop != nil {
+		s.stop()
+	}
+}
+
+// Sessions is the registry of the debug sessions.
+// Sessions idle for TTL are evicted, and the sessions reserve up to MaxBytes for their state vectors.
+// A call to Step or Continue runs for up to Timeout. The zero value uses the defaults.
+type Sessions struct {
+	TTL      time.Duration
+	Timeout  time.Duration
+	Max      int
+	MaxBytes int64
+	m        map[string]*Session
+	bytes    int64
+	mu       sync.Mutex
+}
+
+// Add adds the session, evicting the expired sessions first.
+func (r *Sessions) Add(s *Session) error {
+	expired, err := r.add(s)
+	closeAll(expired)
+	return err
+}
+
+func (r *Sessions) add(s *Session) ([]*Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	expired := r.evict(now)
+
+	limit := cmp.Or(r.Max, maxSessions)
+	if len(r.m) >= limit {
+		return expired, fmt.Errorf("max=%d: %w", limit, ErrTooManySessions)
+	}
+
+	if bytes, limit := r.bytes+s.bytes, cmp.Or(r.MaxBytes, maxSessionsMemory); bytes > limit {
+		return expired, fmt.Errorf("need=%d bytes, max=%d: %w", bytes, limit, ErrResourceExhausted)
+	}
+
+	if r.m == nil {
+		r.m = make(map[string]*Session)
+	}
+
+	s.expiresAt.Store(now.Add(cmp.Or(r.TTL, sessionTTL)).UnixNano())
+	r.m[s.ID] = s
+	r.bytes += s.bytes
+	return expired, nil
+}
+
+// Get returns the session and extends its expiry.
+func (r *Sessions) Get(id string) (*Session, error) {
+	expired, s, err := r.get(id)
+	closeAll(expired)
+	return s, err
+}
+
+func (r *Sessions) get(id string) ([]*Session, *Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	expired := r.evict(now)
+
+	s, ok := r.m[id]
+	if !ok {
+		return expired, nil, ErrSessionNotFound
+	}
+
+	s.expiresAt.Store(now.Add(cmp.Or(r.TTL, sessionTTL)).UnixNano())
+	return expired, s, nil
+}
+
+// Delete deletes the session and stops its program.
+func (r *Sessions) Delete(id string) {
+	r.mu.Lock()
+	s, ok := r.m[id]
+	if ok {
+		delete(r.m, id)
+		r.bytes -= s.bytes
+	}
+	r.mu.Unlock()
+
+	if ok {
+		s.close()
+	}
+}
+
+// evict deletes the expired sessions and returns them.
+// The caller must close them after releasing the lock.
+func (r *Sessions) evict(now time.Time) []*Session {
+	var expired []*Session
+	maps.DeleteFunc(r.m, func(_ string, s *Session) bool {
+		if now.UnixNano() > s.expiresAt.Load() {
+			expired = append(expired, s)
+			r.bytes -= s.bytes
+			return true
+		}
+
+		return false
+	})
+
+	return expired
+}
+
+func closeAll(sessions []*Session) {
+	for _, s := range sessions {
+		s.close()
+	}
+}
+
+func (s *QuasarService) StartSession(
+	ctx context.Context,
+	req *connect.Request[quasarv1.StartSessionRequest],
+) (*connect.Response[quasarv1.StartSessionResponse], error) {
+	code, err := s.snippet(ctx, req.Msg.Code, req.Msg.SnippetId)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err = s.source(ctx, code, req.Msg.Includes, req.Msg.Imports)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	decl := Declared(program)
+	inputs, err := Bind(decl, req.Msg.Inputs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	seed := mrand.Uint64()
+	if req.Msg.Seed != nil {
+		seed = req.Msg.GetSeed()
+	}
+
+	// the state vector is limited to maxSessionMemory bytes
+	maxQubits := bits.Len(uint(maxSessionMemory/16)) - 1
+	if s.MaxQubits > 0 {
+		maxQubits = min(maxQubits, s.MaxQubits)
+	}
+
+	maxOps := maxSessionOps
+	if s.MaxOps > 0 {
+		maxOps = min(maxOps, s.MaxOps)
+	}
+
+	// the state vector is reserved from the memory of all sessions
+	r := compiler.Estimate(program, compiler.WithInputs(inputs))
+	session := &Session{
+		ID: rand.Text(),
+		machine: &statevector.Machine{
+			State: statevector.New(0),
+			Rand:  mrand.New(mrand.NewPCG(seed, seed)).Float64,
+		},
+		decl:  decl,
+		bytes: stateBytes(min(r.Qubits, maxQubits)),
+	}
+
+	// the program pauses before each statement and each operation of a gate body
+	session.next, session.stop = iter.Pull(func(yield func(position) bool) {
+		session.compiler = compiler.New(
+			compiler.WithInputs(inputs),
+			compiler.WithMaxQubits(maxQubits),
+			compiler.WithMaxOps(maxOps),
+			compiler.WithMachine(&pausing{
+				Machine: session.machine,
+				session: session,
+				yield:   yield,
+			}),
+			compiler.WithStep(func(line, column int) error {
+				if !yield(position{line: line, column: column}) {
+					return errStopped
+				}
+
+				return nil
+			}),
+		)
+
+		_, session.err = session.compiler.Compile(program)
+	})
+
+	// stop before the first statement
+	if err := session.advance(); err != nil {
+		session.stop()
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	session.Lock()
+	defer session.Unlock()
+
+	if err := s.Sessions.Add(session); err != nil {
+		session.stop()
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	return connect.NewResponse(&quasarv1.StartSessionResponse{
+		Session: s.session(session),
+	}), nil
+}
+
+func (s *QuasarService) Step(
+	ctx context.Context,
+	req *connect.Request[quasarv1.StepRequest],
+) (*connect.Response[quasarv1.StepResponse], error) {
+	count := 1
+	if req.Msg.Count != nil {
+		count = int(req.Msg.GetCount())
+	}
+
+	if count < 1 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("count must be positive: %w", ErrInvalidCount))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(s.Sessions.Timeout, sessionTimeout))
+	defer cancel()
+
+	session, err := s.run(req.Msg.Id, func(session *Session) error {
+		return session.Step(ctx, count)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&quasarv1.StepResponse{
+		Session: session,
+	}), nil
+}
+
+func (s *QuasarService) Continue(
+	ctx context.Context,
+	req *connect.Request[quasarv1.ContinueRequest],
+) (*connect.Response[quasarv1.ContinueResponse], error) {
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(s.Sessions.Timeout, sessionTimeout))
+	defer cancel()
+
+	session, err := s.run(req.Msg.Id, func(session *Session) error {
+		return session.Continue(ctx, int(req.Msg.GetLine()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&quasarv1.ContinueResponse{
+		Session: session,
+	}), nil
+}
+
+func (s *QuasarService) Inspect(
+	ctx context.Context,
+	req *connect.Request[quasarv1.InspectRequest],
+) (*connect.Response[quasarv1.InspectResponse], error) {
+	session, err := s.Sessions.Get(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	session.Lock()
+	defer session.Unlock()
+
+	name := req.Msg.Register
+	c := session.compiler.Circuit()
+	if i := slices.IndexFunc(c.QRegs, func(r circuit.Register) bool { return r.Name == name }); i >= 0 {
+		// marginal probabilities of the register
+		index := [][]int{c.QRegs[i].Index}
+		probs := make(map[string]float64)
+		for k, p := range session.machine.Probabilities() {
+			probs[BinaryString(k, c.Qubits, index)[0]] += p
+		}
+
+		states := make([]*quasarv1.SimulateResponse_State, 0, len(probs))
+		for _, k := range slices.Sorted(maps.Keys(probs)) {
+			if rounded.negligible(probs[k]) {
+				continue
+			}
+
+			states = append(states, &quasarv1.SimulateResponse_State{
+				BinaryString: []string{k},
+				Probability:  rounded.round(probs[k]),
+			})
+		}
+
+		return connect.NewResponse(&quasarv1.InspectResponse{
+			Register: name,
+			States:   states,
+		}), nil
+	}
+
+	// all variables, not only the outputs
+	classical := Classical(c, session.compiler.Env(), &Declarations{Angle: session.decl.Angle})
+	if c, ok := classical[name]; ok {
+		return connect.NewResponse(&quasarv1.InspectResponse{
+			Register:  name,
+			Classical: c,
+		}), nil
+	}
+
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s: %w", name, ErrRegisterNotFound))
+}
+
+func (s *QuasarService) End(
+	ctx context.Context,
+	req *connect.Request[quasarv1.EndRequest],
+) (*connect.Response[quasarv1.EndResponse], error) {
+	if _, err := s.Sessions.Get(req.Msg.Id); err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	s.Sessions.Delete(req.Msg.Id)
+	return connect.NewResponse(&quasarv1.EndResponse{}), nil
+}
+
+// run runs f on the session and returns the resulting state.
+// The session is kept if the context of f is done, and is ended if the program fails.
+func (s *QuasarService) run(id string, f func(session *Session) error) (*quasarv1.Session, error) {
+	session, err := s.Sessions.Get(id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	out, err := func() (*quasarv1.Session, error) {
+		session.Lock()
+		defer session.Unlock()
+
+		if err := f(session); err != nil {
+			return nil, err
+		}
+
+		return s.session(session), nil
+	}()
+	if errors.Is(err, context.DeadlineExceeded) {
+		// the session stays at the last pause
+		return nil, connect.NewError(connect.CodeDeadlineExceeded, err)
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return nil, connect.NewError(connect.CodeCanceled, err)
+	}
+
+	if err != nil {
+		// the lock of the session is released before it is closed
+		s.Sessions.Delete(id)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return out, nil
+}
+
+// session returns the state of the session. The caller must hold the lock of the session.
+func (s *QuasarService) session(session *Session) *quasarv1.Session {
+	c := session.compiler.Circuit()
+	out := &quasarv1.Session{
+		Id:        session.ID,
+		Step:      int32(session.steps),
+		Done:      session.Done(),
+		States:    make([]*quasarv1.SimulateResponse_State, 0),
+		Classical: Classical(c, session.compiler.Env(), session.decl),
+		ExpiresAt: timestamppb.New(time.Unix(0, session.expiresAt.Load())),
+	}
+
+	if !session.Done() {
+		out.Line = new(int32(session.at.line))
+		out.Column = new(int32(session.at.column))
+	}
+
+	if c.Qubits == 0 {
+		return out
+	}
+
+	for k, amp := range session.machine.Amplitude {
+		p := real(amp)*real(amp) + imag(amp)*imag(amp)
+		if rounded.negligible(p) {
+			continue
+		}
+
+		out.States = append(out.States, &quasarv1.SimulateResponse_State{
+			BinaryString: BinaryString(k, c.Qubits, c.Index()),
+			Probability:  rounded.round(p),
+			Amplitude: &quasarv1.SimulateResponse_Amplitude{
+				Real: rounded.round(real(amp)),
+				Imag: rounded.round(imag(amp)),
+			},
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,386 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/store"
+)
+
+func ExampleQuasarService_StartSession() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+bit[2] c;
+h q[0];
+cx q[0], q[1];
+c = measure q;
+`
+
+	svc := &handler.QuasarService{}
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: code,
+		Seed: new(uint64(1)),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	id := start.Msg.Session.Id
+	fmt.Println(start.Msg.Session.GetLine(), start.Msg.Session.Done)
+
+	// break at the cx
+	cont, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{
+		Id:   id,
+		Line: new(int32(9)),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(cont.Msg.Session.GetLine())
+	for _, s := range cont.Msg.Session.States {
+		fmt.Println(s.BinaryString, s.Probability)
+	}
+
+	step, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{
+		Id: id,
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range step.Msg.Session.States {
+		fmt.Println(s.BinaryString, s.Probability)
+	}
+
+	inspect, err := svc.Inspect(context.Background(), connect.NewRequest(&quasarv1.InspectRequest{
+		Id:       id,
+		Register: "q",
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range inspect.Msg.States {
+		fmt.Println(s.BinaryString, s.Probability)
+	}
+
+	if _, err := svc.End(context.Background(), connect.NewRequest(&quasarv1.EndRequest{
+		Id: id,
+	})); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// 3 false
+	// 9
+	// [00] 0.5
+	// [10] 0.5
+	// [00] 0.5
+	// [11] 0.5
+	// [00] 0.5
+	// [11] 0.5
+}
+
+func TestQuasarService_Session(t *testing.T) {
+	snippets := &store.MemoryStore{}
+	if err := snippets.Put(context.Background(), "abcd", &store.Snippet{
+		Code:      "qubit q;\nbit c;\nU(pi, 0, pi) q;\nc = measure q;",
+		CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	svc := &handler.QuasarService{Store: snippets}
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		SnippetId: "abcd",
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	id := start.Msg.Session.Id
+	cont, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{
+		Id: id,
+	}))
+	if err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+
+	if !cont.Msg.Session.Done || cont.Msg.Session.Line != nil || cont.Msg.Session.Step != 4 {
+		t.Errorf("got=%v", cont.Msg.Session)
+	}
+
+	inspect, err := svc.Inspect(context.Background(), connect.NewRequest(&quasarv1.InspectRequest{
+		Id:       id,
+		Register: "c",
+	}))
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+
+	if got := inspect.Msg.Classical.GetBits().GetValues(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("got=%v", got)
+	}
+
+	if _, err := svc.Inspect(context.Background(), connect.NewRequest(&quasarv1.InspectRequest{
+		Id:       id,
+		Register: "x",
+	})); !errors.Is(err, handler.ErrRegisterNotFound) {
+		t.Errorf("got=%v", err)
+	}
+
+	if _, err := svc.End(context.Background(), connect.NewRequest(&quasarv1.EndRequest{Id: id})); err != nil {
+		t.Fatalf("end: %v", err)
+	}
+
+	if _, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("got=%v", err)
+	}
+}
+
+func TestQuasarService_Session_error(t *testing.T) {
+	svc := &handler.QuasarService{
+		MaxQubits: 2,
+		Store:     &store.MemoryStore{},
+	}
+
+	cases := []struct {
+		req  *quasarv1.StartSessionRequest
+		code connect.Code
+	}{
+		{&quasarv1.StartSessionRequest{}, connect.CodeInvalidArgument},
+		{&quasarv1.StartSessionRequest{SnippetId: "notfound"}, connect.CodeNotFound},
+		{&quasarv1.StartSessionRequest{Code: "qubit q"}, connect.CodeInvalidArgument},
+		{&quasarv1.StartSessionRequest{Code: "input int n; qubit q;"}, connect.CodeInvalidArgument},
+	}
+
+	for _, c := range cases {
+		if _, err := svc.StartSession(context.Background(), connect.NewRequest(c.req)); connect.CodeOf(err) != c.code {
+			t.Errorf("got=%v, want=%v", err, c.code)
+		}
+	}
+
+	// too many qubits ends the session
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "qubit[3] q;",
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	id := start.Msg.Session.Id
+	if _, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("got=%v", err)
+	}
+
+	if _, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("got=%v", err)
+	}
+
+	if _, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id, Count: new(int32(0))})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("got=%v", err)
+	}
+}
+
+func TestQuasarService_Session_maxOps(t *testing.T) {
+	svc := &handler.QuasarService{
+		MaxOps: 100,
+	}
+
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "qubit q;\nwhile (true) {\n  U(pi, 0, pi) q;\n}",
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	// too many operations ends the session
+	id := start.Msg.Session.Id
+	if _, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{Id: id})); !errors.Is(err, compiler.ErrTooManyOps) {
+		t.Errorf("got=%v", err)
+	}
+
+	if _, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("got=%v", err)
+	}
+}
+
+func TestQuasarService_Session_imports(t *testing.T) {
+	svc := &handler.QuasarService{
+		Store: &store.MemoryStore{},
+	}
+
+	shared, err := svc.Share(context.Background(), connect.NewRequest(&quasarv1.ShareRequest{
+		Code: "gate x a { U(pi, 0, pi) a; }",
+	}))
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code:    "qubit q;\nx q;",
+		Imports: []string{shared.Msg.Id},
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	cont, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{
+		Id: start.Msg.Session.Id,
+	}))
+	if err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+
+	if got := cont.Msg.Session.States; len(got) != 1 || got[0].BinaryString[0] != "1" {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	r := &handler.Sessions{
+		TTL: 10 * time.Millisecond,
+		Max: 2,
+	}
+
+	for _, id := range []string{"a", "b"} {
+		if err := r.Add(&handler.Session{ID: id}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	if err := r.Add(&handler.Session{ID: "c"}); !errors.Is(err, handler.ErrTooManySessions) {
+		t.Errorf("got=%v, want=%v", err, handler.ErrTooManySessions)
+	}
+
+	// idle sessions are evicted
+	time.Sleep(20 * time.Millisecond)
+	if err := r.Add(&handler.Session{ID: "c"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	if _, err := r.Get("a"); !errors.Is(err, handler.ErrSessionNotFound) {
+		t.Errorf("got=%v, want=%v", err, handler.ErrSessionNotFound)
+	}
+
+	if _, err := r.Get("c"); err != nil {
+		t.Errorf("get: %v", err)
+	}
+
+	r.Delete("c")
+	if _, err := r.Get("c"); !errors.Is(err, handler.ErrSessionNotFound) {
+		t.Errorf("got=%v, want=%v", err, handler.ErrSessionNotFound)
+	}
+}
+
+func TestQuasarService_Session_gate(t *testing.T) {
+	code := "qubit[2] q;\ngate bell a, b {\n  U(pi/2.0, 0, pi) a;\n  ctrl @ U(pi, 0, pi) a, b;\n}\nbell q[0], q[1];"
+
+	svc := &handler.QuasarService{}
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: code,
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	// break at the second operation of the gate body
+	id := start.Msg.Session.Id
+	cont, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{
+		Id:   id,
+		Line: new(int32(4)),
+	}))
+	if err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+
+	if cont.Msg.Session.GetLine() != 4 || len(cont.Msg.Session.States) != 2 || cont.Msg.Session.States[1].BinaryString[0] != "10" {
+		t.Errorf("got=%v", cont.Msg.Session)
+	}
+
+	// step runs to the next statement, the end of the program
+	step, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{
+		Id: id,
+	}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+
+	if !step.Msg.Session.Done || step.Msg.Session.Step != 3 || step.Msg.Session.States[1].BinaryString[0] != "11" {
+		t.Errorf("got=%v", step.Msg.Session)
+	}
+}
+
+func TestQuasarService_Session_timeout(t *testing.T) {
+	svc := &handler.QuasarService{
+		Sessions: handler.Sessions{
+			Timeout: 10 * time.Millisecond,
+		},
+	}
+
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "int i = 0;\nwhile (true) {\n  i += 1;\n}",
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	id := start.Msg.Session.Id
+	if _, err := svc.Continue(context.Background(), connect.NewRequest(&quasarv1.ContinueRequest{Id: id})); connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Errorf("got=%v", err)
+	}
+
+	// the session is kept
+	step, err := svc.Step(context.Background(), connect.NewRequest(&quasarv1.StepRequest{Id: id}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+
+	if step.Msg.Session.Done {
+		t.Errorf("got=%v", step.Msg.Session)
+	}
+}
+
+func TestQuasarService_Session_memory(t *testing.T) {
+	svc := &handler.QuasarService{
+		Sessions: handler.Sessions{
+			MaxBytes: 64, // the state vector of 2 qubits
+		},
+	}
+
+	start, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "qubit[2] q;",
+	}))
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+
+	if _, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "qubit q;",
+	})); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("got=%v", err)
+	}
+
+	// the memory is released when the session ends
+	if _, err := svc.End(context.Background(), connect.NewRequest(&quasarv1.EndRequest{Id: start.Msg.Session.Id})); err != nil {
+		t.Fatalf("end: %v", err)
+	}
+
+	if _, err := svc.StartSession(context.Background(), connect.NewRequest(&quasarv1.StartSessionRequest{
+		Code: "qubit q;",
+	})); err != nil {
+		t.Errorf("start session: %v", err)
+	}
+}
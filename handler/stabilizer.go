@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/pauli"
+	"github.com/itsubaki/quasar/stabilizer"
+)
+
+// clifford returns true if the unspecified backend runs the program on the stabilizer tableau
+// when it is too large for the state vector. The program must pass the preflight of the stabilizer tableau,
+// and compile to a circuit of Clifford gates that does not read a measured bit.
+func (s *QuasarService) clifford(sim *simulation, backend quasarv1.Backend, inputs map[string]any, runs int) bool {
+	if backend != quasarv1.Backend_BACKEND_UNSPECIFIED || sim.req.Analysis != nil {
+		return false
+	}
+
+	if err := s.preflight(sim.program, inputs, s.backend(quasarv1.Backend_BACKEND_STABILIZER, 0, runs)); err != nil {
+		return false
+	}
+
+	c, _, err := sim.run(nil, inputs, maxClifford)
+	return err == nil && stabilizer.IsClifford(c)
+}
+
+// simulateStabilizer runs the Clifford program on the stabilizer tableau.
+// The response has the stabilizer generators instead of the states.
+func (sim *simulation) simulateStabilizer(values map[string]float64, inputs map[string]any) (*quasarv1.SimulateResponse_Result, error) {
+	machine := func() *stabilizer.Machine {
+		return &stabilizer.Machine{
+			State: stabilizer.New(0),
+			Rand:  sim.rng.Float64,
+		}
+	}
+
+	tableau := machine()
+	c, env, err := sim.run(tableau, inputs, maxClifford)
+	if err != nil {
+		return nil, err
+	}
+
+	// expectation values
+	index := slices.Concat(c.Index()...)
+	expectations := make([]float64, len(sim.observables))
+	for i, h := range sim.observables {
+		if h.Qubits() != len(index) {
+			return nil, fmt.Errorf("qubits=%d, pauli=%d: %w", len(index), h.Qubits(), pauli.ErrInvalidLength)
+		}
+
+		for _, t := range h {
+			expectations[i] += t.Coef * tableau.Expectation(t.Pauli, index)
+		}
+	}
+
+	// measurement counts
+	var counts map[string]int32
+	if sim.shots > 0 {
+		counts = make(map[string]int32)
+		for range sim.shots {
+			if err := sim.ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			m := machine()
+			qc, _, err := sim.run(m, inputs, maxClifford)
+			if err != nil {
+				return nil, err
+			}
+
+			bits := m.Sample(sim.rng.Float64)
+			counts[strings.Join(Registers(bits, qc.Index()), " ")]++
+		}
+	}
+
+	return &quasarv1.SimulateResponse_Result{
+		Inputs:       values,
+		States:       make([]*quasarv1.SimulateResponse_State, 0),
+		Counts:       counts,
+		Classical:    Classical(c, env, sim.decl),
+		Expectations: expectations,
+		Stabilizers:  tableau.Stabilizers(),
+	}, nil
+}
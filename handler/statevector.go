@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"errors"
+	"maps"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/q"
+	"github.com/itsubaki/qasm/environ"
+	"github.com/itsubaki/qasm/visitor"
+	"github.com/itsubaki/quasar/compiler"
+	"github.com/itsubaki/quasar/density"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/packed"
+	"github.com/itsubaki/quasar/statevector"
+)
+
+// simulateStatevector runs the program on the quantum simulator with the visitor of the qasm package.
+// The program is compiled first, so that its operations and loops are bounded and it stops on cancellation.
+// The programs that read a measured bit or have a bit input run on the state vector machine of the compiler instead.
+func (sim *simulation) simulateStatevector(values map[string]float64, inputs map[string]any) (*quasarv1.SimulateResponse_Result, error) {
+	c, cenv, err := sim.run(nil, inputs, sim.maxQubits)
+	if errors.Is(err, compiler.ErrClassicalControl) || slices.Contains(slices.Collect(maps.Values(sim.decl.Input)), "bit") {
+		return sim.simulateMachine(values, inputs)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	qsim, env, err := sim.visit(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	amp := qsim.Amplitude()
+	n := bits.Len(uint(len(amp))) - 1
+
+	// build response
+	var states []*quasarv1.SimulateResponse_State
+	var amplitudes []byte
+	switch {
+	case sim.req.Packed:
+		// full state vector
+		amplitudes = packed.Encode(amp)
+	case sim.req.Dense:
+		// full state vector including zero entries
+		states = make([]*quasarv1.SimulateResponse_State, len(amp))
+		for k, a := range amp {
+			states[k] = sim.state(BinaryString(k, n, env.Index()), a, real(a)*real(a)+imag(a)*imag(a))
+		}
+	default:
+		// quantum state
+		states = make([]*quasarv1.SimulateResponse_State, 0)
+		for k, a := range amp {
+			p := real(a)*real(a) + imag(a)*imag(a)
+			if sim.r.negligible(p) {
+				continue
+			}
+
+			// prob >= epsilon
+			states = append(states, sim.state(BinaryString(k, n, env.Index()), a, p))
+		}
+	}
+
+	// expectation values
+	index := slices.Concat(env.Index()...)
+	expectations := make([]float64, len(sim.observables))
+	for i, h := range sim.observables {
+		e, err := h.Expectation(amp, index)
+		if err != nil {
+			return nil, err
+		}
+
+		expectations[i] = e
+	}
+
+	// reduced density matrices
+	var analysis *quasarv1.SimulateResponse_Analysis
+	if sim.req.Analysis != nil {
+		analysis, err = Analyze(func(keep []int) ([]complex128, error) {
+			return density.Reduced(amp, keep)
+		}, index, sim.req.Analysis.Subsystem, sim.r.round)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// measurement counts
+	var counts map[string]int32
+	if sim.shots > 0 {
+		// the shots are sampled from a single run if the measurements are terminal
+		var final []float64
+		if terminal(c, cenv) {
+			sv, err := statevector.Run(unmeasured(c), sim.rng.Float64, sim.maxQubits)
+			if err != nil {
+				return nil, err
+			}
+
+			final = sv.Probabilities()
+		}
+
+		counts = make(map[string]int32)
+		for range sim.shots {
+			if err := sim.ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if final != nil {
+				k := Sample(final, sim.rng.Float64())
+				counts[strings.Join(BinaryString(k, c.Qubits, c.Index()), " ")]++
+				continue
+			}
+
+			qsim, env, err := sim.visit(inputs)
+			if err != nil {
+				return nil, err
+			}
+
+			amp := qsim.Amplitude()
+			probs := make([]float64, len(amp))
+			for i, a := range amp {
+				probs[i] = real(a)*real(a) + imag(a)*imag(a)
+			}
+
+			k := Sample(probs, sim.rng.Float64())
+			counts[strings.Join(BinaryString(k, bits.Len(uint(len(amp)))-1, env.Index()), " ")]++
+		}
+	}
+
+	return &quasarv1.SimulateResponse_Result{
+		Inputs:       values,
+		States:       states,
+		Counts:       counts,
+		Classical:    Visited(env, sim.decl),
+		Expectations: expectations,
+		Packed:       amplitudes,
+		Analysis:     analysis,
+	}, nil
+}
+
+// visit runs the program on the quantum simulator with the visitor of the qasm package.
+func (sim *simulation) visit(inputs map[string]any) (*q.Q, *environ.Environ, error) {
+	qsim := q.New()
+	qsim.Rand = sim.rng.Float64
+
+	env := environ.New()
+	maps.Copy(env.Variable, inputs)
+
+	v := visitor.New(qsim, env,
+		visitor.WithMaxQubits(sim.maxQubits),
+	)
+
+	if err := v.Run(sim.program); err != nil {
+		return nil, nil, err
+	}
+
+	if len(env.Qubit) == 0 {
+		return nil, nil, ErrQubitsNotFound
+	}
+
+	return qsim, env, nil
+}
+
+// simulateMachine runs the program on the state vector machine of the compiler,
+// which stops on cancellation and at the operation limit while the program runs.
+func (sim *simulation) simulateMachine(values map[string]float64, inputs map[string]any) (*quasarv1.SimulateResponse_Result, error) {
+	machine := func() *statevector.Machine {
+		return &statevector.Machine{
+			State: statevector.New(0),
+			Rand:  sim.rng.Float64,
+		}
+	}
+
+	sv := machine()
+	c, env, err := sim.run(sv, inputs, sim.maxQubits)
+	if err != nil {
+		return nil, err
+	}
+
+	// build response
+	var states []*quasarv1.SimulateResponse_State
+	var amplitudes []byte
+	switch {
+	case sim.req.Packed:
+		// full state vector
+		amplitudes = packed.Encode(sv.Amplitude)
+	case sim.req.Dense:
+		// full state vector including zero entries
+		states = make([]*quasarv1.SimulateResponse_State, len(sv.Amplitude))
+		for k, a := range sv.Amplitude {
+			states[k] = sim.state(BinaryString(k, c.Qubits, c.Index()), a, real(a)*real(a)+imag(a)*imag(a))
+		}
+	default:
+		// quantum state
+		states = make([]*quasarv1.SimulateResponse_State, 0)
+		for k, a := range sv.Amplitude {
+			p := real(a)*real(a) + imag(a)*imag(a)
+			if sim.r.negligible(p) {
+				continue
+			}
+
+			// prob >= epsilon
+			states = append(states, sim.state(BinaryString(k, c.Qubits, c.Index()), a, p))
+		}
+	}
+
+	// expectation values
+	index := slices.Concat(c.Index()...)
+	expectations := make([]float64, len(sim.observables))
+	for i, h := range sim.observables {
+		e, err := h.Expectation(sv.Amplitude, index)
+		if err != nil {
+			return nil, err
+		}
+
+		expectations[i] = e
+	}
+
+	// reduced density matrices
+	var analysis *quasarv1.SimulateResponse_Analysis
+	if sim.req.Analysis != nil {
+		amp := sv.Amplitude
+		analysis, err = Analyze(func(keep []int) ([]complex128, error) {
+			return density.Reduced(amp, keep)
+		}, index, sim.req.Analysis.Subsystem, sim.r.round)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// measurement counts
+	var counts map[string]int32
+	if sim.shots > 0 {
+		// the shots are sampled from a single run if the measurements are terminal
+		var final []float64
+		if terminal(c, env) {
+			sv, err := statevector.Run(unmeasured(c), sim.rng.Float64, sim.maxQubits)
+			if err != nil {
+				return nil, err
+			}
+
+			final = sv.Probabilities()
+		}
+
+		counts = make(map[string]int32)
+		for range sim.shots {
+			if err := sim.ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			probs, qc := final, c
+			if final == nil {
+				m := machine()
+				qc, _, err = sim.run(m, inputs, sim.maxQubits)
+				if err != nil {
+					return nil, err
+				}
+
+				probs = m.Probabilities()
+			}
+
+			k := Sample(probs, sim.rng.Float64())
+			counts[strings.Join(BinaryString(k, qc.Qubits, qc.Index()), " ")]++
+		}
+	}
+
+	return &quasarv1.SimulateResponse_Result{
+		Inputs:       values,
+		States:       states,
+		Counts:       counts,
+		Classical:    Classical(c, env, sim.decl),
+		Expectations: expectations,
+		Packed:       amplitudes,
+		Analysis:     analysis,
+	}, nil
+}
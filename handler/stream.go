@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/statevector"
+)
+
+const (
+	snapshots    = 100
+	maxSnapshots = 1000
+)
+
+var (
+	ErrInvalidSnapshots   = errors.New("invalid max snapshots")
+	ErrStreamNotSupported = errors.New("not supported by the stream")
+)
+
+// errSent stops the program when max snapshots have been sent.
+var errSent = errors.New("max snapshots sent")
+
+// SimulateStream sends the state after each executed statement.
+// The program runs on the state vector as it is interpreted, so the snapshots are the same as Simulate.
+// A compound statement, e.g. an if statement or each iteration of a loop, is sent before its body.
+// The statements before the first qubit is declared are counted as steps, but not sent.
+// Only the statements on the given lines are sent if lines is not empty.
+// The stream ends after the last statement, or when max snapshots have been sent.
+func (s *QuasarService) SimulateStream(
+	ctx context.Context,
+	req *connect.Request[quasarv1.SimulateStreamRequest],
+	stream *connect.ServerStream[quasarv1.SimulateStreamResponse],
+) error {
+	msg := req.Msg.GetRequest()
+	if len(strings.TrimSpace(msg.GetCode())) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
+	}
+
+	// the state vector without noise or shots
+	for _, o := range []struct {
+		name string
+		set  bool
+	}{
+		{"backend", msg.Backend != quasarv1.Backend_BACKEND_UNSPECIFIED && msg.Backend != quasarv1.Backend_BACKEND_STATEVECTOR},
+		{"noise", msg.Noise != nil},
+		{"shots", msg.GetShots() != 0},
+		{"sweep", len(msg.Sweep) > 0},
+		{"observables", len(msg.Observables) > 0},
+		{"analysis", msg.Analysis != nil},
+	} {
+		if o.set {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s: %w", o.name, ErrStreamNotSupported))
+		}
+	}
+
+	r, err := newRounding(msg.Precision, msg.Epsilon)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	limit := snapshots
+	if req.Msg.MaxSnapshots != nil {
+		limit = int(req.Msg.GetMaxSnapshots())
+	}
+
+	if limit < 1 || limit > maxSnapshots {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("max snapshots must be between 1 and %d: %w", maxSnapshots, ErrInvalidSnapshots))
+	}
+
+	lines := make(map[int]bool)
+	for _, l := range req.Msg.Lines {
+		lines[int(l)] = true
+	}
+
+	code, err := s.source(ctx, msg.Code, msg.Includes, msg.Imports)
+	if err != nil {
+		return err
+	}
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	inputs, err := Bind(Declared(program), msg.Inputs)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := s.preflight(program, inputs, s.backend(quasarv1.Backend_BACKEND_STATEVECTOR, 0, 1)); err != nil {
+		return connect.NewError(errorCode(err), err)
+	}
+
+	seed := rand.Uint64()
+	if msg.Seed != nil {
+		seed = msg.GetSeed()
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	m := &streamer{
+		Machine: &statevector.Machine{
+			State: statevector.New(0),
+			Rand:  rng.Float64,
+		},
+	}
+
+	cc := compiler.New(
+		compiler.WithContext(ctx),
+		compiler.WithInputs(inputs),
+		compiler.WithMaxQubits(s.MaxQubits),
+		compiler.WithMaxOps(s.MaxOps),
+		compiler.WithMachine(m),
+		compiler.WithStep(m.next),
+	)
+
+	var sent int
+	var failed error
+	m.send = func(step, line, column int, call *circuit.Call) error {
+		if len(lines) > 0 && !lines[line] {
+			return nil
+		}
+
+		c := cc.Circuit()
+		if c.Qubits == 0 {
+			return nil
+		}
+
+		var gate string
+		var params []float64
+		var qubits []int32
+		if call != nil {
+			gate, params = call.Name, call.Params
+			for _, q := range call.Qubits {
+				qubits = append(qubits, int32(q))
+			}
+		}
+
+		states := make([]*quasarv1.SimulateResponse_State, 0)
+		for k, a := range m.Amplitude {
+			prob := real(a)*real(a) + imag(a)*imag(a)
+			if r.negligible(prob) {
+				continue
+			}
+
+			states = append(states, &quasarv1.SimulateResponse_State{
+				BinaryString: BinaryString(k, c.Qubits, c.Index()),
+				Probability:  r.round(prob),
+				Amplitude: &quasarv1.SimulateResponse_Amplitude{
+					Real: r.round(real(a)),
+					Imag: r.round(imag(a)),
+				},
+			})
+		}
+
+		if err := stream.Send(&quasarv1.SimulateStreamResponse{
+			Step:   int32(step),
+			Line:   int32(line),
+			Column: int32(column),
+			Gate:   gate,
+			Params: params,
+			Qubits: qubits,
+			States: states,
+		}); err != nil {
+			failed = err
+			return err
+		}
+
+		if sent++; sent == limit {
+			return errSent
+		}
+
+		return nil
+	}
+
+	c, err := cc.Compile(program)
+	if err == nil {
+		// the last statement
+		err = m.flush()
+	}
+
+	if failed != nil {
+		return failed
+	}
+
+	if errors.Is(err, errSent) {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return connect.NewError(connect.CodeCanceled, ctx.Err())
+	}
+
+	if err != nil {
+		return connect.NewError(errorCode(err), err)
+	}
+
+	if c.Qubits == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	return nil
+}
+
+// streamer runs the operations on the state vector, and sends the state after each statement.
+// The compiler calls next before each statement, so the state of the previous statement is sent then,
+// and the state of the last statement is sent by flush.
+type streamer struct {
+	*statevector.Machine
+	send    func(step, line, column int, call *circuit.Call) error
+	call    *circuit.Call // the first call of the operations of the statement
+	step    int
+	line    int
+	column  int
+	pending bool
+}
+
+func (m *streamer) Run(op circuit.Op) (int, error) {
+	if m.call == nil {
+		m.call = op.Call
+	}
+
+	return m.Machine.Run(op)
+}
+
+// next sends the state after the previous statement, and starts the statement at the position.
+func (m *streamer) next(line, column int) error {
+	if err := m.flush(); err != nil {
+		return err
+	}
+
+	m.step, m.line, m.column, m.pending = m.step+1, line, column, true
+	return nil
+}
+
+// flush sends the state after the current statement.
+func (m *streamer) flush() error {
+	if !m.pending {
+		return nil
+	}
+
+	call := m.call
+	m.call, m.pending = nil, false
+	return m.send(m.step, m.line, m.column, call)
+}
@@ -0,0 +1,177 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/gen/quasar/v1/quasarv1connect"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func stream(t *testing.T, req *quasarv1.SimulateStreamRequest) ([]*quasarv1.SimulateStreamResponse, error) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(quasarv1connect.NewQuasarServiceHandler(&handler.QuasarService{}))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s, err := quasarv1connect.NewQuasarServiceClient(srv.Client(), srv.URL).SimulateStream(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	var out []*quasarv1.SimulateStreamResponse
+	for s.Receive() {
+		out = append(out, s.Msg())
+	}
+
+	return out, s.Err()
+}
+
+func ExampleQuasarService_SimulateStream() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+
+qubit[2] q;
+h q[0];
+cx q[0], q[1];
+`
+
+	mux := http.NewServeMux()
+	mux.Handle(quasarv1connect.NewQuasarServiceHandler(&handler.QuasarService{}))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s, err := quasarv1connect.NewQuasarServiceClient(srv.Client(), srv.URL).SimulateStream(
+		context.Background(),
+		connect.NewRequest(&quasarv1.SimulateStreamRequest{
+			Request: &quasarv1.SimulateRequest{
+				Code: code,
+			},
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer s.Close()
+
+	for s.Receive() {
+		msg := s.Msg()
+		fmt.Printf("%d %d:%d %s %v\n", msg.Step, msg.Line, msg.Column, msg.Gate, msg.Qubits)
+		for _, state := range msg.States {
+			fmt.Println(state.BinaryString, state.Probability)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// 3 6:0  []
+	// [00] 1
+	// 4 7:0 h [0]
+	// [00] 0.5
+	// [10] 0.5
+	// 5 8:0 cx [0 1]
+	// [00] 0.5
+	// [11] 0.5
+}
+
+func TestQuasarService_SimulateStream(t *testing.T) {
+	code := "qubit[2] q;\nbit[2] c;\nU(pi/2, 0, pi) q;\nc = measure q;\nreset q;"
+
+	cases := []struct {
+		maxSnapshots *int32
+		lines        []int32
+		want         []int32
+	}{
+		{nil, nil, []int32{1, 2, 3, 4, 5}},
+		{new(int32(2)), nil, []int32{1, 2}},
+		{nil, []int32{4, 5}, []int32{4, 5}},
+		{new(int32(1)), []int32{5}, []int32{5}},
+	}
+
+	for _, c := range cases {
+		got, err := stream(t, &quasarv1.SimulateStreamRequest{
+			Request: &quasarv1.SimulateRequest{
+				Code: code,
+				Seed: new(uint64(1)),
+			},
+			MaxSnapshots: c.maxSnapshots,
+			Lines:        c.lines,
+		})
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+
+		lines := make([]int32, len(got))
+		for i, msg := range got {
+			lines[i] = msg.Line
+		}
+
+		if fmt.Sprint(lines) != fmt.Sprint(c.want) {
+			t.Errorf("got=%v, want=%v", lines, c.want)
+		}
+
+		// reset leaves |00>
+		if last := got[len(got)-1]; last.Line == 5 && (len(last.States) != 1 || last.States[0].BinaryString[0] != "00") {
+			t.Errorf("got=%v", last.States)
+		}
+	}
+}
+
+func TestQuasarService_SimulateStream_invalid(t *testing.T) {
+	cases := []*quasarv1.SimulateStreamRequest{
+		{},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;"}, MaxSnapshots: new(int32(0))},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;"}, MaxSnapshots: new(int32(1001))},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;", Precision: new(int32(-1))}},
+		{Request: &quasarv1.SimulateRequest{Code: "bit c;"}},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;", Backend: quasarv1.Backend_BACKEND_DENSITY_MATRIX}},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;", Noise: &quasarv1.NoiseModel{}}},
+		{Request: &quasarv1.SimulateRequest{Code: "qubit q;", Shots: new(int32(10))}},
+	}
+
+	for _, c := range cases {
+		if _, err := stream(t, c); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
+
+func TestQuasarService_SimulateStream_feedback(t *testing.T) {
+	got, err := stream(t, &quasarv1.SimulateStreamRequest{
+		Request: &quasarv1.SimulateRequest{
+			Code: "qubit q;\nbit c;\nU(pi, 0, pi) q;\nc = measure q;\nif (c == 1) {\n  U(pi, 0, pi) q;\n}",
+		},
+	})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	var lines []int32
+	for _, msg := range got {
+		lines = append(lines, msg.Line)
+	}
+
+	if fmt.Sprint(lines) != "[1 2 3 4 5 6]" {
+		t.Errorf("got=%v", lines)
+	}
+
+	// the measured bit flips the qubit back
+	if last := got[len(got)-1]; len(last.States) != 1 || last.States[0].BinaryString[0] != "0" {
+		t.Errorf("got=%v", last.States)
+	}
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/route"
+	"github.com/itsubaki/quasar/transpile"
+)
+
+const (
+	maxTranspileQubits   = 1024
+	maxTranspileInputOps = 10000  // operations of the program before it is transpiled
+	maxTranspileOps      = 100000 // operations of the transpiled program
+	maxRouteQubits       = 1024   // physical qubits of the coupling map
+	maxRouteEdges        = 10000
+)
+
+var (
+	ErrBasisNotFound      = errors.New("basis not found")
+	ErrInvalidCouplingMap = errors.New("invalid coupling map")
+)
+
+// Transpile decomposes the program into the basis gates and returns it as an OpenQASM 3 program.
+// Multi-controlled gates are decomposed without ancillas, so the number of gates grows quickly with the controls.
+func (s *QuasarService) Transpile(
+	ctx context.Context,
+	req *connect.Request[quasarv1.TranspileRequest],
+) (*connect.Response[quasarv1.TranspileResponse], error) {
+	if req.Msg.Program == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrProgramNotFound)
+	}
+
+	if len(req.Msg.Basis) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrBasisNotFound)
+	}
+
+	c, err := s.compile(ctx, req.Msg.Program, nil,
+		compiler.WithMaxQubits(maxTranspileQubits),
+		compiler.WithMaxOps(maxTranspileInputOps),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	transpiled, err := transpile.Transpile(c, req.Msg.Basis, transpile.WithMaxOps(maxTranspileOps))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var routing *quasarv1.Routing
+	if len(req.Msg.CouplingMap) > 0 {
+		edges, err := Edges(req.Msg.CouplingMap)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		r, err := route.Route(transpiled, edges, route.WithMaxOps(maxTranspileOps))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		// the swaps are decomposed into the basis gates
+		transpiled, err = transpile.Transpile(r.Circuit, req.Msg.Basis, transpile.WithMaxOps(maxTranspileOps))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		routing = Routing(r)
+	}
+
+	return connect.NewResponse(&quasarv1.TranspileResponse{
+		Code:    transpile.QASM(transpiled),
+		Metrics: Metrics(transpiled),
+		Routing: routing,
+	}), nil
+}
+
+// validateRouting compiles the program and routes it onto the coupling map.
+// The code is invalid if it has more qubits than the coupling map, or its qubits are not connected.
+func (s *QuasarService) validateRouting(ctx context.Context, p *quasarv1.Program, coupling []*quasarv1.Edge) (*quasarv1.ValidateResponse, error) {
+	c, err := s.compile(ctx, p, nil,
+		compiler.WithMaxQubits(maxTranspileQubits),
+		compiler.WithMaxOps(maxTranspileInputOps),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	// multi-controlled gates are decomposed into two-qubit gates
+	transpiled, err := transpile.Transpile(c, []string{"U", "cx"}, transpile.WithMaxOps(maxTranspileOps))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	edges, err := Edges(coupling)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	r, err := route.Route(transpiled, edges, route.WithMaxOps(maxTranspileOps))
+	if errors.Is(err, route.ErrTooManyQubits) || errors.Is(err, route.ErrDisconnected) {
+		msg := err.Error()
+		return &quasarv1.ValidateResponse{
+			Valid:   false,
+			Message: &msg,
+		}, nil
+	}
+
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return &quasarv1.ValidateResponse{
+		Valid:   true,
+		Routing: Routing(r),
+	}, nil
+}
+
+// Edges returns the edges of the coupling map.
+// The coupling map is limited in its edges and physical qubits, since the router keeps the distances of all pairs.
+func Edges(coupling []*quasarv1.Edge) ([][2]int, error) {
+	if len(coupling) > maxRouteEdges {
+		return nil, fmt.Errorf("edges=%d, max=%d: %w", len(coupling), maxRouteEdges, ErrInvalidCouplingMap)
+	}
+
+	out := make([][2]int, len(coupling))
+	for i, e := range coupling {
+		if e.A >= maxRouteQubits || e.B >= maxRouteQubits {
+			return nil, fmt.Errorf("[%d %d]: physical qubits must be less than %d: %w", e.A, e.B, maxRouteQubits, ErrInvalidCouplingMap)
+		}
+
+		out[i] = [2]int{int(e.A), int(e.B)}
+	}
+
+	return out, nil
+}
+
+func Routing(r *route.Result) *quasarv1.Routing {
+	int32s := func(v []int) []int32 {
+		out := make([]int32, len(v))
+		for i, p := range v {
+			out[i] = int32(p)
+		}
+
+		return out
+	}
+
+	return &quasarv1.Routing{
+		Layout:      int32s(r.Layout),
+		FinalLayout: int32s(r.Final),
+		Swaps:       int32(r.Swaps),
+	}
+}
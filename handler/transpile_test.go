@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+)
+
+func ExampleQuasarService_Transpile() {
+	code := `
+OPENQASM 3.0;
+gate h q { U(pi/2.0, 0, pi) q; }
+gate x q { U(pi, 0, pi) q; }
+
+qubit[2] q;
+h q[0];
+ctrl @ x q[0], q[1];
+`
+
+	resp, err := (&handler.QuasarService{}).Transpile(context.Background(), connect.NewRequest(&quasarv1.TranspileRequest{
+		Program: &quasarv1.Program{Code: code},
+		Basis:   []string{"rz", "sx", "x", "cx"},
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(resp.Msg.Code)
+	fmt.Println(resp.Msg.Metrics.Gates)
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// gate rz(lambda) q { gphase(-lambda / 2); U(0, 0, lambda) q; }
+	// gate sx q { gphase(pi / 4); U(pi / 2, -pi / 2, pi / 2) q; }
+	// gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }
+	//
+	// qubit[2] q;
+	//
+	// rz(pi/2) q[0];
+	// sx q[0];
+	// rz(pi/2) q[0];
+	// cx q[0], q[1];
+	// map[U:1 cx:1 s:2]
+}
+
+func TestQuasarService_Transpile(t *testing.T) {
+	cases := []struct {
+		code  string
+		basis []string
+	}{
+		{"qubit[3] q; ctrl(2) @ U(pi, 0, pi) q[0], q[1], q[2];", []string{"rz", "sx", "x", "cx"}},
+		{"qubit[3] q; negctrl @ ctrl @ U(0.1, 0.2, 0.3) q[0], q[1], q[2];", []string{"u3", "cz"}},
+		{"qubit[4] q; gate g a, b { U(pi/2, 0, pi) a; ctrl @ U(0, 0, pi/4) a, b; } ctrl(2) @ g q[0], q[1], q[2], q[3];", []string{"rz", "ry", "cx"}},
+		{"qubit[2] q; inv @ pow(0.5) @ U(pi, 0, pi) q[0]; ctrl @ gphase(pi/3) q[1];", []string{"rz", "rx", "cz"}},
+	}
+
+	svc := &handler.QuasarService{}
+	for _, c := range cases {
+		resp, err := svc.Transpile(context.Background(), connect.NewRequest(&quasarv1.TranspileRequest{
+			Program: &quasarv1.Program{Code: c.code},
+			Basis:   c.basis,
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", c.code, err)
+		}
+
+		eq, err := svc.Equivalent(context.Background(), connect.NewRequest(&quasarv1.EquivalentRequest{
+			A: &quasarv1.Program{Code: c.code},
+			B: &quasarv1.Program{Code: resp.Msg.Code},
+		}))
+		if err != nil {
+			t.Fatalf("%s: %v", resp.Msg.Code, err)
+		}
+
+		if !eq.Msg.Equivalent {
+			t.Errorf("%s: got=%v", c.code, resp.Msg.Code)
+		}
+	}
+}
+
+func TestQuasarService_Transpile_couplingMap(t *testing.T) {
+	resp, err := (&handler.QuasarService{}).Transpile(context.Background(), connect.NewRequest(&quasarv1.TranspileRequest{
+		Program:     &quasarv1.Program{Code: "qubit[3] q; U(pi/2, 0, pi) q[0]; ctrl @ U(pi, 0, pi) q[0], q[2];"},
+		Basis:       []string{"rz", "sx", "cz"},
+		CouplingMap: []*quasarv1.Edge{{A: 0, B: 1}, {A: 1, B: 2}},
+	}))
+	if err != nil {
+		t.Fatalf("transpile: %v", err)
+	}
+
+	if resp.Msg.Routing.Swaps != 1 {
+		t.Errorf("got=%v, want=1", resp.Msg.Routing.Swaps)
+	}
+
+	if resp.Msg.Metrics.Gates["cz"] != 4 {
+		t.Errorf("got=%v, want=4", resp.Msg.Metrics.Gates)
+	}
+
+	if strings.Contains(resp.Msg.Code, "q[0], q[2]") {
+		t.Errorf("got=%v", resp.Msg.Code)
+	}
+}
+
+func TestQuasarService_Transpile_error(t *testing.T) {
+	cases := []*quasarv1.TranspileRequest{
+		{Basis: []string{"U", "cx"}},
+		{Program: &quasarv1.Program{Code: "qubit q; U(0, 0, 0) q;"}},
+		{Program: &quasarv1.Program{Code: "qubit q; U(0, 0, 0) q;"}, Basis: []string{"h", "cx"}},
+		{Program: &quasarv1.Program{Code: "qubit q; U(0, 0, 0) q;"}, Basis: []string{"rz", "cx"}},
+		{Program: &quasarv1.Program{Code: "qubit[3] q; U(0, 0, 0) q;"}, Basis: []string{"U", "cx"}, CouplingMap: []*quasarv1.Edge{{A: 0, B: 1}}},
+		{Program: &quasarv1.Program{Code: "qubit[3] q; U(0, 0, 0) q;"}, Basis: []string{"U", "cx"}, CouplingMap: []*quasarv1.Edge{{A: 0, B: 1 << 30}}},
+		{Program: &quasarv1.Program{Code: "qubit[3] q; U(0, 0, 0) q;"}, Basis: []string{"U", "cx"}, CouplingMap: make([]*quasarv1.Edge, 10001)},
+		{Program: &quasarv1.Program{Code: "qubit[11] q; ctrl(10) @ U(pi, 0, pi) q[0], q[1], q[2], q[3], q[4], q[5], q[6], q[7], q[8], q[9], q[10];"}, Basis: []string{"U", "cx"}},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{}).Transpile(context.Background(), connect.NewRequest(c)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("got=%v", err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/itsubaki/qasm/parser"
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/compiler"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/packed"
+)
+
+const maxUnitary = 10
+
+func (s *QuasarService) Unitary(
+	ctx context.Context,
+	req *connect.Request[quasarv1.UnitaryRequest],
+) (*connect.Response[quasarv1.UnitaryResponse], error) {
+	if len(strings.TrimSpace(req.Msg.Code)) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrCodeNotFound)
+	}
+
+	r, err := newRounding(req.Msg.Precision, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	code, err := s.source(ctx, req.Msg.Code, req.Msg.Includes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := parser.Parse(code)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	inputs, err := Bind(Declared(program), req.Msg.Inputs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// the matrix has as many entries as the state vector of 2n qubits
+	limit := maxUnitary
+	if s.MaxQubits > 0 {
+		limit = min(limit, s.MaxQubits/2)
+	}
+
+	opts := []compiler.Option{
+		compiler.WithContext(ctx),
+		compiler.WithInputs(inputs),
+		compiler.WithMaxQubits(limit),
+		compiler.WithMaxOps(s.MaxOps),
+	}
+
+	var c *circuit.Circuit
+	if req.Msg.Gate != nil {
+		c, err = compiler.CompileGate(program, req.Msg.GetGate(), req.Msg.Params, opts...)
+	} else {
+		// the body of the program runs only without the gate
+		if err := s.preflight(program, inputs, workload{qubits: limit, memory: matrixBytes, runs: 1}); err != nil {
+			return nil, connect.NewError(errorCode(err), err)
+		}
+
+		c, err = compiler.Compile(program, opts...)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if c.Qubits == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrQubitsNotFound)
+	}
+
+	u, err := circuit.Unitary(c)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	for i, v := range u {
+		u[i] = r.complex(v)
+	}
+
+	return connect.NewResponse(&quasarv1.UnitaryResponse{
+		Qubits: int32(c.Qubits),
+		Matrix: packed.Encode(u),
+	}), nil
+}
@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"os"
+	"testing"
+
+	"connectrpc.com/connect"
+	quasarv1 "github.com/itsubaki/quasar/gen/quasar/v1"
+	"github.com/itsubaki/quasar/handler"
+	"github.com/itsubaki/quasar/packed"
+)
+
+func ExampleQuasarService_Unitary() {
+	code, err := os.ReadFile("../testdata/qft.qasm")
+	if err != nil {
+		panic(err)
+	}
+
+	resp, err := (&handler.QuasarService{}).Unitary(context.Background(), connect.NewRequest(&quasarv1.UnitaryRequest{
+		Code: string(code),
+		Gate: new("cx"),
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	u, err := packed.Decode(resp.Msg.Matrix)
+	if err != nil {
+		panic(err)
+	}
+
+	n := 1 << resp.Msg.Qubits
+	for i := range n {
+		row := make([]float64, n)
+		for j := range row {
+			row[j] = cmplx.Abs(u[i*n+j])
+		}
+
+		fmt.Println(row)
+	}
+
+	// Output:
+	// [1 0 0 0]
+	// [0 1 0 0]
+	// [0 0 0 1]
+	// [0 0 1 0]
+}
+
+func TestQuasarService_Unitary(t *testing.T) {
+	code, err := os.ReadFile("../testdata/qft.qasm")
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+
+	// qft on 3 qubits, omega = exp(2*pi*i/8)
+	qft := make([]complex128, 64)
+	for j := range 8 {
+		for k := range 8 {
+			qft[j*8+k] = cmplx.Exp(complex(0, 2*math.Pi*float64(j*k)/8)) / complex(math.Sqrt(8), 0)
+		}
+	}
+
+	cases := []struct {
+		code   string
+		gate   *string
+		params []float64
+		want   []complex128
+	}{
+		{
+			string(code),
+			new("cr"),
+			[]float64{math.Pi / 2},
+			[]complex128{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1i},
+		},
+		{
+			string(code),
+			new("h"),
+			nil,
+			[]complex128{1 / math.Sqrt2, 1 / math.Sqrt2, 1 / math.Sqrt2, -1 / math.Sqrt2},
+		},
+		{
+			// the qft def without the initial x
+			"OPENQASM 3.0;\ngate h q { U(pi/2.0, 0, pi) q; }\ngate cx c, t { ctrl @ U(pi, 0, pi) c, t; }\ngate cr(theta) c, t { ctrl @ U(0, 0, theta) c, t; }\nqubit[3] q;\nh q[0];\ncr(pi/2) q[0], q[1];\ncr(pi/4) q[0], q[2];\nh q[1];\ncr(pi/2) q[1], q[2];\nh q[2];\ncx q[0], q[2];\ncx q[2], q[0];\ncx q[0], q[2];",
+			nil,
+			nil,
+			qft,
+		},
+	}
+
+	for _, c := range cases {
+		resp, err := (&handler.QuasarService{}).Unitary(context.Background(), connect.NewRequest(&quasarv1.UnitaryRequest{
+			Code:      c.code,
+			Gate:      c.gate,
+			Params:    c.params,
+			Precision: new(int32(0)),
+		}))
+		if err != nil {
+			t.Fatalf("unitary: %v", err)
+		}
+
+		got, err := packed.Decode(resp.Msg.Matrix)
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		if len(got) != len(c.want) {
+			t.Fatalf("got=%d, want=%d", len(got), len(c.want))
+		}
+
+		for i := range got {
+			if cmplx.Abs(got[i]-c.want[i]) > 1e-9 {
+				t.Errorf("%d: got=%v, want=%v", i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestQuasarService_Unitary_error(t *testing.T) {
+	cases := []struct {
+		maxQubits int
+		code      string
+		gate      *string
+	}{
+		{0, "", nil},
+		{0, "bit c;", nil},
+		{0, "qubit q; bit c; c = measure q;", nil},
+		{0, "qubit q; reset q;", nil},
+		{0, "qubit q;", new("foo")},
+		{0, "qubit[11] q;", nil},
+		{4, "qubit[3] q;", nil},
+	}
+
+	for _, c := range cases {
+		if _, err := (&handler.QuasarService{MaxQubits: c.maxQubits}).Unitary(context.Background(), connect.NewRequest(&quasarv1.UnitaryRequest{
+			Code: c.code,
+			Gate: c.gate,
+		})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%q: got=%v", c.code, err)
+		}
+	}
+}
+
+func TestQuasarService_Unitary_preflight(t *testing.T) {
+	cases := []struct {
+		svc  *handler.QuasarService
+		code string
+		gate *string
+		want connect.Code
+	}{
+		{&handler.QuasarService{MaxOps: 10}, "qubit q; for int i in [0:20] { U(0, 0, 0) q; }", nil, connect.CodeResourceExhausted},
+		{&handler.QuasarService{MaxBytes: 1024}, "qubit[4] q;", nil, connect.CodeResourceExhausted},
+		{&handler.QuasarService{MaxBytes: 1024}, "gate g q { U(0, 0, 0) q; } qubit[4] q;", new("g"), 0},
+	}
+
+	for _, c := range cases {
+		_, err := c.svc.Unitary(context.Background(), connect.NewRequest(&quasarv1.UnitaryRequest{
+			Code: c.code,
+			Gate: c.gate,
+		}))
+		if c.want == 0 {
+			if err != nil {
+				t.Errorf("%q: %v", c.code, err)
+			}
+
+			continue
+		}
+
+		if connect.CodeOf(err) != c.want {
+			t.Errorf("%q: got=%v", c.code, err)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package include
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itsubaki/quasar/lexer"
+)
+
+// maxDepth is the maximum depth of nested includes.
+const maxDepth = 8
+
+// StdGates is the standard gate library of OpenQASM 3.
+// The phase gates are written with U instead of pow and ctrl @ gphase, and have the same unitaries.
+const StdGates = `// OpenQASM 3 standard gate library
+gate p(lambda) a { U(0, 0, lambda) a; }
+gate x a { U(pi, 0, pi) a; }
+gate y a { U(pi, pi / 2, pi / 2) a; }
+gate z a { p(pi) a; }
+gate h a { U(pi / 2, 0, pi) a; }
+gate s a { U(0, 0, pi / 2) a; }
+gate sdg a { U(0, 0, -pi / 2) a; }
+gate t a { U(0, 0, pi / 4) a; }
+gate tdg a { U(0, 0, -pi / 4) a; }
+gate sx a { gphase(pi / 4); U(pi / 2, -pi / 2, pi / 2) a; }
+gate rx(theta) a { U(theta, -pi / 2, pi / 2) a; }
+gate ry(theta) a { U(theta, 0, 0) a; }
+gate rz(lambda) a { gphase(-lambda / 2); U(0, 0, lambda) a; }
+gate cx c, t { ctrl @ x c, t; }
+gate cy a, b { ctrl @ y a, b; }
+gate cz a, b { ctrl @ z a, b; }
+gate cp(lambda) a, b { ctrl @ p(lambda) a, b; }
+gate crx(theta) a, b { ctrl @ rx(theta) a, b; }
+gate cry(theta) a, b { ctrl @ ry(theta) a, b; }
+gate crz(theta) a, b { ctrl @ rz(theta) a, b; }
+gate ch a, b { ctrl @ h a, b; }
+gate swap a, b { cx a, b; cx b, a; cx a, b; }
+gate ccx a, b, c { ctrl @ ctrl @ x a, b, c; }
+gate cswap a, b, c { ctrl @ swap a, b, c; }
+gate cu(theta, phi, lambda, gamma) c, t { p(gamma) c; ctrl @ U(theta, phi, lambda) c, t; }
+// OpenQASM 2 backwards compatibility
+gate CX c, t { ctrl @ U(pi, 0, pi) c, t; }
+gate phase(lambda) q { U(0, 0, lambda) q; }
+gate cphase(lambda) a, b { ctrl @ phase(lambda) a, b; }
+gate id a { U(0, 0, 0) a; }
+gate u1(lambda) q { U(0, 0, lambda) q; }
+gate u2(phi, lambda) q { gphase(-(phi + lambda + pi / 2) / 2); U(pi / 2, phi, lambda) q; }
+gate u3(theta, phi, lambda) q { gphase(-(phi + lambda + theta) / 2); U(theta, phi, lambda) q; }
+`
+
+// Func returns the contents of the file by name, and false if it is not found.
+type Func func(name string) (string, bool, error)
+
+// Resolve replaces each include statement with the contents of the file.
+// stdgates.inc is built in, and the other files are looked up in the maps in order, e.g. the server libraries and then the request.
+// The files are never read from the filesystem. Nested includes are resolved, and a file is included at most once.
+// The contents are written on the line of the include statement without comments, so the line numbers of the statements do not change.
+// It returns a *lexer.Error if a file is not found.
+func Resolve(code string, files ...map[string]string) (string, error) {
+	return ResolveFunc(code, func(name string) (string, bool, error) {
+		for _, f := range files {
+			if contents, ok := f[name]; ok {
+				return contents, true, nil
+			}
+		}
+
+		return "", false, nil
+	})
+}
+
+// ResolveFunc is like Resolve, but looks up the files other than stdgates.inc with the function.
+// The errors of the function are returned as they are.
+// It returns a *lexer.Error if a file is not found, or if a file includes itself directly or through the other files.
+func ResolveFunc(code string, lookup Func) (string, error) {
+	return resolve(code, lookup, make(map[string]bool), make(map[string]bool), 0)
+}
+
+func resolve(code string, lookup Func, seen, active map[string]bool, depth int) (string, error) {
+	tokens := lexer.Tokenize(code)
+
+	var sb strings.Builder
+	var last int
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.Kind != lexer.Ident || t.Text != "include" {
+			continue
+		}
+
+		j := lexer.Next(tokens, i)
+		k := lexer.Next(tokens, j)
+		if j < 0 || tokens[j].Kind != lexer.String || k < 0 || tokens[k].Text != ";" {
+			return "", t.Errorf("invalid include")
+		}
+
+		name := strings.Trim(tokens[j].Text, `"`)
+		if depth >= maxDepth {
+			return "", tokens[j].Errorf("include %q is nested too deeply", name)
+		}
+
+		if active[name] {
+			return "", tokens[j].Errorf("include %q is cyclic", name)
+		}
+
+		var contents string
+		if !seen[name] {
+			c, ok, err := find(name, lookup)
+			if err != nil {
+				return "", err
+			}
+
+			if !ok {
+				return "", tokens[j].Errorf("include %q not found", name)
+			}
+
+			contents = c
+		}
+		seen[name] = true
+
+		active[name] = true
+		resolved, err := resolve(contents, lookup, seen, active, depth+1)
+		delete(active, name)
+		if err != nil {
+			if e, ok := errors.AsType[*lexer.Error](err); ok {
+				// the position in the including program
+				return "", tokens[j].Errorf("%s: %s", name, e.Error())
+			}
+
+			return "", err
+		}
+
+		sb.WriteString(code[last:t.Offset])
+		sb.WriteString(flatten(resolved))
+		last = tokens[k].Offset + 1
+		i = k
+	}
+
+	sb.WriteString(code[last:])
+	return sb.String(), nil
+}
+
+func find(name string, lookup Func) (string, bool, error) {
+	if name == "stdgates.inc" {
+		return StdGates, true, nil
+	}
+
+	return lookup(name)
+}
+
+// Import returns the code with an include statement for each name after the version statement, on the same line.
+// The include statements are written at the start of the code if there is no version statement.
+func Import(code string, names ...string) string {
+	if len(names) == 0 {
+		return code
+	}
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, " include %q;", name)
+	}
+	includes := strings.TrimSpace(sb.String())
+
+	tokens := lexer.Tokenize(code)
+	for i, t := range tokens {
+		if t.Kind == lexer.Comment {
+			continue
+		}
+
+		if t.Text != "OPENQASM" {
+			break
+		}
+
+		// OPENQASM 3.0; include "a"; include "b";
+		for k := i; k < len(tokens); k++ {
+			if tokens[k].Text == ";" {
+				end := tokens[k].Offset + 1
+				return code[:end] + " " + includes + code[end:]
+			}
+		}
+
+		break
+	}
+
+	return includes + " " + code
+}
+
+// Definitions returns the include statements and the gate and def definitions of the program on one line.
+// The other statements, e.g. the declarations and the gate calls of the program, are removed.
+func Definitions(code string) string {
+	var defs []string
+	var depth int
+	start, from := true, -1
+	for _, t := range lexer.Tokenize(code) {
+		if t.Kind == lexer.Comment {
+			continue
+		}
+
+		if start && t.Kind == lexer.Ident && (t.Text == "gate" || t.Text == "def" || t.Text == "include") {
+			from = t.Offset
+		}
+		start = false
+
+		switch t.Text {
+		case "{":
+			depth++
+			continue
+		case "}":
+			depth--
+		case ";":
+		default:
+			continue
+		}
+
+		if depth > 0 {
+			continue
+		}
+
+		// end of the statement
+		if from >= 0 {
+			defs = append(defs, flatten(code[from:t.Offset+1]))
+		}
+		start, from, depth = true, -1, 0
+	}
+
+	return strings.Join(defs, " ")
+}
+
+// flatten returns the code on one line, with the comments and the version statement removed.
+func flatten(code string) string {
+	var sb strings.Builder
+	var last int
+	tokens := lexer.Tokenize(code)
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.Kind == lexer.Comment:
+			sb.WriteString(code[last:t.Offset])
+			sb.WriteByte(' ')
+			last = t.Offset + len(t.Text)
+		case t.Kind == lexer.Ident && t.Text == "OPENQASM":
+			// OPENQASM 3.0;
+			k := i
+			for k < len(tokens) && tokens[k].Text != ";" {
+				k++
+			}
+
+			sb.WriteString(code[last:t.Offset])
+			if k < len(tokens) {
+				last, i = tokens[k].Offset+1, k
+				continue
+			}
+
+			last, i = len(code), k
+		}
+	}
+
+	sb.WriteString(code[last:])
+
+	out := strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(sb.String())
+	return strings.Join(strings.Fields(out), " ")
+}
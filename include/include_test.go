@@ -0,0 +1,240 @@
+package include_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itsubaki/quasar/include"
+	"github.com/itsubaki/quasar/lexer"
+)
+
+func ExampleResolve() {
+	code := `OPENQASM 3.0;
+include "bell.inc";
+qubit[2] q;
+bell q[0], q[1];
+`
+
+	files := map[string]string{
+		"bell.inc": `
+OPENQASM 3.0;
+// h and cx
+gate bell a, b {
+  U(pi / 2, 0, pi) a;
+  ctrl @ U(pi, 0, pi) a, b;
+}
+`,
+	}
+
+	resolved, err := include.Resolve(code, files)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(resolved)
+
+	// Output:
+	// OPENQASM 3.0;
+	// gate bell a, b { U(pi / 2, 0, pi) a; ctrl @ U(pi, 0, pi) a, b; }
+	// qubit[2] q;
+	// bell q[0], q[1];
+}
+
+func ExampleImport() {
+	code := `OPENQASM 3.0;
+qubit[2] q;
+bell q[0], q[1];
+`
+
+	fmt.Print(include.Import(code, "quasar:AMOYU8a1VLEfWjqf"))
+
+	// Output:
+	// OPENQASM 3.0; include "quasar:AMOYU8a1VLEfWjqf";
+	// qubit[2] q;
+	// bell q[0], q[1];
+}
+
+func ExampleDefinitions() {
+	code := `OPENQASM 3.0;
+include "stdgates.inc";
+
+// oracle
+gate bell a, b {
+  h a;
+  cx a, b;
+}
+
+def flip(qubit q) -> bit {
+  x q;
+  return measure q;
+}
+
+qubit[2] q;
+bell q[0], q[1];
+if (flip(q[0])) { x q[1]; }
+`
+
+	fmt.Println(include.Definitions(code))
+
+	// Output:
+	// include "stdgates.inc"; gate bell a, b { h a; cx a, b; } def flip(qubit q) -> bit { x q; return measure q; }
+}
+
+func TestResolve(t *testing.T) {
+	server := map[string]string{
+		"lib.inc":    "include \"stdgates.inc\";\ngate bell a, b { h a; cx a, b; }",
+		"shared.inc": "gate shared a { x a; }",
+	}
+
+	request := map[string]string{
+		"mine.inc":   "include \"lib.inc\"; // nested\ngate mine a { bell a, a; }",
+		"shared.inc": "gate overridden a { y a; }",
+	}
+
+	cases := []struct {
+		code string
+		want []string
+		not  []string
+	}{
+		{
+			code: "OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit q;\nh q;",
+			want: []string{"gate h a { U(pi / 2, 0, pi) a; }", "gate u3(theta, phi, lambda) q {"},
+			not:  []string{"//", "include"},
+		},
+		{
+			code: "include \"mine.inc\";\ninclude \"stdgates.inc\";",
+			want: []string{"gate mine a", "gate bell a, b", "gate cx c, t"},
+			not:  []string{"include", "nested"},
+		},
+		{
+			code: "include \"shared.inc\";",
+			want: []string{"gate shared a"},
+			not:  []string{"overridden"},
+		},
+		{
+			code: "// include \"missing.inc\";\nqubit q;",
+			want: []string{"// include \"missing.inc\";"},
+		},
+	}
+
+	for _, c := range cases {
+		got, err := include.Resolve(c.code, server, request)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+
+		if strings.Count(got, "\n") != strings.Count(c.code, "\n") {
+			t.Errorf("got=%v", got)
+		}
+
+		for _, w := range c.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("got=%v, want=%v", got, w)
+			}
+		}
+
+		for _, n := range c.not {
+			if strings.Contains(got, n) {
+				t.Errorf("got=%v, not=%v", got, n)
+			}
+		}
+
+		if strings.Count(got, "gate h a") > 1 {
+			t.Errorf("got=%v", got)
+		}
+	}
+}
+
+func TestResolve_error(t *testing.T) {
+	files := map[string]string{
+		"broken.inc": "\ninclude \"missing.inc\";",
+	}
+
+	cases := []struct {
+		code   string
+		line   int
+		column int
+		msg    string
+	}{
+		{"qubit q;\ninclude \"missing.inc\";", 2, 8, `include "missing.inc" not found`},
+		{"include stdgates;", 1, 0, "invalid include"},
+		{"include \"/etc/passwd\";", 1, 8, `include "/etc/passwd" not found`},
+		{"\n\ninclude \"broken.inc\";", 3, 8, `broken.inc: line 2:8 include "missing.inc" not found`},
+	}
+
+	for _, c := range cases {
+		_, err := include.Resolve(c.code, files)
+
+		e, ok := errors.AsType[*lexer.Error](err)
+		if !ok {
+			t.Fatalf("got=%v", err)
+		}
+
+		if e.Line != c.line || e.Column != c.column || e.Message != c.msg {
+			t.Errorf("got=%v, want=%d:%d %s", e, c.line, c.column, c.msg)
+		}
+	}
+}
+
+func TestResolveFunc(t *testing.T) {
+	files := map[string]string{
+		"a.inc": "include \"b.inc\"; gate a q { b q; }",
+		"b.inc": "include \"stdgates.inc\"; gate b q { x q; }",
+		"c.inc": "include \"d.inc\";",
+		"d.inc": "\ninclude \"c.inc\";",
+	}
+
+	var calls []string
+	lookup := func(name string) (string, bool, error) {
+		calls = append(calls, name)
+		if name == "error.inc" {
+			return "", false, errors.New("something went wrong")
+		}
+
+		contents, ok := files[name]
+		return contents, ok, nil
+	}
+
+	got, err := include.ResolveFunc("include \"a.inc\"; include \"b.inc\";", lookup)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	if strings.Count(got, "gate b q") != 1 || !strings.Contains(got, "gate a q") {
+		t.Errorf("got=%v", got)
+	}
+
+	// stdgates.inc is built in, and b.inc is looked up once
+	if len(calls) != 2 || calls[0] != "a.inc" || calls[1] != "b.inc" {
+		t.Errorf("got=%v", calls)
+	}
+
+	cases := []struct {
+		code string
+		msg  string
+	}{
+		{"include \"c.inc\";", `c.inc: line 1:8 d.inc: line 2:8 include "c.inc" is cyclic`},
+		{"include \"error.inc\";", "something went wrong"},
+	}
+
+	for _, c := range cases {
+		_, err := include.ResolveFunc(c.code, lookup)
+		if err == nil {
+			t.Fatalf("got=%v", err)
+		}
+
+		if e, ok := errors.AsType[*lexer.Error](err); ok {
+			if e.Message != c.msg {
+				t.Errorf("got=%v, want=%v", e.Message, c.msg)
+			}
+
+			continue
+		}
+
+		if err.Error() != c.msg {
+			t.Errorf("got=%v, want=%v", err, c.msg)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Ident Kind = iota
+	Number
+	String
+	Comment
+	Symbol
+)
+
+// Token is a token of an OpenQASM program.
+// Line starts at 1 and Column starts at 0, as in the syntax errors of the parser.
+type Token struct {
+	Kind   Kind
+	Text   string
+	Offset int
+	Line   int
+	Column int
+}
+
+// Errorf returns an error at the position of the token.
+func (t Token) Errorf(format string, a ...any) *Error {
+	return &Error{
+		Line:    t.Line,
+		Column:  t.Column,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Error is an error at a position of an OpenQASM program.
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d:%d %s", e.Line, e.Column, e.Message)
+}
+
+// Tokenize splits the program into identifiers, numbers, strings, comments and symbols.
+func Tokenize(code string) []Token {
+	var tokens []Token
+	line, start := 1, 0
+	for i := 0; i < len(code); {
+		c := code[i]
+		t := Token{Offset: i, Line: line, Column: i - start}
+
+		var end int
+		switch {
+		case c == '\n':
+			line, start = line+1, i+1
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case strings.HasPrefix(code[i:], "//"):
+			t.Kind, end = Comment, strings.IndexByte(code[i:], '\n')
+		case strings.HasPrefix(code[i:], "/*"):
+			t.Kind, end = Comment, strings.Index(code[i+2:], "*/")
+			if end >= 0 {
+				end += 4
+			}
+		case c == '"':
+			t.Kind, end = String, strings.IndexByte(code[i+1:], '"')
+			if end >= 0 {
+				end += 2
+			}
+		case isLetter(c):
+			t.Kind, end = Ident, 1
+			for i+end < len(code) && (isLetter(code[i+end]) || isDigit(code[i+end])) {
+				end++
+			}
+		case isDigit(c) || c == '.':
+			t.Kind, end = Number, 1
+			for i+end < len(code) && (isDigit(code[i+end]) || strings.IndexByte(".eE", code[i+end]) >= 0) {
+				end++
+			}
+		default:
+			t.Kind, end = Symbol, 1
+		}
+
+		if end < 0 {
+			end = len(code) - i
+		}
+
+		t.Text = code[i : i+end]
+		tokens = append(tokens, t)
+
+		// the lines in the comments
+		for _, r := range t.Text {
+			if r == '\n' {
+				line++
+			}
+		}
+
+		if n := strings.LastIndexByte(t.Text, '\n'); n >= 0 {
+			start = i + n + 1
+		}
+
+		i += end
+	}
+
+	return tokens
+}
+
+// Next returns the index of the next token after i that is not a comment, or -1.
+func Next(tokens []Token, i int) int {
+	if i < 0 {
+		return -1
+	}
+
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].Kind != Comment {
+			return j
+		}
+	}
+
+	return -1
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
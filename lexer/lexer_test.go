@@ -0,0 +1,41 @@
+package lexer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/quasar/lexer"
+)
+
+func ExampleTokenize() {
+	code := `OPENQASM 3.0;
+include "stdgates.inc"; // comment
+/* multi
+   line */ U(1.5e2, 0, pi) q[0];`
+
+	for _, t := range lexer.Tokenize(code) {
+		fmt.Printf("%d:%d %d %q\n", t.Line, t.Column, t.Kind, t.Text)
+	}
+
+	// Output:
+	// 1:0 0 "OPENQASM"
+	// 1:9 1 "3.0"
+	// 1:12 4 ";"
+	// 2:0 0 "include"
+	// 2:8 2 "\"stdgates.inc\""
+	// 2:22 4 ";"
+	// 2:24 3 "// comment"
+	// 3:0 3 "/* multi\n   line */"
+	// 4:11 0 "U"
+	// 4:12 4 "("
+	// 4:13 1 "1.5e2"
+	// 4:18 4 ","
+	// 4:20 1 "0"
+	// 4:21 4 ","
+	// 4:23 0 "pi"
+	// 4:25 4 ")"
+	// 4:27 0 "q"
+	// 4:28 4 "["
+	// 4:29 1 "0"
+	// 4:30 4 "]"
+	// 4:31 4 ";"
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
@@ -27,20 +28,56 @@ var (
 	cprof       = os.Getenv("USE_CPROF")
 	port        = os.Getenv("PORT")
 	timeout     = 5 * time.Second
-	maxQubits   = func() int {
-		v := os.Getenv("MAX_QUBITS")
-		if v == "" {
-			return 0 // no limit
+	maxQubits   = limit("MAX_QUBITS", 0)       // no limit
+	maxBytes    = limit("MAX_BYTES", 1<<30)    // 1 GiB
+	maxOps      = limit("MAX_OPS", 10_000_000) // operations of a simulation
+	includeDir  = os.Getenv("INCLUDE_DIR")
+)
+
+// limit returns the value of the environment variable, or the fallback if it is not set.
+// 0 is no limit.
+func limit(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	max, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+
+	return max
+}
+
+// includes returns the contents of the *.inc files in the directory by name.
+// They are read once at startup, so the programs never read the filesystem.
+func includes(dir string) map[string]string {
+	files := make(map[string]string)
+	if dir == "" {
+		return files
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatalf("read include dir: %v", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".inc" {
+			continue
 		}
 
-		max, err := strconv.Atoi(v)
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
 		if err != nil {
-			log.Fatalf("invalid MAX_QUBITS: %v", err)
+			log.Fatalf("read include: %v", err)
 		}
 
-		return max
-	}()
-)
+		files[e.Name()] = string(b)
+	}
+
+	return files
+}
 
 func main() {
 	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -75,6 +112,13 @@ func main() {
 			Collection: "snippet",
 			Client:     fsc,
 		},
+		&store.FirestoreJobStore{
+			Collection: "job",
+			Client:     fsc,
+		},
+		handler.WithMaxBytes(int64(maxBytes)),
+		handler.WithMaxOps(maxOps),
+		handler.WithIncludes(includes(includeDir)),
 	)
 	if err != nil {
 		log.Fatalf("new handler: %v", err)
@@ -110,5 +154,8 @@ func main() {
 		log.Fatalf("http server shutdown: %v", err)
 	}
 
+	// cancel the jobs
+	h.Close()
+
 	log.Println("shutdown finished")
 }
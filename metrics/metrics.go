@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/stabilizer"
+)
+
+// Metrics is the cost of running a circuit.
+// Gates counts the operations by name, e.g. "h", "cx" or "measure",
+// where a gate has a "c" for each control and negated control, e.g. "ccx".
+// TwoQubitGates counts the gates on two qubits, and TCount counts the uncontrolled t and tdg gates.
+type Metrics struct {
+	Qubits        int
+	Depth         int
+	Gates         map[string]int
+	TwoQubitGates int
+	TCount        int
+	Clifford      bool
+}
+
+// Analyze returns the metrics of the circuit.
+// The depth is the number of layers of gates, measurements and resets.
+// A barrier aligns its qubits, and global phases are not counted.
+func Analyze(c *circuit.Circuit) Metrics {
+	m := Metrics{
+		Qubits:   c.Qubits,
+		Gates:    make(map[string]int),
+		Clifford: stabilizer.IsClifford(c),
+	}
+
+	layer := make([]int, c.Qubits)
+	for _, op := range c.Ops {
+		switch op.Kind {
+		case circuit.Gate:
+			ctrl := len(op.Controls) + len(op.NegControls)
+			name, _ := circuit.Name(op)
+			m.Gates[strings.Repeat("c", ctrl)+name]++
+
+			if ctrl == 1 {
+				m.TwoQubitGates++
+			}
+
+			if ctrl == 0 && (name == "t" || name == "tdg") {
+				m.TCount++
+			}
+		case circuit.Measure, circuit.Reset:
+			m.Gates[op.Kind.String()]++
+		case circuit.Barrier:
+			// aligns the qubits without adding a layer
+			var top int
+			for _, q := range op.Qubits {
+				top = max(top, layer[q])
+			}
+
+			for _, q := range op.Qubits {
+				layer[q] = top
+			}
+
+			continue
+		default:
+			continue
+		}
+
+		qubits := op.Operands()
+
+		var top int
+		for _, q := range qubits {
+			top = max(top, layer[q])
+		}
+
+		for _, q := range qubits {
+			layer[q] = top + 1
+		}
+
+		m.Depth = max(m.Depth, top+1)
+	}
+
+	return m
+}
@@ -0,0 +1,98 @@
+package metrics_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/metrics"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ExampleAnalyze() {
+	// GHZ state
+	c := &circuit.Circuit{
+		Qubits: 3,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+			gate(math.Pi, 0, math.Pi, 2, 1),
+			{Kind: circuit.Measure, Target: 0, Clbit: 0},
+			{Kind: circuit.Measure, Target: 1, Clbit: 1},
+			{Kind: circuit.Measure, Target: 2, Clbit: 2},
+		},
+	}
+
+	m := metrics.Analyze(c)
+	fmt.Println(m.Qubits, m.Depth, m.TwoQubitGates, m.TCount, m.Clifford)
+	fmt.Println(m.Gates)
+
+	// Output:
+	// 3 4 2 0 true
+	// map[cx:2 h:1 measure:3]
+}
+
+func TestAnalyze(t *testing.T) {
+	cases := []struct {
+		ops      []circuit.Op
+		depth    int
+		gates    string
+		two      int
+		tcount   int
+		clifford bool
+	}{
+		{nil, 0, "map[]", 0, 0, true},
+		{
+			[]circuit.Op{
+				gate(0, 0, math.Pi/4, 0),
+				gate(0, 0, -math.Pi/4, 1),
+				gate(0, 0, math.Pi/4, 2),
+			},
+			1, "map[t:2 tdg:1]", 0, 3, false,
+		},
+		{
+			[]circuit.Op{
+				gate(math.Pi, 0, math.Pi, 2, 0, 1),
+				gate(0, 0, math.Pi/4, 2, 0),
+				gate(0, 0, math.Pi, 1),
+			},
+			2, "map[ccx:1 ct:1 z:1]", 1, 0, false,
+		},
+		{
+			[]circuit.Op{
+				gate(math.Pi/2, 0, math.Pi, 0),
+				{Kind: circuit.Barrier, Qubits: []int{0, 1, 2}, Clbit: -1},
+				{Kind: circuit.GlobalPhase, Phase: math.Pi, Clbit: -1},
+				gate(math.Pi/2, 0, math.Pi, 2),
+				{Kind: circuit.Reset, Target: 1, Clbit: -1},
+			},
+			2, "map[h:2 reset:1]", 0, 0, true,
+		},
+		{
+			[]circuit.Op{
+				{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, NegControls: []int{0}, Clbit: -1},
+				gate(0.1, 0.2, 0.3, 2),
+			},
+			1, "map[U:1 cx:1]", 1, 0, false,
+		},
+	}
+
+	for _, c := range cases {
+		got := metrics.Analyze(&circuit.Circuit{Qubits: 3, Ops: c.ops})
+		if got.Qubits != 3 || got.Depth != c.depth || fmt.Sprint(got.Gates) != c.gates || got.TwoQubitGates != c.two || got.TCount != c.tcount || got.Clifford != c.clifford {
+			t.Errorf("got=%+v", got)
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package mps
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+var (
+	ErrInvalidBondDimension = errors.New("invalid bond dimension")
+	ErrInvalidThreshold     = errors.New("invalid truncation threshold")
+	ErrTooManyQubits        = errors.New("too many qubits")
+)
+
+// tensor is the rank-3 tensor of a site. The element (a, s, b) is data[(a*2+s)*r+b].
+type tensor struct {
+	l, r int
+	data []complex128
+}
+
+func (t tensor) at(a, s, b int) complex128 {
+	return t.data[(a*2+s)*t.r+b]
+}
+
+// State is the matrix product state of n qubits. Site i is qubit i.
+type State struct {
+	N      int
+	Clbits []int
+
+	// MaxBond is the maximum bond dimension.
+	MaxBond int
+
+	// Threshold is the maximum discarded weight of each truncation.
+	Threshold float64
+
+	// TruncationError is 1 - prod(1 - discarded weight) over all truncations.
+	TruncationError float64
+
+	sites []tensor
+}
+
+// New returns |0...0>.
+func New(n, maxBond int, threshold float64) *State {
+	sites := make([]tensor, n)
+	for i := range sites {
+		sites[i] = tensor{l: 1, r: 1, data: []complex128{1, 0}}
+	}
+
+	return &State{
+		N:         n,
+		MaxBond:   maxBond,
+		Threshold: threshold,
+		sites:     sites,
+	}
+}
+
+// Run runs the circuit.
+// maxQubits limits the number of qubits, or 0 for no limit.
+func Run(c *circuit.Circuit, maxBond int, threshold float64, rand func() float64, maxQubits int) (*State, error) {
+	if maxBond < 1 {
+		return nil, fmt.Errorf("max_bond_dimension=%d: %w", maxBond, ErrInvalidBondDimension)
+	}
+
+	if threshold < 0 || threshold >= 1 || math.IsNaN(threshold) {
+		return nil, fmt.Errorf("truncation_threshold=%v: %w", threshold, ErrInvalidThreshold)
+	}
+
+	if maxQubits > 0 && c.Qubits > maxQubits {
+		return nil, fmt.Errorf("need=%d, max=%d: %w", c.Qubits, maxQubits, ErrTooManyQubits)
+	}
+
+	s := New(c.Qubits, maxBond, threshold)
+	s.Clbits = make([]int, c.Clbits)
+	for _, op := range c.Ops {
+		s.Step(op, rand)
+	}
+
+	return s, nil
+}
+
+// Step applies the operation and returns the measured bit, or 0 if the operation is not a measurement.
+func (s *State) Step(op circuit.Op, rand func() float64) int {
+	switch op.Kind {
+	case circuit.Gate:
+		s.Apply(op.Matrix(), op.Target, op.Controls, op.NegControls)
+	case circuit.Measure:
+		m := s.Measure(op.Target, rand())
+		if op.Clbit >= 0 && op.Clbit < len(s.Clbits) {
+			s.Clbits[op.Clbit] = m
+		}
+
+		return m
+	case circuit.Reset:
+		if s.Measure(op.Target, rand()) == 1 {
+			s.Apply(circuit.U(math.Pi, 0, math.Pi, 0), op.Target, nil, nil)
+		}
+	}
+
+	return 0
+}
+
+// Grow adds n qubits in |0> after the last qubit.
+func (s *State) Grow(n int) {
+	for range n {
+		s.sites = append(s.sites, tensor{l: 1, r: 1, data: []complex128{1, 0}})
+	}
+
+	s.N += n
+}
+
+// Machine runs the operations on the state as they are emitted by the compiler.
+// Rand is used for measurement and reset.
+type Machine struct {
+	*State
+	Rand func() float64
+}
+
+// Grow adds n qubits in |0>.
+func (m *Machine) Grow(n int) error {
+	m.State.Grow(n)
+	return nil
+}
+
+// Run runs the operation and returns the measured bit.
+func (m *Machine) Run(op circuit.Op) (int, error) {
+	return m.Step(op, m.Rand), nil
+}
+
+// BondDimension returns the largest bond dimension.
+func (s *State) BondDimension() int {
+	var bond int
+	for _, t := range s.sites {
+		bond = max(bond, t.r)
+	}
+
+	return bond
+}
+
+// Apply applies the controlled 2x2 matrix m.
+// The qubits are moved next to each other by swaps, and moved back after the gate.
+func (s *State) Apply(m [2][2]complex128, target int, controls, negControls []int) {
+	qubits := slices.Concat(controls, negControls, []int{target})
+	if len(qubits) == 1 {
+		s.single(m, target)
+		return
+	}
+
+	sorted := slices.Sorted(slices.Values(qubits))
+	first := sorted[0]
+
+	// move the qubits to first, first+1, ...
+	var swaps []int
+	for j := 1; j < len(sorted); j++ {
+		for k := sorted[j]; k > first+j; k-- {
+			s.swap(k - 1)
+			swaps = append(swaps, k-1)
+		}
+	}
+
+	// local index of each qubit in the block
+	local := func(q int) int {
+		return slices.Index(sorted, q)
+	}
+
+	shift := func(qubits []int) []int {
+		out := make([]int, len(qubits))
+		for i, q := range qubits {
+			out[i] = local(q)
+		}
+
+		return out
+	}
+
+	s.block(first, len(sorted), func(v []complex128) {
+		circuit.Apply(v, len(sorted), m, local(target), shift(controls), shift(negControls))
+	})
+
+	// move back
+	for i := len(swaps) - 1; i >= 0; i-- {
+		s.swap(swaps[i])
+	}
+}
+
+func (s *State) single(m [2][2]complex128, q int) {
+	t := s.sites[q]
+	out := make([]complex128, len(t.data))
+	for a := range t.l {
+		for b := range t.r {
+			x0, x1 := t.at(a, 0, b), t.at(a, 1, b)
+			out[(a*2+0)*t.r+b] = m[0][0]*x0 + m[0][1]*x1
+			out[(a*2+1)*t.r+b] = m[1][0]*x0 + m[1][1]*x1
+		}
+	}
+
+	s.sites[q] = tensor{l: t.l, r: t.r, data: out}
+}
+
+func (s *State) swap(i int) {
+	s.block(i, 2, func(v []complex128) {
+		v[1], v[2] = v[2], v[1]
+	})
+}
+
+// block contracts the sites [first, first+n), applies f to the physical vector of each pair of bond indices,
+// and splits the block back into sites with truncated SVDs.
+func (s *State) block(first, n int, f func(v []complex128)) {
+	// contract. theta[(a*dim+k)*r+b]
+	t := s.sites[first]
+	l, r, dim := t.l, t.r, 2
+	theta := slices.Clone(t.data)
+	for i := 1; i < n; i++ {
+		next := s.sites[first+i]
+		out := make([]complex128, l*dim*2*next.r)
+		for a := range l {
+			for k := range dim {
+				for c := range r {
+					x := theta[(a*dim+k)*r+c]
+					if x == 0 {
+						continue
+					}
+
+					for sb := range 2 {
+						for b := range next.r {
+							out[((a*dim+k)*2+sb)*next.r+b] += x * next.at(c, sb, b)
+						}
+					}
+				}
+			}
+		}
+
+		theta, dim, r = out, dim*2, next.r
+	}
+
+	// apply
+	v := make([]complex128, dim)
+	for a := range l {
+		for b := range r {
+			for k := range dim {
+				v[k] = theta[(a*dim+k)*r+b]
+			}
+
+			f(v)
+			for k := range dim {
+				theta[(a*dim+k)*r+b] = v[k]
+			}
+		}
+	}
+
+	// split
+	for i := 0; i < n-1; i++ {
+		rest := dim / 2
+		rows, cols := l*2, rest*r
+		u, sv, vh := SVD(theta, rows, cols)
+		k := min(rows, cols)
+
+		keep := s.truncate(sv)
+		site := make([]complex128, rows*keep)
+		for row := range rows {
+			for j := range keep {
+				site[row*keep+j] = u[row*k+j]
+			}
+		}
+		s.sites[first+i] = tensor{l: l, r: keep, data: site}
+
+		// theta = diag(s) * v^dagger
+		next := make([]complex128, keep*cols)
+		for j := range keep {
+			for c := range cols {
+				next[j*cols+c] = complex(sv[j], 0) * cmplx.Conj(vh[c*k+j])
+			}
+		}
+
+		theta, l, dim = next, keep, rest
+	}
+
+	s.sites[first+n-1] = tensor{l: l, r: r, data: theta}
+}
+
+// truncate returns the number of singular values to keep, and renormalizes them.
+func (s *State) truncate(sv []float64) int {
+	var total float64
+	for _, x := range sv {
+		total += x * x
+	}
+
+	if total == 0 {
+		return 1
+	}
+
+	keep := len(sv)
+	for keep > 1 && sv[keep-1]*sv[keep-1] <= 1e-28*total {
+		// numerical zero
+		keep--
+	}
+
+	var discarded float64
+	for keep > 1 {
+		w := sv[keep-1] * sv[keep-1] / total
+		if keep <= s.MaxBond && discarded+w > s.Threshold {
+			break
+		}
+
+		discarded += w
+		keep--
+	}
+
+	if discarded > 0 {
+		s.TruncationError = 1 - (1-s.TruncationError)*(1-discarded)
+	}
+
+	// renormalize
+	var kept float64
+	for _, x := range sv[:keep] {
+		kept += x * x
+	}
+
+	norm := math.Sqrt(kept / total)
+	for j := range keep {
+		sv[j] /= norm
+	}
+
+	return keep
+}
+
+// expect returns <psi|O|psi>, where ops[i] is the operator on site i. The identity is used for the other sites.
+func (s *State) expect(ops map[int][2][2]complex128) complex128 {
+	// e[a*l+a'] for the bond between sites
+	e := []complex128{1}
+	for i := s.N - 1; i >= 0; i-- {
+		t := s.sites[i]
+		op, ok := ops[i]
+		if !ok {
+			op = [2][2]complex128{{1, 0}, {0, 1}}
+		}
+
+		next := make([]complex128, t.l*t.l)
+		for a := range t.l {
+			for ap := range t.l {
+				var sum complex128
+				for s1 := range 2 {
+					for s2 := range 2 {
+						if op[s2][s1] == 0 {
+							continue
+						}
+
+						// conj(A[ap, s2, bp]) * op[s2][s1] * A[a, s1, b] * e[b, bp]
+						for b := range t.r {
+							x := t.at(a, s1, b)
+							if x == 0 {
+								continue
+							}
+
+							for bp := range t.r {
+								sum += cmplx.Conj(t.at(ap, s2, bp)) * op[s2][s1] * x * e[b*t.r+bp]
+							}
+						}
+					}
+				}
+
+				next[a*t.l+ap] = sum
+			}
+		}
+
+		e = next
+	}
+
+	return e[0]
+}
+
+// Measure measures the qubit in the computational basis and collapses the state.
+func (s *State) Measure(q int, r float64) int {
+	p1 := real(s.expect(map[int][2][2]complex128{q: {{0, 0}, {0, 1}}}))
+
+	m, p := 0, 1-p1
+	proj := [2][2]complex128{{1, 0}, {0, 0}}
+	if r < p1 {
+		m, p, proj = 1, p1, [2][2]complex128{{0, 0}, {0, 1}}
+	}
+
+	s.single(proj, q)
+	t := s.sites[q]
+	for i := range t.data {
+		t.data[i] /= complex(math.Sqrt(p), 0)
+	}
+
+	return m
+}
+
+// Expectation returns the expectation value of the Pauli string. e.g. XZ
+// index[i] is the qubit that the i-th Pauli operator acts on.
+func (s *State) Expectation(pauli string, index []int) float64 {
+	ops := make(map[int][2][2]complex128)
+	for i, p := range pauli {
+		switch p {
+		case 'X':
+			ops[index[i]] = [2][2]complex128{{0, 1}, {1, 0}}
+		case 'Y':
+			ops[index[i]] = [2][2]complex128{{0, -1i}, {1i, 0}}
+		case 'Z':
+			ops[index[i]] = [2][2]complex128{{1, 0}, {0, -1}}
+		}
+	}
+
+	return real(s.expect(ops))
+}
+
+// Sample returns the bits of a computational basis state sampled from the state.
+func (s *State) Sample(rand func() float64) []int {
+	// right environments. env[i] is the contraction of sites i to n-1.
+	env := make([][]complex128, s.N+1)
+	env[s.N] = []complex128{1}
+	for i := s.N - 1; i >= 0; i-- {
+		t := s.sites[i]
+		next := make([]complex128, t.l*t.l)
+		for a := range t.l {
+			for ap := range t.l {
+				var sum complex128
+				for sb := range 2 {
+					for b := range t.r {
+						for bp := range t.r {
+							sum += t.at(a, sb, b) * cmplx.Conj(t.at(ap, sb, bp)) * env[i+1][b*t.r+bp]
+						}
+					}
+				}
+
+				next[a*t.l+ap] = sum
+			}
+		}
+
+		env[i] = next
+	}
+
+	bits := make([]int, s.N)
+	left := []complex128{1}
+	for i := range s.N {
+		t := s.sites[i]
+
+		// conditional amplitudes of each outcome
+		var probs [2]float64
+		var vecs [2][]complex128
+		for sb := range 2 {
+			vec := make([]complex128, t.r)
+			for a := range t.l {
+				if left[a] == 0 {
+					continue
+				}
+
+				for b := range t.r {
+					vec[b] += left[a] * t.at(a, sb, b)
+				}
+			}
+
+			var p complex128
+			for b := range t.r {
+				for bp := range t.r {
+					p += vec[b] * cmplx.Conj(vec[bp]) * env[i+1][b*t.r+bp]
+				}
+			}
+
+			probs[sb], vecs[sb] = real(p), vec
+		}
+
+		sb := 0
+		if rand()*(probs[0]+probs[1]) >= probs[0] {
+			sb = 1
+		}
+
+		// normalize to avoid underflow
+		left = vecs[sb]
+		for b := range left {
+			left[b] /= complex(math.Sqrt(probs[sb]), 0)
+		}
+
+		bits[i] = sb
+	}
+
+	return bits
+}
+
+// Amplitude returns the amplitude of the computational basis state.
+func (s *State) Amplitude(bits []int) complex128 {
+	v := []complex128{1}
+	for i, t := range s.sites {
+		next := make([]complex128, t.r)
+		for a := range t.l {
+			for b := range t.r {
+				next[b] += v[a] * t.at(a, bits[i], b)
+			}
+		}
+
+		v = next
+	}
+
+	return v[0]
+}
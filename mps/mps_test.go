@@ -0,0 +1,259 @@
+package mps_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/mps"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ghz(n int) *circuit.Circuit {
+	c := &circuit.Circuit{Qubits: n}
+	c.Ops = append(c.Ops, gate(math.Pi/2, 0, math.Pi, 0))
+	for i := range n - 1 {
+		c.Ops = append(c.Ops, gate(math.Pi, 0, math.Pi, i+1, i))
+	}
+
+	return c
+}
+
+func ExampleRun() {
+	s, err := mps.Run(ghz(3), 64, 0, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.4f\n", real(s.Amplitude([]int{0, 0, 0})))
+	fmt.Printf("%.4f\n", real(s.Amplitude([]int{1, 1, 1})))
+	fmt.Println(s.BondDimension())
+	fmt.Println(s.TruncationError)
+
+	// Output:
+	// 0.7071
+	// 0.7071
+	// 2
+	// 0
+}
+
+func ExampleRun_truncation() {
+	// bond dimension 1 can not represent the bell state
+	s, err := mps.Run(ghz(2), 1, 0, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(s.BondDimension())
+	fmt.Printf("%.4f\n", s.TruncationError)
+
+	// Output:
+	// 1
+	// 0.5000
+}
+
+func ExampleMachine() {
+	m := &mps.Machine{
+		State: mps.New(0, 64, 0),
+	}
+
+	// the qubits are added as they are declared
+	for _, op := range ghz(3).Ops {
+		if err := m.Grow(1); err != nil {
+			panic(err)
+		}
+
+		if _, err := m.Run(op); err != nil {
+			panic(err)
+		}
+	}
+
+	fmt.Println(m.N, m.BondDimension())
+	fmt.Printf("%.4f\n", real(m.Amplitude([]int{1, 1, 1})))
+
+	// Output:
+	// 3 2
+	// 0.7071
+}
+
+func TestRun(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 10 {
+		n := 5
+		c := &circuit.Circuit{Qubits: n}
+		for range 30 {
+			op := gate(r.Float64()*math.Pi, r.Float64()*math.Pi, r.Float64()*math.Pi, r.IntN(n))
+			op.Phase = r.Float64()
+			switch r.IntN(3) {
+			case 1:
+				op.Controls = []int{(op.Target + 1 + r.IntN(n-1)) % n}
+			case 2:
+				// toffoli-like with a negative control
+				a := (op.Target + 1) % n
+				b := (op.Target + 3) % n
+				op.Controls, op.NegControls = []int{a}, []int{b}
+			}
+
+			c.Ops = append(c.Ops, op)
+		}
+
+		// state vector
+		want := make([]complex128, 1<<n)
+		want[0] = 1
+		for _, op := range c.Ops {
+			circuit.Apply(want, n, op.Matrix(), op.Target, op.Controls, op.NegControls)
+		}
+
+		s, err := mps.Run(c, 64, 0, nil, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		for k := range want {
+			bits := make([]int, n)
+			for i := range n {
+				bits[i] = (k >> (n - 1 - i)) & 1
+			}
+
+			if got := s.Amplitude(bits); cmplx.Abs(got-want[k]) > 1e-9 {
+				t.Fatalf("%v: got=%v, want=%v", bits, got, want[k])
+			}
+		}
+	}
+}
+
+func TestRun_large(t *testing.T) {
+	s, err := mps.Run(ghz(60), 64, 0, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if s.BondDimension() != 2 {
+		t.Errorf("got=%v", s.BondDimension())
+	}
+
+	for _, r := range []float64{0.1, 0.9} {
+		bits := s.Sample(func() float64 { return r })
+		for i := range bits {
+			if bits[i] != bits[0] {
+				t.Fatalf("got=%v", bits)
+			}
+		}
+	}
+}
+
+func TestRun_measure(t *testing.T) {
+	c := ghz(3)
+	c.Clbits = 3
+	c.Ops = append(c.Ops,
+		circuit.Op{Kind: circuit.Measure, Target: 2, Clbit: 0},
+		circuit.Op{Kind: circuit.Measure, Target: 0, Clbit: 1},
+		circuit.Op{Kind: circuit.Reset, Target: 1, Clbit: -1},
+	)
+
+	for _, r := range []float64{0.1, 0.9} {
+		s, err := mps.Run(c, 64, 0, func() float64 { return r }, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if s.Clbits[0] != s.Clbits[1] {
+			t.Errorf("got=%v", s.Clbits)
+		}
+
+		if got := s.Sample(func() float64 { return 0.5 }); got[1] != 0 || got[0] != s.Clbits[0] {
+			t.Errorf("got=%v", got)
+		}
+	}
+}
+
+func TestState_Expectation(t *testing.T) {
+	s, err := mps.Run(ghz(2), 64, 0, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	cases := []struct {
+		pauli string
+		want  float64
+	}{
+		{"ZZ", 1},
+		{"XX", 1},
+		{"YY", -1},
+		{"ZI", 0},
+		{"II", 1},
+	}
+
+	for _, c := range cases {
+		if got := s.Expectation(c.pauli, []int{0, 1}); math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("%s: got=%v, want=%v", c.pauli, got, c.want)
+		}
+	}
+}
+
+func TestRun_invalid(t *testing.T) {
+	cases := []struct {
+		maxBond   int
+		threshold float64
+		maxQubits int
+		err       error
+	}{
+		{0, 0, 0, mps.ErrInvalidBondDimension},
+		{2, -0.1, 0, mps.ErrInvalidThreshold},
+		{2, 1, 0, mps.ErrInvalidThreshold},
+		{2, 0, 2, mps.ErrTooManyQubits},
+	}
+
+	for _, c := range cases {
+		if _, err := mps.Run(ghz(3), c.maxBond, c.threshold, nil, c.maxQubits); !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+	}
+}
+
+func TestSVD(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for _, size := range [][2]int{{1, 1}, {2, 3}, {4, 2}, {5, 5}, {8, 3}} {
+		rows, cols := size[0], size[1]
+		m := make([]complex128, rows*cols)
+		for i := range m {
+			m[i] = complex(r.NormFloat64(), r.NormFloat64())
+		}
+
+		u, s, v := mps.SVD(m, rows, cols)
+		k := min(rows, cols)
+		for i := 1; i < k; i++ {
+			if s[i] > s[i-1] {
+				t.Errorf("not sorted: %v", s)
+			}
+		}
+
+		for i := range rows {
+			for j := range cols {
+				var got complex128
+				for l := range k {
+					got += u[i*k+l] * complex(s[l], 0) * cmplx.Conj(v[j*k+l])
+				}
+
+				if cmplx.Abs(got-m[i*cols+j]) > 1e-9 {
+					t.Fatalf("%dx%d: got=%v, want=%v", rows, cols, got, m[i*cols+j])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package mps
+
+import (
+	"math"
+	"math/cmplx"
+	"slices"
+)
+
+// SVD returns the singular value decomposition m = u * diag(s) * v^dagger of the rows x cols matrix.
+// m is row-major. u is rows x k, v is cols x k, where k = min(rows, cols).
+// The singular values are in descending order.
+func SVD(m []complex128, rows, cols int) (u []complex128, s []float64, v []complex128) {
+	if rows < cols {
+		// m^dagger = v * diag(s) * u^dagger
+		v, s, u = SVD(adjoint(m, rows, cols), cols, rows)
+		return u, s, v
+	}
+
+	// one-sided jacobi on the columns of a
+	a := slices.Clone(m)
+	vv := make([]complex128, cols*cols)
+	for i := range cols {
+		vv[i*cols+i] = 1
+	}
+
+	for sweep := 0; sweep < 64; sweep++ {
+		rotated := false
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				var alpha, beta float64
+				var gamma complex128
+				for i := range rows {
+					ap, aq := a[i*cols+p], a[i*cols+q]
+					alpha += real(ap)*real(ap) + imag(ap)*imag(ap)
+					beta += real(aq)*real(aq) + imag(aq)*imag(aq)
+					gamma += cmplx.Conj(ap) * aq
+				}
+
+				g := cmplx.Abs(gamma)
+				if g <= 1e-15*math.Sqrt(alpha*beta) || g == 0 {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * g)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+
+				c := 1 / math.Sqrt(1+t*t)
+				sn := c * t
+				e := cmplx.Conj(gamma / complex(g, 0))
+
+				rotate := func(x []complex128, n, stride int) {
+					for i := range n {
+						xp, xq := x[i*stride+p], x[i*stride+q]
+						x[i*stride+p] = complex(c, 0)*xp - complex(sn, 0)*e*xq
+						x[i*stride+q] = complex(sn, 0)*xp + complex(c, 0)*e*xq
+					}
+				}
+
+				rotate(a, rows, cols)
+				rotate(vv, cols, cols)
+			}
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	// singular values are the column norms
+	norms := make([]float64, cols)
+	for j := range cols {
+		var sum float64
+		for i := range rows {
+			x := a[i*cols+j]
+			sum += real(x)*real(x) + imag(x)*imag(x)
+		}
+
+		norms[j] = math.Sqrt(sum)
+	}
+
+	order := make([]int, cols)
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		switch {
+		case norms[i] > norms[j]:
+			return -1
+		case norms[i] < norms[j]:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	u = make([]complex128, rows*cols)
+	s = make([]float64, cols)
+	v = make([]complex128, cols*cols)
+	for k, j := range order {
+		s[k] = norms[j]
+		for i := range rows {
+			if norms[j] > 0 {
+				u[i*cols+k] = a[i*cols+j] / complex(norms[j], 0)
+			}
+		}
+
+		for i := range cols {
+			v[i*cols+k] = vv[i*cols+j]
+		}
+	}
+
+	return u, s, v
+}
+
+func adjoint(m []complex128, rows, cols int) []complex128 {
+	out := make([]complex128, len(m))
+	for i := range rows {
+		for j := range cols {
+			out[j*rows+i] = cmplx.Conj(m[i*cols+j])
+		}
+	}
+
+	return out
+}
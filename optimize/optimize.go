@@ -0,0 +1,246 @@
+package optimize
+
+import (
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+const eps = 1e-9
+
+// Optimize returns a circuit with the same unitary as c up to global phase.
+// Adjacent gates on the same target and controls are merged, gates that commute are moved to find them,
+// and gates equal to the identity, e.g. zero-angle rotations and self-inverse pairs, are removed.
+// The global phases are merged into one at the end of the circuit.
+// A gate in a branch on measured bits is merged only with the gates in the same branch.
+func Optimize(c *circuit.Circuit) *circuit.Circuit {
+	var phase float64
+	ops := slices.Clone(c.Ops)
+	for {
+		next, p := pass(ops)
+		phase += p
+
+		if len(next) == len(ops) {
+			ops = next
+			break
+		}
+
+		ops = next
+	}
+
+	if p := math.Remainder(phase, 2*math.Pi); math.Abs(p) > eps {
+		ops = append(ops, circuit.Op{Kind: circuit.GlobalPhase, Phase: p, Clbit: -1})
+	}
+
+	return &circuit.Circuit{
+		Qubits: c.Qubits,
+		Clbits: c.Clbits,
+		QRegs:  c.QRegs,
+		CRegs:  c.CRegs,
+		Ops:    ops,
+	}
+}
+
+// pass merges each gate into the last gate it does not commute with, and returns the global phase removed.
+func pass(ops []circuit.Op) ([]circuit.Op, float64) {
+	var phase float64
+	out := make([]circuit.Op, 0, len(ops))
+	for _, op := range ops {
+		switch op.Kind {
+		case circuit.GlobalPhase:
+			phase += op.Phase
+			continue
+		case circuit.Gate:
+		default:
+			out = append(out, op)
+			continue
+		}
+
+		g, p := normalize(op)
+		phase += p
+		if Identity(g) {
+			continue
+		}
+
+		merged := false
+		for j := len(out) - 1; j >= 0; j-- {
+			if Mergeable(out[j], g) {
+				m, p := normalize(Merge(out[j], g))
+				phase += p
+
+				if Identity(m) {
+					out = slices.Delete(out, j, j+1)
+				} else {
+					out[j] = m
+				}
+
+				merged = true
+				break
+			}
+
+			if !Commute(out[j], g) {
+				break
+			}
+		}
+
+		if !merged {
+			out = append(out, g)
+		}
+	}
+
+	return out, phase
+}
+
+// normalize moves the phase of an uncontrolled gate to the global phase, and returns the phase.
+// A controlled phase gate is symmetric in its qubits, so the last qubit becomes the target.
+func normalize(op circuit.Op) (circuit.Op, float64) {
+	if len(op.Controls)+len(op.NegControls) > 0 {
+		m := op.Matrix()
+		if len(op.NegControls) == 0 && cmplx.Abs(m[0][1]) < eps && cmplx.Abs(m[1][0]) < eps && cmplx.Abs(m[0][0]-1) < eps {
+			qubits := append(slices.Clone(op.Controls), op.Target)
+			slices.Sort(qubits)
+			op.Target, op.Controls = qubits[len(qubits)-1], qubits[:len(qubits)-1]
+		}
+
+		return op, 0
+	}
+
+	p := op.Phase
+	op.Phase = 0
+	return op, p
+}
+
+// Identity returns true if the gate is the identity, or a global phase if it has no controls.
+func Identity(op circuit.Op) bool {
+	m := op.Matrix()
+	if cmplx.Abs(m[0][1]) > eps || cmplx.Abs(m[1][0]) > eps {
+		return false
+	}
+
+	if len(op.Controls)+len(op.NegControls) == 0 {
+		return cmplx.Abs(m[0][0]-m[1][1]) < eps
+	}
+
+	return cmplx.Abs(m[0][0]-1) < eps && cmplx.Abs(m[1][1]-1) < eps
+}
+
+// Mergeable returns true if a and b are gates on the same target with the same controls, in the same branch.
+func Mergeable(a, b circuit.Op) bool {
+	return a.Kind == circuit.Gate && b.Kind == circuit.Gate &&
+		a.Target == b.Target &&
+		a.Condition == b.Condition &&
+		sameSet(a.Controls, b.Controls) &&
+		sameSet(a.NegControls, b.NegControls)
+}
+
+// Merge returns the gate b*a, which applies a and then b.
+func Merge(a, b circuit.Op) circuit.Op {
+	ma, mb := a.Matrix(), b.Matrix()
+
+	var m [2][2]complex128
+	for i := range 2 {
+		for j := range 2 {
+			for k := range 2 {
+				m[i][j] += mb[i][k] * ma[k][j]
+			}
+		}
+	}
+
+	theta, phi, lambda, phase := circuit.ZYZ(m)
+
+	op := a
+	op.Theta, op.Phi, op.Lambda, op.Phase = snap(theta), snap(phi), snap(lambda), snap(phase)
+	return op
+}
+
+// snap rounds the angle to a multiple of pi/16 if it is within the rounding error, e.g. pi/4 + 1e-16.
+func snap(v float64) float64 {
+	n := v * 16 / math.Pi
+	if r := math.Round(n); math.Abs(n-r) < 1e-12 {
+		return r * math.Pi / 16
+	}
+
+	return v
+}
+
+// Commute returns true if a and b commute.
+// On each qubit they share, both must be diagonal, e.g. a control or a rotation about Z,
+// or both must commute with X, e.g. the target of a controlled X.
+// Measurements, resets and barriers do not commute with the operations on their qubits.
+func Commute(a, b circuit.Op) bool {
+	ka, kb := kinds(a), kinds(b)
+	if ka == nil || kb == nil {
+		// a barrier on all qubits
+		return false
+	}
+
+	for q, x := range ka {
+		y, ok := kb[q]
+		if !ok {
+			continue
+		}
+
+		if x == general || x != y {
+			return false
+		}
+	}
+
+	return true
+}
+
+type kind int
+
+const (
+	general kind = iota
+	diagonal
+	xlike
+)
+
+// kinds returns how the operation acts on each of its qubits.
+func kinds(op circuit.Op) map[int]kind {
+	out := make(map[int]kind)
+	switch op.Kind {
+	case circuit.Gate:
+		for _, q := range slices.Concat(op.Controls, op.NegControls) {
+			out[q] = diagonal
+		}
+
+		m := op.Matrix()
+		switch {
+		case cmplx.Abs(m[0][1]) < eps && cmplx.Abs(m[1][0]) < eps:
+			out[op.Target] = diagonal
+		case cmplx.Abs(m[0][0]-m[1][1]) < eps && cmplx.Abs(m[0][1]-m[1][0]) < eps:
+			out[op.Target] = xlike
+		default:
+			out[op.Target] = general
+		}
+	case circuit.Barrier:
+		if len(op.Qubits) == 0 {
+			return nil
+		}
+
+		for _, q := range op.Qubits {
+			out[q] = general
+		}
+	case circuit.Measure, circuit.Reset:
+		out[op.Target] = general
+	}
+
+	return out
+}
+
+func sameSet(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+
+	return true
+}
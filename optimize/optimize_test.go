@@ -0,0 +1,153 @@
+package optimize_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/optimize"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func h(q int) circuit.Op             { return gate(math.Pi/2, 0, math.Pi, q) }
+func x(q int) circuit.Op             { return gate(math.Pi, 0, math.Pi, q) }
+func rz(a float64, q int) circuit.Op { return gate(0, 0, a, q) }
+func cx(c, t int) circuit.Op         { return gate(math.Pi, 0, math.Pi, t, c) }
+
+func when(cond *circuit.Condition, op circuit.Op) circuit.Op {
+	op.Condition = cond
+	return op
+}
+
+func gates(c *circuit.Circuit) int {
+	var n int
+	for _, op := range c.Ops {
+		if op.Kind == circuit.Gate {
+			n++
+		}
+	}
+
+	return n
+}
+
+func ExampleOptimize() {
+	c := &circuit.Circuit{
+		Qubits: 2,
+		QRegs:  []circuit.Register{{Name: "q", Index: []int{0, 1}}},
+		Ops: []circuit.Op{
+			h(0),
+			h(0),
+			rz(math.Pi/4, 0),
+			cx(0, 1),
+			rz(math.Pi/4, 0),
+			x(1),
+			cx(0, 1),
+			rz(0, 1),
+		},
+	}
+
+	fmt.Print(circuit.QASM(optimize.Optimize(c)))
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// qubit[2] q;
+	//
+	// U(0, 0, pi/2) q[0];
+	// U(pi, 0, pi) q[1];
+}
+
+func TestOptimize(t *testing.T) {
+	cond := &circuit.Condition{Expr: "c"}
+	other := &circuit.Condition{Expr: "c", Else: true}
+	cases := []struct {
+		ops  []circuit.Op
+		want int
+	}{
+		{[]circuit.Op{h(0), h(0)}, 0},
+		{[]circuit.Op{cx(0, 1), cx(0, 1)}, 0},
+		{[]circuit.Op{rz(0.1, 0), rz(0.2, 0), rz(-0.3, 0)}, 0},
+		{[]circuit.Op{rz(0.1, 0), h(0), rz(0.2, 0)}, 1},
+		{[]circuit.Op{x(1), cx(0, 1), x(1)}, 1},
+		{[]circuit.Op{rz(0.1, 1), cx(0, 1), rz(0.2, 1)}, 3},
+		{[]circuit.Op{cx(0, 1), cx(1, 2), cx(0, 1)}, 3},
+		{[]circuit.Op{cx(0, 2), cx(1, 2), cx(0, 2)}, 1},
+		{[]circuit.Op{gate(0, 0, 0.5, 1, 0), gate(0, 0, -0.5, 0, 1)}, 0},
+		{[]circuit.Op{{Kind: circuit.GlobalPhase, Phase: math.Pi, Clbit: -1}, {Kind: circuit.GlobalPhase, Phase: math.Pi, Clbit: -1}}, 0},
+		{[]circuit.Op{h(0), {Kind: circuit.Barrier, Qubits: []int{0}, Clbit: -1}, h(0)}, 3},
+		{[]circuit.Op{h(0), {Kind: circuit.Measure, Target: 0, Clbit: -1}, h(0)}, 3},
+		{[]circuit.Op{h(0), {Kind: circuit.Measure, Target: 1, Clbit: -1}, h(0)}, 1},
+		{[]circuit.Op{when(cond, x(0)), when(cond, x(0))}, 0},
+		{[]circuit.Op{when(cond, x(0)), when(other, x(0))}, 2},
+		{[]circuit.Op{x(0), when(cond, x(0)), x(0)}, 1},
+		{[]circuit.Op{x(0), when(cond, h(0)), x(0)}, 3},
+		{[]circuit.Op{x(0), when(cond, x(1)), x(0)}, 1},
+	}
+
+	for _, c := range cases {
+		got := optimize.Optimize(&circuit.Circuit{Qubits: 3, Ops: c.ops})
+		if len(got.Ops) != c.want {
+			t.Errorf("got=%v, want=%d", got.Ops, c.want)
+		}
+	}
+}
+
+func TestOptimize_equivalent(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	angles := []float64{0, math.Pi / 4, math.Pi / 2, math.Pi, -math.Pi / 2, 0.3}
+	angle := func() float64 { return angles[rng.IntN(len(angles))] }
+
+	for range 200 {
+		n := 3
+		c := &circuit.Circuit{Qubits: n}
+		for range 1 + rng.IntN(30) {
+			t, k := rng.IntN(n), rng.IntN(4)
+			op := gate(angle(), angle(), angle(), t)
+			op.Phase = angle()
+			for q := range n {
+				if q == t || rng.IntN(3) != 0 {
+					continue
+				}
+
+				if k%2 == 0 {
+					op.Controls = append(op.Controls, q)
+				} else {
+					op.NegControls = append(op.NegControls, q)
+				}
+			}
+
+			if k == 3 {
+				op = circuit.Op{Kind: circuit.GlobalPhase, Phase: angle(), Clbit: -1}
+			}
+
+			c.Ops = append(c.Ops, op, op.Inverse())[:len(c.Ops)+1+rng.IntN(2)]
+		}
+
+		got := optimize.Optimize(c)
+		if gates(got) > gates(c) {
+			t.Errorf("got=%d, want<=%d", gates(got), gates(c))
+		}
+
+		fidelity, _, err := circuit.Equivalent(c, got)
+		if err != nil {
+			t.Fatalf("equivalent: %v", err)
+		}
+
+		if math.Abs(fidelity-1) > 1e-9 {
+			t.Fatalf("fidelity=%v, ops=%v, got=%v", fidelity, c.Ops, got.Ops)
+		}
+	}
+}
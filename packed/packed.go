@@ -0,0 +1,37 @@
+package packed
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrInvalidLength = errors.New("invalid length")
+
+// Encode encodes the amplitudes as little-endian float64 pairs of (real, imag).
+func Encode(amp []complex128) []byte {
+	b := make([]byte, 0, len(amp)*16)
+	for _, a := range amp {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(real(a)))
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(imag(a)))
+	}
+
+	return b
+}
+
+// Decode decodes the little-endian float64 pairs of (real, imag) into amplitudes.
+func Decode(b []byte) ([]complex128, error) {
+	if len(b)%16 != 0 {
+		return nil, fmt.Errorf("length=%d: %w", len(b), ErrInvalidLength)
+	}
+
+	amp := make([]complex128, len(b)/16)
+	for i := range amp {
+		re := math.Float64frombits(binary.LittleEndian.Uint64(b[i*16:]))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(b[i*16+8:]))
+		amp[i] = complex(re, im)
+	}
+
+	return amp, nil
+}
@@ -0,0 +1,35 @@
+package packed_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/packed"
+)
+
+func ExampleEncode() {
+	b := packed.Encode([]complex128{
+		complex(1/math.Sqrt2, 0),
+		complex(0, -1/math.Sqrt2),
+	})
+
+	amp, err := packed.Decode(b)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(b))
+	fmt.Printf("%.4f\n", amp)
+
+	// Output:
+	// 32
+	// [(0.7071+0.0000i) (0.0000-0.7071i)]
+}
+
+func TestDecode(t *testing.T) {
+	if _, err := packed.Decode(make([]byte, 15)); !errors.Is(err, packed.ErrInvalidLength) {
+		t.Errorf("got=%v, want=%v", err, packed.ErrInvalidLength)
+	}
+}
@@ -0,0 +1,202 @@
+package pauli
+
+import (
+	"errors"
+	"fmt"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidTerm   = errors.New("invalid term")
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// Term is a Pauli string with a coefficient. e.g. 0.5*ZZ
+type Term struct {
+	Coef  float64
+	Pauli string
+}
+
+// Hamiltonian is a sum of Pauli terms. e.g. 0.5*ZZ + 0.3*XI
+type Hamiltonian []Term
+
+// Parse parses a sum of Pauli terms such as "0.5*ZZ + 0.3*XI - YY".
+func Parse(s string) (Hamiltonian, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if len(s) == 0 {
+		return nil, fmt.Errorf("empty: %w", ErrInvalidTerm)
+	}
+
+	// split into signed terms
+	var terms []string
+	begin := 0
+	for i := 1; i < len(s); i++ {
+		if s[i] != '+' && s[i] != '-' {
+			continue
+		}
+
+		if s[i-1] == 'e' || s[i-1] == 'E' {
+			// exponent. e.g. 1e-3
+			continue
+		}
+
+		terms = append(terms, s[begin:i])
+		begin = i
+	}
+	terms = append(terms, s[begin:])
+
+	var n int
+	h := make(Hamiltonian, 0, len(terms))
+	for _, t := range terms {
+		term, err := parseTerm(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if n > 0 && len(term.Pauli) != n {
+			return nil, fmt.Errorf("%s: %w", t, ErrInvalidLength)
+		}
+
+		n = len(term.Pauli)
+		h = append(h, term)
+	}
+
+	return h, nil
+}
+
+func parseTerm(t string) (Term, error) {
+	sign := 1.0
+	body := t
+	switch {
+	case strings.HasPrefix(body, "+"):
+		body = body[1:]
+	case strings.HasPrefix(body, "-"):
+		sign, body = -1.0, body[1:]
+	}
+
+	coef, pauli := 1.0, body
+	if c, p, ok := strings.Cut(body, "*"); ok {
+		v, err := strconv.ParseFloat(c, 64)
+		if err != nil {
+			return Term{}, fmt.Errorf("%s: %w", t, ErrInvalidTerm)
+		}
+
+		coef, pauli = v, p
+	}
+
+	if len(pauli) == 0 {
+		return Term{}, fmt.Errorf("%s: %w", t, ErrInvalidTerm)
+	}
+
+	pauli = strings.ToUpper(pauli)
+	for _, r := range pauli {
+		if !strings.ContainsRune("IXYZ", r) {
+			return Term{}, fmt.Errorf("%s: %w", t, ErrInvalidTerm)
+		}
+	}
+
+	return Term{
+		Coef:  sign * coef,
+		Pauli: pauli,
+	}, nil
+}
+
+// Qubits returns the number of qubits the Hamiltonian acts on.
+func (h Hamiltonian) Qubits() int {
+	if len(h) == 0 {
+		return 0
+	}
+
+	return len(h[0].Pauli)
+}
+
+// Expectation returns <psi|H|psi>.
+// amp is the state vector of n qubits, where qubit 0 is the most significant bit.
+// index[i] is the qubit in amp that the i-th Pauli operator acts on.
+func (h Hamiltonian) Expectation(amp []complex128, index []int) (float64, error) {
+	if h.Qubits() != len(index) {
+		return 0, fmt.Errorf("qubits=%d, pauli=%d: %w", len(index), h.Qubits(), ErrInvalidLength)
+	}
+
+	var n int
+	for 1<<n < len(amp) {
+		n++
+	}
+
+	var sum float64
+	for _, t := range h {
+		sum += t.Coef * real(expectation(t.Pauli, amp, index, n))
+	}
+
+	return sum, nil
+}
+
+// DensityExpectation returns tr(rho*H).
+// rho is the row-major density matrix of n qubits, where qubit 0 is the most significant bit.
+func (h Hamiltonian) DensityExpectation(rho []complex128, index []int) (float64, error) {
+	if h.Qubits() != len(index) {
+		return 0, fmt.Errorf("qubits=%d, pauli=%d: %w", len(index), h.Qubits(), ErrInvalidLength)
+	}
+
+	var n int
+	for 1<<(2*n) < len(rho) {
+		n++
+	}
+
+	var sum float64
+	for _, t := range h {
+		var e complex128
+		for k := range 1 << n {
+			// <k|rho P|k> = phase * <k|rho|k ^ flip>
+			flip, phase := apply(t.Pauli, k, index, n)
+			e += phase * rho[k<<n|(k^flip)]
+		}
+
+		sum += t.Coef * real(e)
+	}
+
+	return sum, nil
+}
+
+func expectation(pauli string, amp []complex128, index []int, n int) complex128 {
+	var sum complex128
+	for k := range amp {
+		if amp[k] == 0 {
+			continue
+		}
+
+		flip, phase := apply(pauli, k, index, n)
+		sum += cmplx.Conj(amp[k^flip]) * phase * amp[k]
+	}
+
+	return sum
+}
+
+// apply returns flip and phase such that P|k> = phase|k ^ flip>.
+func apply(pauli string, k int, index []int, n int) (int, complex128) {
+	flip, phase := 0, complex(1, 0)
+	for i, p := range pauli {
+		mask := 1 << (n - 1 - index[i])
+		bit := k&mask != 0
+
+		switch p {
+		case 'X':
+			flip |= mask
+		case 'Y':
+			flip |= mask
+			if bit {
+				phase *= -1i
+			} else {
+				phase *= 1i
+			}
+		case 'Z':
+			if bit {
+				phase *= -1
+			}
+		}
+	}
+
+	return flip, phase
+}
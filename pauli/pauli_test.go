@@ -0,0 +1,163 @@
+package pauli_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/pauli"
+)
+
+func ExampleParse() {
+	h, err := pauli.Parse("0.5*ZZ + 0.3*XI - YY + 1e-3*IZ")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, t := range h {
+		fmt.Println(t.Coef, t.Pauli)
+	}
+
+	// Output:
+	// 0.5 ZZ
+	// 0.3 XI
+	// -1 YY
+	// 0.001 IZ
+}
+
+func ExampleHamiltonian_Expectation() {
+	h, err := pauli.Parse("0.5*ZZ + 0.3*XX + 0.2*YY")
+	if err != nil {
+		panic(err)
+	}
+
+	// bell state (|00> + |11>)/sqrt(2)
+	amp := []complex128{
+		complex(1/math.Sqrt2, 0),
+		0,
+		0,
+		complex(1/math.Sqrt2, 0),
+	}
+
+	e, err := h.Expectation(amp, []int{0, 1})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%.4f\n", e)
+
+	// Output:
+	// 0.6000
+}
+
+func TestHamiltonian_Expectation(t *testing.T) {
+	cases := []struct {
+		h     string
+		amp   []complex128
+		index []int
+		want  float64
+	}{
+		{"Z", []complex128{1, 0}, []int{0}, 1},
+		{"Z", []complex128{0, 1}, []int{0}, -1},
+		{"X", []complex128{complex(1/math.Sqrt2, 0), complex(1/math.Sqrt2, 0)}, []int{0}, 1},
+		{"Y", []complex128{complex(1/math.Sqrt2, 0), complex(0, 1/math.Sqrt2)}, []int{0}, 1},
+		{"Y", []complex128{complex(1/math.Sqrt2, 0), complex(0, -1/math.Sqrt2)}, []int{0}, -1},
+		{"ZI", []complex128{0, 0, 1, 0}, []int{0, 1}, -1},
+		{"IZ", []complex128{0, 0, 1, 0}, []int{0, 1}, 1},
+		{"ZI", []complex128{0, 0, 1, 0}, []int{1, 0}, 1},
+		{"Z", []complex128{0, 0, 1, 0}, []int{1}, 1},
+		{"2*Z - 0.5*I", []complex128{0, 1}, []int{0}, -2.5},
+	}
+
+	for _, c := range cases {
+		h, err := pauli.Parse(c.h)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		got, err := h.Expectation(c.amp, c.index)
+		if err != nil {
+			t.Fatalf("expectation: %v", err)
+		}
+
+		if math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("%s: got=%v, want=%v", c.h, got, c.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in  string
+		err error
+	}{
+		{"", pauli.ErrInvalidTerm},
+		{"0.5*", pauli.ErrInvalidTerm},
+		{"a*ZZ", pauli.ErrInvalidTerm},
+		{"ZA", pauli.ErrInvalidTerm},
+		{"ZZ + X", pauli.ErrInvalidLength},
+	}
+
+	for _, c := range cases {
+		if _, err := pauli.Parse(c.in); !errors.Is(err, c.err) {
+			t.Errorf("%q: got=%v, want=%v", c.in, err, c.err)
+		}
+	}
+}
+
+func TestHamiltonian_Expectation_invalid(t *testing.T) {
+	h, err := pauli.Parse("ZZ")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if _, err := h.Expectation([]complex128{1, 0}, []int{0}); !errors.Is(err, pauli.ErrInvalidLength) {
+		t.Errorf("got=%v, want=%v", err, pauli.ErrInvalidLength)
+	}
+}
+
+func TestHamiltonian_DensityExpectation(t *testing.T) {
+	cases := []struct {
+		h   string
+		amp []complex128
+	}{
+		{"0.5*ZZ + 0.3*XX + 0.2*YY", []complex128{complex(1/math.Sqrt2, 0), 0, 0, complex(1/math.Sqrt2, 0)}},
+		{"XY - 0.5*ZI", []complex128{0.5, complex(0, 0.5), -0.5, complex(0, -0.5)}},
+		{"Y", []complex128{complex(1/math.Sqrt2, 0), complex(0, 1/math.Sqrt2)}},
+	}
+
+	for _, c := range cases {
+		h, err := pauli.Parse(c.h)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+
+		// rho = |psi><psi|
+		rho := make([]complex128, len(c.amp)*len(c.amp))
+		for i := range c.amp {
+			for j := range c.amp {
+				rho[i*len(c.amp)+j] = c.amp[i] * complex(real(c.amp[j]), -imag(c.amp[j]))
+			}
+		}
+
+		index := make([]int, h.Qubits())
+		for i := range index {
+			index[i] = i
+		}
+
+		want, err := h.Expectation(c.amp, index)
+		if err != nil {
+			t.Fatalf("expectation: %v", err)
+		}
+
+		got, err := h.DensityExpectation(rho, index)
+		if err != nil {
+			t.Fatalf("density expectation: %v", err)
+		}
+
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: got=%v, want=%v", c.h, got, want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package qasm2
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/itsubaki/quasar/lexer"
+)
+
+// Error is an error at a position of an OpenQASM 2 program.
+type Error = lexer.Error
+
+// Qelib1 is the standard library of OpenQASM 2, written with the built-in U and CX gates.
+const Qelib1 = `gate u3(theta, phi, lambda) q { U(theta, phi, lambda) q; }
+gate u2(phi, lambda) q { U(pi / 2, phi, lambda) q; }
+gate u1(lambda) q { U(0, 0, lambda) q; }
+gate cx c, t { CX c, t; }
+gate id a { U(0, 0, 0) a; }
+gate u0(gamma) q { U(0, 0, 0) q; }
+gate u(theta, phi, lambda) q { U(theta, phi, lambda) q; }
+gate p(lambda) q { U(0, 0, lambda) q; }
+gate x a { u3(pi, 0, pi) a; }
+gate y a { u3(pi, pi / 2, pi / 2) a; }
+gate z a { u1(pi) a; }
+gate h a { u2(0, pi) a; }
+gate s a { u1(pi / 2) a; }
+gate sdg a { u1(-pi / 2) a; }
+gate t a { u1(pi / 4) a; }
+gate tdg a { u1(-pi / 4) a; }
+gate sx a { sdg a; h a; sdg a; }
+gate sxdg a { s a; h a; s a; }
+gate rx(theta) a { u3(theta, -pi / 2, pi / 2) a; }
+gate ry(theta) a { u3(theta, 0, 0) a; }
+gate rz(phi) a { u1(phi) a; }
+gate cz a, b { h b; cx a, b; h b; }
+gate cy a, b { sdg b; cx a, b; s b; }
+gate swap a, b { cx a, b; cx b, a; cx a, b; }
+gate ch a, b { h b; sdg b; cx a, b; h b; t b; cx a, b; t b; h b; s b; x b; s a; }
+gate ccx a, b, c { h c; cx b, c; tdg c; cx a, c; t c; cx b, c; tdg c; cx a, c; t b; t c; h c; cx a, b; t a; tdg b; cx a, b; }
+gate cswap a, b, c { cx c, b; ccx a, b, c; cx c, b; }
+gate crx(lambda) a, b { u1(pi / 2) b; cx a, b; u3(-lambda / 2, 0, 0) b; cx a, b; u3(lambda / 2, -pi / 2, 0) b; }
+gate cry(lambda) a, b { ry(lambda / 2) b; cx a, b; ry(-lambda / 2) b; cx a, b; }
+gate crz(lambda) a, b { rz(lambda / 2) b; cx a, b; rz(-lambda / 2) b; cx a, b; }
+gate cu1(lambda) a, b { u1(lambda / 2) a; cx a, b; u1(-lambda / 2) b; cx a, b; u1(lambda / 2) b; }
+gate cp(lambda) a, b { p(lambda / 2) a; cx a, b; p(-lambda / 2) b; cx a, b; p(lambda / 2) b; }
+gate cu3(theta, phi, lambda) c, t { u1((lambda + phi) / 2) c; u1((lambda - phi) / 2) t; cx c, t; u3(-theta / 2, 0, -(phi + lambda) / 2) t; cx c, t; u3(theta / 2, phi, 0) t; }
+gate csx a, b { h b; cu1(pi / 2) a, b; h b; }
+gate cu(theta, phi, lambda, gamma) c, t { p(gamma) c; p((lambda + phi) / 2) c; p((lambda - phi) / 2) t; cx c, t; u(-theta / 2, 0, -(phi + lambda) / 2) t; cx c, t; u(theta / 2, phi, 0) t; }
+gate rxx(theta) a, b { u3(pi / 2, theta, 0) a; h b; cx a, b; u1(-theta) b; cx a, b; h b; u2(-pi, pi - theta) a; }
+gate rzz(theta) a, b { cx a, b; u1(theta) b; cx a, b; }
+`
+
+// cx is the built-in CX gate of OpenQASM 2.
+const cx = "gate CX c, t { ctrl @ U(pi, 0, pi) c, t; }"
+
+// keywords are the OpenQASM 3 keywords that are not in OpenQASM 2.
+var keywords = map[string]bool{
+	"qubit": true, "bit": true, "int": true, "uint": true, "float": true, "angle": true, "bool": true,
+	"complex": true, "duration": true, "stretch": true, "array": true, "const": true, "let": true,
+	"input": true, "output": true, "def": true, "return": true, "extern": true, "defcal": true, "cal": true,
+	"for": true, "in": true, "while": true, "break": true, "continue": true, "switch": true, "case": true,
+	"default": true, "else": true, "box": true, "delay": true, "gphase": true,
+	"ctrl": true, "negctrl": true, "inv": true, "pow": true,
+}
+
+var header = regexp.MustCompile(`^OPENQASM\s+([0-9]+(?:\.[0-9]+)?)\s*;`)
+
+// Version returns the version in the header of the program, e.g. "2.0" or "3.0", or "" if there is no header.
+func Version(code string) string {
+	for _, t := range lexer.Tokenize(code) {
+		if t.Kind == lexer.Comment {
+			continue
+		}
+
+		if m := header.FindStringSubmatch(code[t.Offset:]); m != nil {
+			return m[1]
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+// Translate returns the OpenQASM 2 program as an OpenQASM 3 program, or the program as it is for the other versions.
+// The include of qelib1.inc is replaced by its gates, and the built-in CX gate is defined after the header.
+// The definitions are written on the lines they replace, so the line numbers of the statements do not change.
+// It returns an *Error for the OpenQASM 3 keywords, the includes of other files and the opaque gates.
+func Translate(code string) (string, error) {
+	version := Version(code)
+	if version != "2" && version != "2.0" {
+		return code, nil
+	}
+
+	tokens := lexer.Tokenize(code)
+	var sb strings.Builder
+	var last int
+	replace := func(start, end int, s string) {
+		sb.WriteString(code[last:start])
+		sb.WriteString(s)
+		last = end
+	}
+
+	var versioned bool
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.Kind == lexer.Comment {
+			continue
+		}
+
+		switch {
+		case !versioned && t.Text == "OPENQASM":
+			// OPENQASM 2.0; -> OPENQASM 3.0; gate CX ...
+			m := header.FindStringIndex(code[t.Offset:])
+			replace(t.Offset, t.Offset+m[1], "OPENQASM 3.0; "+cx)
+			versioned = true
+
+			for i+1 < len(tokens) && tokens[i+1].Offset < t.Offset+m[1] {
+				i++
+			}
+		case t.Text == "include":
+			j := lexer.Next(tokens, i)
+			k := lexer.Next(tokens, j)
+			if j < 0 || tokens[j].Kind != lexer.String || k < 0 || tokens[k].Text != ";" {
+				return "", t.Errorf("invalid include")
+			}
+
+			if name := strings.Trim(tokens[j].Text, `"`); name != "qelib1.inc" {
+				return "", tokens[j].Errorf("include %q is not supported in OpenQASM 2.0", name)
+			}
+
+			replace(t.Offset, tokens[k].Offset+1, strings.ReplaceAll(strings.TrimSpace(Qelib1), "\n", " "))
+			i = k
+		case t.Text == "opaque":
+			return "", t.Errorf("opaque gates are not supported")
+		case t.Kind == lexer.Ident && keywords[t.Text]:
+			return "", t.Errorf("%q is not supported in OpenQASM 2.0", t.Text)
+		case t.Text == "@":
+			return "", t.Errorf("gate modifiers are not supported in OpenQASM 2.0")
+		}
+	}
+
+	sb.WriteString(code[last:])
+	return sb.String(), nil
+}
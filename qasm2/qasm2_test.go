@@ -0,0 +1,132 @@
+package qasm2_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itsubaki/quasar/qasm2"
+)
+
+func ExampleTranslate() {
+	code := `OPENQASM 2.0;
+qreg q[2];
+creg c[2];
+U(pi/2, 0, pi) q[0];
+CX q[0], q[1];
+measure q -> c;
+`
+
+	translated, err := qasm2.Translate(code)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(translated)
+
+	// Output:
+	// OPENQASM 3.0; gate CX c, t { ctrl @ U(pi, 0, pi) c, t; }
+	// qreg q[2];
+	// creg c[2];
+	// U(pi/2, 0, pi) q[0];
+	// CX q[0], q[1];
+	// measure q -> c;
+}
+
+func ExampleVersion() {
+	fmt.Println(qasm2.Version("OPENQASM 2.0;"))
+	fmt.Println(qasm2.Version("// comment\n/* comment */ OPENQASM 3;"))
+	fmt.Println(qasm2.Version("qubit q;") == "")
+
+	// Output:
+	// 2.0
+	// 3
+	// true
+}
+
+func TestTranslate(t *testing.T) {
+	code := `// bell state
+OPENQASM 2.0;
+include "qelib1.inc"; // standard gates
+qreg q[2];
+creg c[2];
+/* h and cx
+   on two qubits */
+h q[0];
+cx q[0], q[1];
+measure q -> c;
+`
+
+	got, err := qasm2.Translate(code)
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+
+	if strings.Count(got, "\n") != strings.Count(code, "\n") {
+		t.Errorf("got=%v", got)
+	}
+
+	lines := strings.Split(got, "\n")
+	if lines[1] != "OPENQASM 3.0; gate CX c, t { ctrl @ U(pi, 0, pi) c, t; }" {
+		t.Errorf("got=%v", lines[1])
+	}
+
+	if !strings.HasPrefix(lines[2], "gate u3(theta, phi, lambda) q {") || !strings.HasSuffix(lines[2], "} // standard gates") {
+		t.Errorf("got=%v", lines[2])
+	}
+
+	if strings.Count(lines[2], "gate ") != strings.Count(qasm2.Qelib1, "gate ") {
+		t.Errorf("got=%v", lines[2])
+	}
+
+	if strings.Join(lines[3:], "\n") != strings.Join(strings.Split(code, "\n")[3:], "\n") {
+		t.Errorf("got=%v", got)
+	}
+}
+
+func TestTranslate_v3(t *testing.T) {
+	for _, code := range []string{
+		"OPENQASM 3.0; qubit q; ctrl @ x q;",
+		"qubit q;",
+		"",
+	} {
+		got, err := qasm2.Translate(code)
+		if err != nil {
+			t.Fatalf("translate: %v", err)
+		}
+
+		if got != code {
+			t.Errorf("got=%v, want=%v", got, code)
+		}
+	}
+}
+
+func TestTranslate_error(t *testing.T) {
+	cases := []struct {
+		code   string
+		line   int
+		column int
+		msg    string
+	}{
+		{"OPENQASM 2.0;\nqubit[2] q;", 2, 0, `"qubit" is not supported in OpenQASM 2.0`},
+		{"OPENQASM 2.0;\nqreg q[2];\nfor i in [0:1] { }", 3, 0, `"for" is not supported in OpenQASM 2.0`},
+		{"OPENQASM 2.0;\nqreg q[2];\ninv @ U(0, 0, pi) q[0];", 3, 0, `"inv" is not supported in OpenQASM 2.0`},
+		{"OPENQASM 2.0;\ninclude \"stdgates.inc\";", 2, 8, `include "stdgates.inc" is not supported in OpenQASM 2.0`},
+		{"OPENQASM 2.0;\ninclude qelib1;", 2, 0, "invalid include"},
+		{"OPENQASM 2.0;\nqreg q[1];\n  opaque g q;", 3, 2, "opaque gates are not supported"},
+	}
+
+	for _, c := range cases {
+		_, err := qasm2.Translate(c.code)
+
+		var e *qasm2.Error
+		if !errors.As(err, &e) {
+			t.Fatalf("got=%v", err)
+		}
+
+		if e.Line != c.line || e.Column != c.column || e.Message != c.msg {
+			t.Errorf("got=%v, want=%d:%d %s", e, c.line, c.column, c.msg)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+var (
+	ErrInvalidEdge     = errors.New("invalid edge")
+	ErrTooManyQubits   = errors.New("too many qubits for the coupling map")
+	ErrTooManyControls = errors.New("gate with more than one control")
+	ErrDisconnected    = errors.New("qubits are not connected")
+	ErrTooManyOps      = errors.New("too many operations")
+)
+
+type Router struct {
+	maxOps int
+}
+
+type Option func(*Router)
+
+// WithMaxOps limits the operations of the routed circuit, including the gates of the swaps.
+func WithMaxOps(n int) Option {
+	return func(r *Router) {
+		r.maxOps = n
+	}
+}
+
+// Result is the routed circuit on the physical qubits.
+// Layout is the physical qubit of each logical qubit at the start of the circuit,
+// and Final is the physical qubit of each logical qubit at the end, after the swaps.
+type Result struct {
+	Circuit *circuit.Circuit
+	Layout  []int
+	Final   []int
+	Swaps   int
+}
+
+// Route maps the logical qubits of c to the physical qubits of the coupling map,
+// and inserts swaps so that every two-qubit gate acts on connected physical qubits.
+// The edges are undirected, and the physical qubits are numbered from 0 to the largest qubit in the edges.
+// Logical qubit i starts on physical qubit i, and each swap moves the control one step along a shortest path to the target.
+// Each swap is three controlled X gates. The gates must have at most one control, e.g. transpiled to a basis.
+func Route(c *circuit.Circuit, edges [][2]int, opts ...Option) (*Result, error) {
+	router := &Router{}
+	for _, opt := range opts {
+		opt(router)
+	}
+
+	var n int
+	for _, e := range edges {
+		if e[0] < 0 || e[1] < 0 || e[0] == e[1] {
+			return nil, fmt.Errorf("%v: %w", e, ErrInvalidEdge)
+		}
+
+		n = max(n, e[0]+1, e[1]+1)
+	}
+
+	if c.Qubits > n {
+		return nil, fmt.Errorf("qubits=%d, physical=%d: %w", c.Qubits, n, ErrTooManyQubits)
+	}
+
+	dist := distances(n, edges)
+	phys, logical := make([]int, n), make([]int, n)
+	for i := range n {
+		phys[i], logical[i] = i, i
+	}
+
+	r := &Result{
+		Circuit: &circuit.Circuit{
+			Qubits: n,
+			Clbits: c.Clbits,
+			QRegs:  []circuit.Register{{Name: "q", Index: seq(n)}},
+			CRegs:  c.CRegs,
+		},
+		Layout: slices.Clone(phys[:c.Qubits]),
+	}
+
+	swap := func(a, b int) {
+		for _, op := range [][2]int{{a, b}, {b, a}, {a, b}} {
+			r.Circuit.Ops = append(r.Circuit.Ops, circuit.Op{
+				Kind:     circuit.Gate,
+				Theta:    math.Pi,
+				Lambda:   math.Pi,
+				Target:   op[1],
+				Controls: []int{op[0]},
+				Clbit:    -1,
+			})
+		}
+
+		logical[a], logical[b] = logical[b], logical[a]
+		phys[logical[a]], phys[logical[b]] = a, b
+		r.Swaps++
+	}
+
+	for _, op := range c.Ops {
+		if op.Kind == circuit.Gate {
+			qubits := op.Operands()
+			if len(qubits) > 2 {
+				return nil, fmt.Errorf("%v: %w", qubits, ErrTooManyControls)
+			}
+
+			if len(qubits) == 2 {
+				a, b := qubits[0], qubits[1]
+				if dist[phys[a]][phys[b]] < 0 {
+					return nil, fmt.Errorf("%d, %d: %w", phys[a], phys[b], ErrDisconnected)
+				}
+
+				for dist[phys[a]][phys[b]] > 1 {
+					swap(phys[a], next(dist, edges, phys[a], phys[b]))
+					if router.maxOps > 0 && len(r.Circuit.Ops) > router.maxOps {
+						return nil, fmt.Errorf("max=%d: %w", router.maxOps, ErrTooManyOps)
+					}
+				}
+			}
+		}
+
+		r.Circuit.Ops = append(r.Circuit.Ops, remap(op, phys))
+		if router.maxOps > 0 && len(r.Circuit.Ops) > router.maxOps {
+			return nil, fmt.Errorf("max=%d: %w", router.maxOps, ErrTooManyOps)
+		}
+	}
+
+	r.Final = slices.Clone(phys[:c.Qubits])
+	return r, nil
+}
+
+// remap returns the operation on the physical qubits.
+func remap(op circuit.Op, phys []int) circuit.Op {
+	m := func(qubits []int) []int {
+		if qubits == nil {
+			return nil
+		}
+
+		out := make([]int, len(qubits))
+		for i, q := range qubits {
+			out[i] = phys[q]
+		}
+
+		return out
+	}
+
+	out := op
+	out.Controls, out.NegControls, out.Qubits = m(op.Controls), m(op.NegControls), m(op.Qubits)
+	if op.Kind == circuit.Gate || op.Kind == circuit.Measure || op.Kind == circuit.Reset {
+		out.Target = phys[op.Target]
+	}
+
+	return out
+}
+
+// next returns the neighbor of a on a shortest path to b.
+func next(dist [][]int, edges [][2]int, a, b int) int {
+	for _, e := range edges {
+		for _, v := range [][2]int{{e[0], e[1]}, {e[1], e[0]}} {
+			if v[0] == a && dist[v[1]][b] == dist[a][b]-1 {
+				return v[1]
+			}
+		}
+	}
+
+	return b
+}
+
+// distances returns the number of edges of the shortest path between each pair of qubits, or -1 if they are disconnected.
+func distances(n int, edges [][2]int) [][]int {
+	adj := make([][]int, n)
+	for _, e := range edges {
+		adj[e[0]] = append(adj[e[0]], e[1])
+		adj[e[1]] = append(adj[e[1]], e[0])
+	}
+
+	dist := make([][]int, n)
+	for s := range n {
+		dist[s] = make([]int, n)
+		for i := range dist[s] {
+			dist[s][i] = -1
+		}
+
+		dist[s][s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range adj[v] {
+				if dist[s][w] < 0 {
+					dist[s][w] = dist[s][v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+
+	return dist
+}
+
+func seq(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+
+	return out
+}
@@ -0,0 +1,184 @@
+package route_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/route"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ExampleRoute() {
+	c := &circuit.Circuit{
+		Qubits: 3,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 2, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+		},
+	}
+
+	// 0 - 1 - 2
+	r, err := route.Route(c, [][2]int{{0, 1}, {1, 2}})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(r.Layout, r.Final, r.Swaps)
+	fmt.Print(circuit.QASM(r.Circuit))
+
+	// Output:
+	// [0 1 2] [1 0 2] 1
+	// OPENQASM 3.0;
+	//
+	// qubit[3] q;
+	//
+	// U(pi/2, 0, pi) q[0];
+	// ctrl @ U(pi, 0, pi) q[0], q[1];
+	// ctrl @ U(pi, 0, pi) q[1], q[0];
+	// ctrl @ U(pi, 0, pi) q[0], q[1];
+	// ctrl @ U(pi, 0, pi) q[1], q[2];
+	// ctrl @ U(pi, 0, pi) q[1], q[0];
+}
+
+func TestRoute(t *testing.T) {
+	maps := [][][2]int{
+		{{0, 1}, {1, 2}, {2, 3}, {3, 4}},
+		{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}},
+		{{0, 2}, {1, 2}, {2, 3}, {3, 4}},
+		{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}},
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	angles := []float64{0, math.Pi / 4, math.Pi / 2, math.Pi, 0.3}
+	angle := func() float64 { return angles[rng.IntN(len(angles))] }
+
+	for range 50 {
+		n := 5
+		c := &circuit.Circuit{Qubits: n}
+		for range 1 + rng.IntN(20) {
+			target := rng.IntN(n)
+			op := gate(angle(), angle(), angle(), target)
+			if ctrl := rng.IntN(n); ctrl != target && rng.IntN(2) == 0 {
+				op.Controls = []int{ctrl}
+			}
+
+			c.Ops = append(c.Ops, op)
+		}
+
+		for _, edges := range maps {
+			r, err := route.Route(c, edges)
+			if err != nil {
+				t.Fatalf("route: %v", err)
+			}
+
+			connected := make(map[[2]int]bool)
+			for _, e := range edges {
+				connected[e], connected[[2]int{e[1], e[0]}] = true, true
+			}
+
+			for _, op := range r.Circuit.Ops {
+				if len(op.Controls) == 1 && !connected[[2]int{op.Controls[0], op.Target}] {
+					t.Fatalf("edges=%v, got=%v", edges, op)
+				}
+			}
+
+			if fidelity := fidelity(t, c, r); math.Abs(fidelity-1) > 1e-9 {
+				t.Fatalf("edges=%v, fidelity=%v, ops=%v", edges, fidelity, c.Ops)
+			}
+		}
+	}
+}
+
+// fidelity compares the unitary of c with the unitary of the routed circuit,
+// where the output qubits are permuted back to the logical qubits.
+func fidelity(t *testing.T, c *circuit.Circuit, r *route.Result) float64 {
+	ua, err := circuit.Unitary(c)
+	if err != nil {
+		t.Fatalf("unitary: %v", err)
+	}
+
+	ub, err := circuit.Unitary(r.Circuit)
+	if err != nil {
+		t.Fatalf("unitary: %v", err)
+	}
+
+	n, dim := c.Qubits, 1<<c.Qubits
+	logical := func(k int) int {
+		var out int
+		for l, p := range r.Final {
+			if k&(1<<(n-1-p)) != 0 {
+				out |= 1 << (n - 1 - l)
+			}
+		}
+
+		return out
+	}
+
+	var tr complex128
+	for j := range dim {
+		for k := range dim {
+			tr += cmplx.Conj(ua[logical(j)*dim+k]) * ub[j*dim+k]
+		}
+	}
+
+	abs := cmplx.Abs(tr) / float64(dim)
+	return abs * abs
+}
+
+func TestRoute_error(t *testing.T) {
+	cases := []struct {
+		c     *circuit.Circuit
+		edges [][2]int
+		want  error
+	}{
+		{&circuit.Circuit{Qubits: 2}, [][2]int{{0, 0}}, route.ErrInvalidEdge},
+		{&circuit.Circuit{Qubits: 2}, [][2]int{{-1, 0}}, route.ErrInvalidEdge},
+		{&circuit.Circuit{Qubits: 3}, [][2]int{{0, 1}}, route.ErrTooManyQubits},
+		{&circuit.Circuit{Qubits: 3, Ops: []circuit.Op{gate(math.Pi, 0, math.Pi, 2, 0, 1)}}, [][2]int{{0, 1}, {1, 2}}, route.ErrTooManyControls},
+		{&circuit.Circuit{Qubits: 4, Ops: []circuit.Op{gate(math.Pi, 0, math.Pi, 3, 0)}}, [][2]int{{0, 1}, {2, 3}}, route.ErrDisconnected},
+	}
+
+	for _, c := range cases {
+		if _, err := route.Route(c.c, c.edges); !errors.Is(err, c.want) {
+			t.Errorf("got=%v, want=%v", err, c.want)
+		}
+	}
+
+}
+
+func TestRoute_maxOps(t *testing.T) {
+	// a swap and the gate
+	in := &circuit.Circuit{Qubits: 3, Ops: []circuit.Op{gate(math.Pi, 0, math.Pi, 2, 0)}}
+	cases := []struct {
+		maxOps int
+		want   error
+	}{
+		{2, route.ErrTooManyOps},
+		{3, route.ErrTooManyOps},
+		{4, nil},
+		{0, nil},
+	}
+
+	for _, c := range cases {
+		if _, err := route.Route(in, [][2]int{{0, 1}, {1, 2}}, route.WithMaxOps(c.maxOps)); !errors.Is(err, c.want) {
+			t.Errorf("max=%d: got=%v, want=%v", c.maxOps, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,635 @@
+package stabilizer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+const eps = 1e-9
+
+var (
+	ErrNotClifford   = errors.New("not a clifford gate")
+	ErrTooManyQubits = errors.New("too many qubits")
+)
+
+// State is the stabilizer tableau of n qubits.
+// Rows 0 to n-1 are the destabilizers, rows n to 2n-1 are the stabilizers and row 2n is scratch space.
+type State struct {
+	N      int
+	Clbits []int
+	x      [][]bool
+	z      [][]bool
+	r      []bool
+}
+
+// New returns the tableau of |0...0>.
+func New(n int) *State {
+	s := &State{
+		N: n,
+		x: make([][]bool, 2*n+1),
+		z: make([][]bool, 2*n+1),
+		r: make([]bool, 2*n+1),
+	}
+
+	for i := range 2*n + 1 {
+		s.x[i] = make([]bool, n)
+		s.z[i] = make([]bool, n)
+	}
+
+	for i := range n {
+		s.x[i][i] = true
+		s.z[n+i][i] = true
+	}
+
+	return s
+}
+
+// Run runs the Clifford circuit.
+// maxQubits limits the number of qubits, or 0 for no limit.
+func Run(c *circuit.Circuit, rand func() float64, maxQubits int) (*State, error) {
+	if maxQubits > 0 && c.Qubits > maxQubits {
+		return nil, fmt.Errorf("need=%d, max=%d: %w", c.Qubits, maxQubits, ErrTooManyQubits)
+	}
+
+	s := New(c.Qubits)
+	s.Clbits = make([]int, c.Clbits)
+	for _, op := range c.Ops {
+		if _, err := s.Step(op, rand); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Step applies the operation and returns the measured bit, or 0 if the operation is not a measurement.
+// It returns ErrNotClifford if the gate is not a Clifford gate.
+func (s *State) Step(op circuit.Op, rand func() float64) (int, error) {
+	switch op.Kind {
+	case circuit.Gate:
+		gates, err := Decompose(op)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, g := range gates {
+			s.Apply(g)
+		}
+	case circuit.Measure:
+		m := s.Measure(op.Target, rand)
+		if op.Clbit >= 0 && op.Clbit < len(s.Clbits) {
+			s.Clbits[op.Clbit] = m
+		}
+
+		return m, nil
+	case circuit.Reset:
+		s.Reset(op.Target, rand)
+	}
+
+	return 0, nil
+}
+
+// Grow adds n qubits in |0> after the last qubit.
+func (s *State) Grow(n int) {
+	g := New(s.N + n)
+	for i := range s.N {
+		// the destabilizer and the stabilizer of qubit i
+		copy(g.x[i], s.x[i])
+		copy(g.z[i], s.z[i])
+		g.r[i] = s.r[i]
+
+		copy(g.x[g.N+i], s.x[s.N+i])
+		copy(g.z[g.N+i], s.z[s.N+i])
+		g.r[g.N+i] = s.r[s.N+i]
+	}
+
+	g.Clbits = s.Clbits
+	*s = *g
+}
+
+// Machine runs the operations on the tableau as they are emitted by the compiler.
+// Rand is used for measurement and reset.
+type Machine struct {
+	*State
+	Rand func() float64
+}
+
+// Grow adds n qubits in |0>.
+func (m *Machine) Grow(n int) error {
+	m.State.Grow(n)
+	return nil
+}
+
+// Run runs the operation and returns the measured bit.
+func (m *Machine) Run(op circuit.Op) (int, error) {
+	return m.Step(op, m.Rand)
+}
+
+// Clone returns a copy of the state.
+func (s *State) Clone() *State {
+	out := &State{
+		N:      s.N,
+		Clbits: append([]int{}, s.Clbits...),
+		x:      make([][]bool, len(s.x)),
+		z:      make([][]bool, len(s.z)),
+		r:      append([]bool{}, s.r...),
+	}
+
+	for i := range s.x {
+		out.x[i] = append([]bool{}, s.x[i]...)
+		out.z[i] = append([]bool{}, s.z[i]...)
+	}
+
+	return out
+}
+
+// Apply applies the Clifford gate.
+func (s *State) Apply(g Gate) {
+	switch g.Name {
+	case "h":
+		s.H(g.Qubits[0])
+	case "s":
+		s.S(g.Qubits[0])
+	case "x":
+		s.X(g.Qubits[0])
+	case "cx":
+		s.CX(g.Qubits[0], g.Qubits[1])
+	}
+}
+
+func (s *State) H(a int) {
+	for i := range 2 * s.N {
+		s.r[i] = s.r[i] != (s.x[i][a] && s.z[i][a])
+		s.x[i][a], s.z[i][a] = s.z[i][a], s.x[i][a]
+	}
+}
+
+func (s *State) S(a int) {
+	for i := range 2 * s.N {
+		s.r[i] = s.r[i] != (s.x[i][a] && s.z[i][a])
+		s.z[i][a] = s.z[i][a] != s.x[i][a]
+	}
+}
+
+func (s *State) X(a int) {
+	for i := range 2 * s.N {
+		s.r[i] = s.r[i] != s.z[i][a]
+	}
+}
+
+func (s *State) CX(a, b int) {
+	for i := range 2 * s.N {
+		s.r[i] = s.r[i] != (s.x[i][a] && s.z[i][b] && (s.x[i][b] == s.z[i][a]))
+		s.x[i][b] = s.x[i][b] != s.x[i][a]
+		s.z[i][a] = s.z[i][a] != s.z[i][b]
+	}
+}
+
+// Measure measures the qubit in the computational basis and collapses the state.
+func (s *State) Measure(a int, rand func() float64) int {
+	n := s.N
+
+	p := -1
+	for i := n; i < 2*n; i++ {
+		if s.x[i][a] {
+			p = i
+			break
+		}
+	}
+
+	if p < 0 {
+		// deterministic
+		clear(s.x[2*n])
+		clear(s.z[2*n])
+		s.r[2*n] = false
+		for i := range n {
+			if s.x[i][a] {
+				s.rowsum(2*n, i+n)
+			}
+		}
+
+		if s.r[2*n] {
+			return 1
+		}
+
+		return 0
+	}
+
+	// random
+	for i := range 2 * n {
+		if i != p && s.x[i][a] {
+			s.rowsum(i, p)
+		}
+	}
+
+	copy(s.x[p-n], s.x[p])
+	copy(s.z[p-n], s.z[p])
+	s.r[p-n] = s.r[p]
+
+	clear(s.x[p])
+	clear(s.z[p])
+	s.z[p][a] = true
+	s.r[p] = rand() < 0.5
+
+	if s.r[p] {
+		return 1
+	}
+
+	return 0
+}
+
+// Reset resets the qubit to |0>.
+func (s *State) Reset(a int, rand func() float64) {
+	if s.Measure(a, rand) == 1 {
+		s.X(a)
+	}
+}
+
+// Sample measures every qubit of a copy of the state.
+func (s *State) Sample(rand func() float64) []int {
+	c := s.Clone()
+
+	out := make([]int, s.N)
+	for i := range out {
+		out[i] = c.Measure(i, rand)
+	}
+
+	return out
+}
+
+// Expectation returns the expectation value of the Pauli string. e.g. XZ
+// index[i] is the qubit that the i-th Pauli operator acts on.
+func (s *State) Expectation(pauli string, index []int) float64 {
+	n := s.N
+	px, pz := make([]bool, n), make([]bool, n)
+	for i, p := range pauli {
+		px[index[i]] = p == 'X' || p == 'Y'
+		pz[index[i]] = p == 'Z' || p == 'Y'
+	}
+
+	anticommute := func(row int) bool {
+		var odd bool
+		for j := range n {
+			odd = odd != ((s.x[row][j] && pz[j]) != (s.z[row][j] && px[j]))
+		}
+
+		return odd
+	}
+
+	// the expectation is 0 if the Pauli string is not in the stabilizer group
+	for i := n; i < 2*n; i++ {
+		if anticommute(i) {
+			return 0
+		}
+	}
+
+	// product of the stabilizers whose destabilizer anticommutes with the Pauli string
+	clear(s.x[2*n])
+	clear(s.z[2*n])
+	s.r[2*n] = false
+	for i := range n {
+		if anticommute(i) {
+			s.rowsum(2*n, i+n)
+		}
+	}
+
+	if s.r[2*n] {
+		return -1
+	}
+
+	return 1
+}
+
+// Stabilizers returns the stabilizer generators. e.g. +XX, -ZZ
+// The i-th Pauli operator acts on qubit i.
+func (s *State) Stabilizers() []string {
+	out := make([]string, s.N)
+	for i := range s.N {
+		sign := byte('+')
+		if s.r[s.N+i] {
+			sign = '-'
+		}
+
+		var sb strings.Builder
+		sb.WriteByte(sign)
+
+		for j := range s.N {
+			x, z := s.x[s.N+i][j], s.z[s.N+i][j]
+			switch {
+			case x && z:
+				sb.WriteByte('Y')
+			case x:
+				sb.WriteByte('X')
+			case z:
+				sb.WriteByte('Z')
+			default:
+				sb.WriteByte('I')
+			}
+		}
+
+		out[i] = sb.String()
+	}
+
+	return out
+}
+
+// rowsum sets row h to the product of rows h and i.
+func (s *State) rowsum(h, i int) {
+	var sum int
+	for j := range s.N {
+		sum += g(s.x[i][j], s.z[i][j], s.x[h][j], s.z[h][j])
+	}
+
+	if s.r[h] {
+		sum += 2
+	}
+
+	if s.r[i] {
+		sum += 2
+	}
+
+	s.r[h] = ((sum%4)+4)%4 == 2
+	for j := range s.N {
+		s.x[h][j] = s.x[h][j] != s.x[i][j]
+		s.z[h][j] = s.z[h][j] != s.z[i][j]
+	}
+}
+
+// g returns the exponent to which i is raised when the Pauli matrices are multiplied.
+func g(x1, z1, x2, z2 bool) int {
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+
+		return 0
+	}
+
+	switch {
+	case !x1 && !z1:
+		return 0
+	case x1 && z1:
+		return b(z2) - b(x2)
+	case x1 && !z1:
+		return b(z2) * (2*b(x2) - 1)
+	default:
+		return b(x2) * (1 - 2*b(z2))
+	}
+}
+
+// Gate is a gate of the generating set h, s, x and cx.
+type Gate struct {
+	Name   string
+	Qubits []int
+}
+
+var (
+	hmat = circuit.U(math.Pi/2, 0, math.Pi, 0)
+	smat = circuit.U(0, 0, math.Pi/2, 0)
+)
+
+// cliffords is the sequence of h and s for each single-qubit Clifford gate.
+var cliffords = func() map[[2][2]complex128][]string {
+	type word struct {
+		m     [2][2]complex128
+		names []string
+	}
+
+	out := map[[2][2]complex128][]string{
+		normalize(circuit.U(0, 0, 0, 0)): {},
+	}
+
+	queue := []word{{m: circuit.U(0, 0, 0, 0)}}
+	for len(queue) > 0 {
+		w := queue[0]
+		queue = queue[1:]
+
+		for _, next := range []struct {
+			name string
+			m    [2][2]complex128
+		}{
+			{"h", hmat},
+			{"s", smat},
+		} {
+			m := normalize(mul(next.m, w.m))
+			if _, ok := out[m]; ok {
+				continue
+			}
+
+			names := append(append([]string{}, w.names...), next.name)
+			out[m] = names
+			queue = append(queue, word{m: m, names: names})
+		}
+	}
+
+	return out
+}()
+
+// Decompose returns the sequence of h, s, x and cx equivalent to the operation up to a global phase.
+func Decompose(op circuit.Op) ([]Gate, error) {
+	m := op.Matrix()
+	ctrl := len(op.Controls) + len(op.NegControls)
+
+	if ctrl == 0 {
+		names, ok := cliffords[normalize(m)]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", name(op), ErrNotClifford)
+		}
+
+		gates := make([]Gate, len(names))
+		for i, n := range names {
+			gates[i] = Gate{Name: n, Qubits: []int{op.Target}}
+		}
+
+		return gates, nil
+	}
+
+	// controlled exp(i*k*pi/2)*P
+	pauli, k, ok := controlledPauli(m)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name(op), ErrNotClifford)
+	}
+
+	if pauli == 'I' && k == 0 {
+		return nil, nil
+	}
+
+	if ctrl > 1 {
+		return nil, fmt.Errorf("%s: %w", name(op), ErrNotClifford)
+	}
+
+	c, neg := 0, len(op.NegControls) == 1
+	if neg {
+		c = op.NegControls[0]
+	} else {
+		c = op.Controls[0]
+	}
+
+	t := op.Target
+	var gates []Gate
+	if neg {
+		gates = append(gates, Gate{"x", []int{c}})
+	}
+
+	// the phase of the controlled gate is a phase gate on the control
+	for range k {
+		gates = append(gates, Gate{"s", []int{c}})
+	}
+
+	switch pauli {
+	case 'X':
+		gates = append(gates, Gate{"cx", []int{c, t}})
+	case 'Y':
+		gates = append(gates,
+			Gate{"s", []int{t}}, Gate{"s", []int{t}}, Gate{"s", []int{t}},
+			Gate{"cx", []int{c, t}},
+			Gate{"s", []int{t}},
+		)
+	case 'Z':
+		gates = append(gates,
+			Gate{"h", []int{t}},
+			Gate{"cx", []int{c, t}},
+			Gate{"h", []int{t}},
+		)
+	}
+
+	if neg {
+		gates = append(gates, Gate{"x", []int{c}})
+	}
+
+	return gates, nil
+}
+
+// IsClifford returns true if every gate of the circuit is a Clifford gate.
+func IsClifford(c *circuit.Circuit) bool {
+	for _, op := range c.Ops {
+		if op.Kind != circuit.Gate {
+			continue
+		}
+
+		if _, err := Decompose(op); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func controlledPauli(m [2][2]complex128) (byte, int, bool) {
+	paulis := []struct {
+		name byte
+		m    [2][2]complex128
+	}{
+		{'I', [2][2]complex128{{1, 0}, {0, 1}}},
+		{'X', [2][2]complex128{{0, 1}, {1, 0}}},
+		{'Y', [2][2]complex128{{0, -1i}, {1i, 0}}},
+		{'Z', [2][2]complex128{{1, 0}, {0, -1}}},
+	}
+
+	for _, p := range paulis {
+		// m = exp(i*gamma)*P
+		var gamma complex128
+		for i := range 2 {
+			for j := range 2 {
+				if p.m[i][j] != 0 && gamma == 0 {
+					gamma = m[i][j] / p.m[i][j]
+				}
+			}
+		}
+
+		if !equals(m, scale(p.m, gamma)) {
+			continue
+		}
+
+		// gamma = k*pi/2
+		f := cmplx.Phase(gamma) / (math.Pi / 2)
+		k := math.Round(f)
+		if math.Abs(f-k) > eps {
+			return 0, 0, false
+		}
+
+		return p.name, (int(k)%4 + 4) % 4, true
+	}
+
+	return 0, 0, false
+}
+
+// normalize removes the global phase and rounds the matrix so that it can be used as a map key.
+func normalize(m [2][2]complex128) [2][2]complex128 {
+	var phase complex128
+	for i := range 2 {
+		for j := range 2 {
+			if phase == 0 && cmplx.Abs(m[i][j]) > eps {
+				phase = cmplx.Exp(complex(0, -cmplx.Phase(m[i][j])))
+			}
+		}
+	}
+
+	round := func(v float64) float64 {
+		r := math.Round(v*1e6) / 1e6
+		if r == 0 {
+			// -0
+			return 0
+		}
+
+		return r
+	}
+
+	var out [2][2]complex128
+	for i := range 2 {
+		for j := range 2 {
+			v := m[i][j] * phase
+			out[i][j] = complex(round(real(v)), round(imag(v)))
+		}
+	}
+
+	return out
+}
+
+func mul(a, b [2][2]complex128) [2][2]complex128 {
+	var out [2][2]complex128
+	for i := range 2 {
+		for j := range 2 {
+			for k := range 2 {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+
+	return out
+}
+
+func scale(m [2][2]complex128, v complex128) [2][2]complex128 {
+	for i := range 2 {
+		for j := range 2 {
+			m[i][j] *= v
+		}
+	}
+
+	return m
+}
+
+func equals(a, b [2][2]complex128) bool {
+	for i := range 2 {
+		for j := range 2 {
+			if cmplx.Abs(a[i][j]-b[i][j]) > eps {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func name(op circuit.Op) string {
+	if op.Call != nil {
+		return op.Call.Name
+	}
+
+	return op.Kind.String()
+}
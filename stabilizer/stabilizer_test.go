@@ -0,0 +1,271 @@
+package stabilizer_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/stabilizer"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ExampleRun() {
+	// GHZ state
+	c := &circuit.Circuit{
+		Qubits: 3,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+			gate(math.Pi, 0, math.Pi, 2, 1),
+		},
+	}
+
+	s, err := stabilizer.Run(c, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(s.Stabilizers())
+
+	// Output:
+	// [+XXX +ZZI +IZZ]
+}
+
+func ExampleState_Sample() {
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+		},
+	}
+
+	s, err := stabilizer.Run(c, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(s.Sample(func() float64 { return 0.1 }))
+	fmt.Println(s.Sample(func() float64 { return 0.9 }))
+
+	// Output:
+	// [1 1]
+	// [0 0]
+}
+
+func ExampleMachine() {
+	m := &stabilizer.Machine{
+		State: stabilizer.New(1),
+	}
+
+	// the qubits are added as they are declared
+	for _, op := range []circuit.Op{
+		gate(math.Pi/2, 0, math.Pi, 0),
+		gate(math.Pi, 0, math.Pi, 1, 0),
+		gate(math.Pi, 0, math.Pi, 2, 1),
+	} {
+		if err := m.Grow(1); err != nil {
+			panic(err)
+		}
+
+		if _, err := m.Run(op); err != nil {
+			panic(err)
+		}
+	}
+
+	fmt.Println(m.N, m.Stabilizers())
+
+	// Output:
+	// 4 [+XXXI +ZZII +IZZI +IIIZ]
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		ops  []circuit.Op
+		want []string
+	}{
+		{[]circuit.Op{gate(math.Pi, 0, math.Pi, 0)}, []string{"-Z"}},
+		{[]circuit.Op{gate(math.Pi/2, 0, math.Pi, 0)}, []string{"+X"}},
+		{[]circuit.Op{gate(math.Pi/2, 0, math.Pi, 0), gate(0, 0, math.Pi/2, 0)}, []string{"+Y"}},
+		{[]circuit.Op{gate(math.Pi/2, 0, math.Pi, 0), gate(0, 0, -math.Pi/2, 0)}, []string{"-Y"}},
+		{[]circuit.Op{gate(math.Pi, math.Pi/2, math.Pi/2, 0)}, []string{"-Z"}},
+		{[]circuit.Op{gate(math.Pi/2, 0, 0, 0)}, []string{"+X"}},
+		{[]circuit.Op{{Kind: circuit.GlobalPhase, Phase: 0.3}, gate(0, 0, math.Pi, 0)}, []string{"+Z"}},
+	}
+
+	for _, c := range cases {
+		s, err := stabilizer.Run(&circuit.Circuit{Qubits: 1, Ops: c.ops}, nil, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if got := s.Stabilizers(); fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
+
+func TestRun_controlled(t *testing.T) {
+	h := func(q int) circuit.Op { return gate(math.Pi/2, 0, math.Pi, q) }
+
+	cases := []struct {
+		ops  []circuit.Op
+		want []string
+	}{
+		// cz |++>
+		{[]circuit.Op{h(0), h(1), gate(0, 0, math.Pi, 1, 0)}, []string{"+XZ", "+ZX"}},
+		// cy |+0>
+		{[]circuit.Op{h(0), gate(math.Pi, math.Pi/2, math.Pi/2, 1, 0)}, []string{"+XY", "+ZZ"}},
+		// negctrl @ x |00>
+		{[]circuit.Op{{Kind: circuit.Gate, Theta: math.Pi, Lambda: math.Pi, Target: 1, NegControls: []int{0}}}, []string{"+ZI", "-ZZ"}},
+	}
+
+	for _, c := range cases {
+		s, err := stabilizer.Run(&circuit.Circuit{Qubits: 2, Ops: c.ops}, nil, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if got := s.Stabilizers(); fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("got=%v, want=%v", got, c.want)
+		}
+	}
+}
+
+func TestRun_measure(t *testing.T) {
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Clbits: 2,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+			{Kind: circuit.Measure, Target: 0, Clbit: 0},
+			{Kind: circuit.Measure, Target: 1, Clbit: 1},
+			{Kind: circuit.Reset, Target: 0, Clbit: -1},
+		},
+	}
+
+	for _, r := range []float64{0.1, 0.9} {
+		s, err := stabilizer.Run(c, func() float64 { return r }, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if s.Clbits[0] != s.Clbits[1] {
+			t.Errorf("got=%v", s.Clbits)
+		}
+
+		if got := s.Sample(nil)[0]; got != 0 {
+			t.Errorf("reset: got=%v", got)
+		}
+	}
+}
+
+func TestRun_large(t *testing.T) {
+	n := 500
+	c := &circuit.Circuit{Qubits: n}
+	c.Ops = append(c.Ops, gate(math.Pi/2, 0, math.Pi, 0))
+	for i := range n - 1 {
+		c.Ops = append(c.Ops, gate(math.Pi, 0, math.Pi, i+1, i))
+	}
+
+	s, err := stabilizer.Run(c, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	bits := s.Sample(func() float64 { return 0.1 })
+	for i := range bits {
+		if bits[i] != 1 {
+			t.Fatalf("got=%v", bits)
+		}
+	}
+}
+
+func TestRun_invalid(t *testing.T) {
+	cases := []struct {
+		c         *circuit.Circuit
+		maxQubits int
+		err       error
+	}{
+		{&circuit.Circuit{Qubits: 1, Ops: []circuit.Op{gate(0, 0, math.Pi/4, 0)}}, 0, stabilizer.ErrNotClifford},
+		{&circuit.Circuit{Qubits: 3, Ops: []circuit.Op{gate(math.Pi, 0, math.Pi, 2, 0, 1)}}, 0, stabilizer.ErrNotClifford},
+		{&circuit.Circuit{Qubits: 3}, 2, stabilizer.ErrTooManyQubits},
+	}
+
+	for _, c := range cases {
+		if _, err := stabilizer.Run(c.c, nil, c.maxQubits); !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+	}
+}
+
+func TestIsClifford(t *testing.T) {
+	cases := []struct {
+		op   circuit.Op
+		want bool
+	}{
+		{gate(math.Pi/2, 0, math.Pi, 0), true},
+		{gate(0, 0, math.Pi/4, 0), false},
+		{gate(math.Pi/2, math.Pi/2, -math.Pi/2, 0), true},
+		{gate(0.1, 0, 0, 0), false},
+		{gate(0, 0, 0, 1, 0), true},
+		{gate(0, 0, math.Pi/4, 1, 0), false},
+		{gate(0, 0, math.Pi/2, 1, 0), false},
+	}
+
+	for _, c := range cases {
+		if got := stabilizer.IsClifford(&circuit.Circuit{Qubits: 2, Ops: []circuit.Op{c.op}}); got != c.want {
+			t.Errorf("%+v: got=%v, want=%v", c.op, got, c.want)
+		}
+	}
+}
+
+func TestState_Expectation(t *testing.T) {
+	// bell state
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+		},
+	}
+
+	s, err := stabilizer.Run(c, nil, 0)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	cases := []struct {
+		pauli string
+		index []int
+		want  float64
+	}{
+		{"ZZ", []int{0, 1}, 1},
+		{"XX", []int{0, 1}, 1},
+		{"YY", []int{0, 1}, -1},
+		{"ZI", []int{0, 1}, 0},
+		{"XY", []int{0, 1}, 0},
+		{"II", []int{0, 1}, 1},
+		{"Z", []int{1}, 0},
+	}
+
+	for _, c := range cases {
+		if got := s.Expectation(c.pauli, c.index); got != c.want {
+			t.Errorf("%s: got=%v, want=%v", c.pauli, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package statevector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+var (
+	ErrTooManyQubits  = errors.New("too many qubits")
+	ErrLengthMismatch = errors.New("length mismatch")
+)
+
+// State is the state vector of n qubits, where qubit 0 is the most significant bit.
+type State struct {
+	N         int
+	Amplitude []complex128
+	Clbits    []int
+}
+
+// New returns |0...0>.
+func New(n int) *State {
+	amp := make([]complex128, 1<<n)
+	amp[0] = 1
+
+	return &State{
+		N:         n,
+		Amplitude: amp,
+	}
+}
+
+// Run runs the circuit.
+// maxQubits limits the number of qubits, or 0 for no limit.
+func Run(c *circuit.Circuit, rand func() float64, maxQubits int) (*State, error) {
+	if maxQubits > 0 && c.Qubits > maxQubits {
+		return nil, fmt.Errorf("need=%d, max=%d: %w", c.Qubits, maxQubits, ErrTooManyQubits)
+	}
+
+	s := New(c.Qubits)
+	s.Clbits = make([]int, c.Clbits)
+	for _, op := range c.Ops {
+		s.Step(op, rand)
+	}
+
+	return s, nil
+}
+
+// Step applies the operation and returns the measured bit, or 0 if the operation is not a measurement.
+// rand is used for measurement and reset.
+func (s *State) Step(op circuit.Op, rand func() float64) int {
+	switch op.Kind {
+	case circuit.Gate:
+		s.Apply(op.Matrix(), op.Target, op.Controls, op.NegControls)
+	case circuit.GlobalPhase:
+		s.Phase(op.Phase)
+	case circuit.Measure:
+		m := s.Measure(op.Target, rand())
+		if op.Clbit >= 0 && op.Clbit < len(s.Clbits) {
+			s.Clbits[op.Clbit] = m
+		}
+
+		return m
+	case circuit.Reset:
+		s.Reset(op.Target, rand())
+	}
+
+	return 0
+}
+
+// Grow adds n qubits in |0> after the last qubit.
+func (s *State) Grow(n int) {
+	amp := make([]complex128, 1<<(s.N+n))
+	for k, a := range s.Amplitude {
+		amp[k<<n] = a
+	}
+
+	s.N, s.Amplitude = s.N+n, amp
+}
+
+// Machine runs the operations on the state as they are emitted by the compiler.
+// Rand is used for measurement and reset.
+type Machine struct {
+	*State
+	Rand func() float64
+}
+
+// Grow adds n qubits in |0>.
+func (m *Machine) Grow(n int) error {
+	m.State.Grow(n)
+	return nil
+}
+
+// Run runs the operation and returns the measured bit.
+func (m *Machine) Run(op circuit.Op) (int, error) {
+	return m.Step(op, m.Rand), nil
+}
+
+// Apply applies the controlled 2x2 matrix m.
+func (s *State) Apply(m [2][2]complex128, target int, controls, negControls []int) {
+	circuit.Apply(s.Amplitude, s.N, m, target, controls, negControls)
+}
+
+// Phase multiplies the state by exp(i*theta).
+func (s *State) Phase(theta float64) {
+	p := cmplx.Exp(complex(0, theta))
+	for i := range s.Amplitude {
+		s.Amplitude[i] *= p
+	}
+}
+
+// Measure measures the qubit in the computational basis and collapses the state.
+func (s *State) Measure(q int, r float64) int {
+	mask := 1 << (s.N - 1 - q)
+
+	var p1 float64
+	for i, a := range s.Amplitude {
+		if i&mask != 0 {
+			p1 += real(a)*real(a) + imag(a)*imag(a)
+		}
+	}
+
+	m, p := 0, 1-p1
+	if r < p1 {
+		m, p = 1, p1
+	}
+
+	norm := complex(math.Sqrt(p), 0)
+	for i := range s.Amplitude {
+		if (i&mask != 0) != (m == 1) {
+			s.Amplitude[i] = 0
+			continue
+		}
+
+		s.Amplitude[i] /= norm
+	}
+
+	return m
+}
+
+// Reset measures the qubit and flips it to |0> if the outcome is 1.
+func (s *State) Reset(q int, r float64) {
+	if s.Measure(q, r) == 1 {
+		s.Apply([2][2]complex128{{0, 1}, {1, 0}}, q, nil, nil)
+	}
+}
+
+// Probabilities returns the probabilities of the computational basis states.
+func (s *State) Probabilities() []float64 {
+	out := make([]float64, len(s.Amplitude))
+	for i, a := range s.Amplitude {
+		out[i] = real(a)*real(a) + imag(a)*imag(a)
+	}
+
+	return out
+}
+
+// Fidelity returns |<a|b>|^2 of the pure states.
+func Fidelity(a, b []complex128) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%d != %d: %w", len(a), len(b), ErrLengthMismatch)
+	}
+
+	var inner complex128
+	for i := range a {
+		inner += cmplx.Conj(a[i]) * b[i]
+	}
+
+	abs := cmplx.Abs(inner)
+	return abs * abs, nil
+}
+
+// TraceDistance returns the trace distance sqrt(1 - |<a|b>|^2) of the pure states.
+func TraceDistance(a, b []complex128) (float64, error) {
+	f, err := Fidelity(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Sqrt(max(0, 1-f)), nil
+}
@@ -0,0 +1,172 @@
+package statevector_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/statevector"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ExampleRun() {
+	c := &circuit.Circuit{
+		Qubits: 2,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+		},
+	}
+
+	s, err := statevector.Run(c, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, p := range s.Probabilities() {
+		fmt.Printf("%.4f\n", p)
+	}
+
+	// Output:
+	// 0.5000
+	// 0.0000
+	// 0.0000
+	// 0.5000
+}
+
+func ExampleState_Step() {
+	s := statevector.New(1)
+	for _, op := range []circuit.Op{
+		gate(math.Pi/2, 0, math.Pi, 0),
+		{Kind: circuit.GlobalPhase, Phase: math.Pi / 2},
+	} {
+		s.Step(op, nil)
+		fmt.Printf("%.4f\n", s.Amplitude)
+	}
+
+	// Output:
+	// [(0.7071+0.0000i) (0.7071+0.0000i)]
+	// [(0.0000+0.7071i) (0.0000+0.7071i)]
+}
+
+func ExampleMachine() {
+	m := &statevector.Machine{
+		State: statevector.New(0),
+		Rand:  func() float64 { return 0.1 },
+	}
+
+	// qubit q; x q; qubit r; measure q;
+	if err := m.Grow(1); err != nil {
+		panic(err)
+	}
+
+	if _, err := m.Run(gate(math.Pi, 0, math.Pi, 0)); err != nil {
+		panic(err)
+	}
+
+	if err := m.Grow(1); err != nil {
+		panic(err)
+	}
+
+	bit, err := m.Run(circuit.Op{Kind: circuit.Measure, Target: 0, Clbit: -1})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(bit, m.N)
+	fmt.Printf("%.4f\n", m.Probabilities())
+
+	// Output:
+	// 1 2
+	// [0.0000 0.0000 1.0000 0.0000]
+}
+
+func TestRun_measure(t *testing.T) {
+	qc := &circuit.Circuit{
+		Qubits: 2,
+		Clbits: 2,
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+			{Kind: circuit.Measure, Target: 0, Clbit: 0},
+			{Kind: circuit.Measure, Target: 1, Clbit: 1},
+			{Kind: circuit.Reset, Target: 0, Clbit: -1},
+		},
+	}
+
+	cases := []struct {
+		r    float64
+		bits []int
+		want []complex128
+	}{
+		{0.1, []int{1, 1}, []complex128{0, 1, 0, 0}},
+		{0.9, []int{0, 0}, []complex128{1, 0, 0, 0}},
+	}
+
+	for _, c := range cases {
+		s, err := statevector.Run(qc, func() float64 { return c.r }, 0)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		if fmt.Sprint(s.Clbits) != fmt.Sprint(c.bits) {
+			t.Errorf("got=%v, want=%v", s.Clbits, c.bits)
+		}
+
+		for i := range c.want {
+			if cmplx.Abs(s.Amplitude[i]-c.want[i]) > 1e-12 {
+				t.Errorf("got=%v, want=%v", s.Amplitude, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestRun_tooManyQubits(t *testing.T) {
+	if _, err := statevector.Run(&circuit.Circuit{Qubits: 3}, nil, 2); !errors.Is(err, statevector.ErrTooManyQubits) {
+		t.Errorf("got=%v, want=%v", err, statevector.ErrTooManyQubits)
+	}
+}
+
+func TestFidelity(t *testing.T) {
+	h := complex(1/math.Sqrt2, 0)
+
+	cases := []struct {
+		a, b     []complex128
+		fidelity float64
+		distance float64
+		err      error
+	}{
+		{[]complex128{1, 0}, []complex128{1, 0}, 1, 0, nil},
+		{[]complex128{1, 0}, []complex128{0, 1}, 0, 1, nil},
+		{[]complex128{1, 0}, []complex128{h, h}, 0.5, 1 / math.Sqrt2, nil},
+		{[]complex128{h, h}, []complex128{h * 1i, h * 1i}, 1, 0, nil},
+		{[]complex128{1, 0}, []complex128{1, 0, 0, 0}, 0, 0, statevector.ErrLengthMismatch},
+	}
+
+	for _, c := range cases {
+		f, err := statevector.Fidelity(c.a, c.b)
+		if !errors.Is(err, c.err) {
+			t.Errorf("got=%v, want=%v", err, c.err)
+		}
+
+		d, _ := statevector.TraceDistance(c.a, c.b)
+		if math.Abs(f-c.fidelity) > 1e-12 || math.Abs(d-c.distance) > 1e-6 {
+			t.Errorf("got=%v, %v, want=%v, %v", f, d, c.fidelity, c.distance)
+		}
+	}
+}
@@ -62,3 +62,95 @@ func Get[T any](data map[string]any, key string) (T, error) {
 
 	return typed, nil
 }
+
+type FirestoreJobStore struct {
+	Collection string
+	Client     *firestore.Client
+}
+
+func (s *FirestoreJobStore) Put(ctx context.Context, job *Job) error {
+	if _, err := s.Client.Collection(s.Collection).Doc(job.ID).Set(ctx, map[string]any{
+		"id":          job.ID,
+		"owner":       job.Owner,
+		"status":      string(job.Status),
+		"result":      job.Result,
+		"error":       job.Error,
+		"created_at":  job.CreatedAt,
+		"started_at":  job.StartedAt,
+		"finished_at": job.FinishedAt,
+	}); err != nil {
+		return fmt.Errorf("set: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FirestoreJobStore) Get(ctx context.Context, id string) (*Job, error) {
+	doc, err := s.Client.Collection(s.Collection).Doc(id).Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return toJob(doc.Data())
+}
+
+// List returns the most recently created jobs of the owner, up to limit.
+// The query needs a composite index of owner and created_at.
+func (s *FirestoreJobStore) List(ctx context.Context, owner string, limit int) ([]*Job, error) {
+	docs, err := s.Client.Collection(s.Collection).
+		Where("owner", "==", owner).
+		OrderBy("created_at", firestore.Desc).
+		Limit(limit).
+		Documents(ctx).
+		GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+
+	jobs := make([]*Job, len(docs))
+	for i, doc := range docs {
+		job, err := toJob(doc.Data())
+		if err != nil {
+			return nil, err
+		}
+
+		jobs[i] = job
+	}
+
+	return jobs, nil
+}
+
+func toJob(data map[string]any) (*Job, error) {
+	id, err := Get[string](data, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	owner, _ := Get[string](data, "owner")
+	status, err := Get[string](data, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := Get[[]byte](data, "result")
+	message, _ := Get[string](data, "error")
+
+	createdAt, err := Get[time.Time](data, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt, _ := Get[time.Time](data, "started_at")
+	finishedAt, _ := Get[time.Time](data, "finished_at")
+
+	return &Job{
+		ID:         id,
+		Owner:      owner,
+		Status:     JobStatus(status),
+		Result:     result,
+		Error:      message,
+		CreatedAt:  createdAt,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package store
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Done returns true if the job has finished.
+func (s JobStatus) Done() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCanceled
+}
+
+// Job is an asynchronous simulation.
+// Owner is the key of the caller who submitted the job, and the jobs are listed per owner.
+// Result is the serialized response of a succeeded job, and Error is the error message of a failed job.
+type Job struct {
+	ID         string
+	Owner      string
+	Status     JobStatus
+	Result     []byte
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
@@ -0,0 +1,22 @@
+package store_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/quasar/store"
+)
+
+func ExampleJobStatus_Done() {
+	fmt.Println(store.JobQueued.Done())
+	fmt.Println(store.JobRunning.Done())
+	fmt.Println(store.JobSucceeded.Done())
+	fmt.Println(store.JobFailed.Done())
+	fmt.Println(store.JobCanceled.Done())
+
+	// Output:
+	// false
+	// false
+	// true
+	// true
+	// true
+}
@@ -1,8 +1,11 @@
 package store
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
 )
 
@@ -36,3 +39,86 @@ func (s *MemoryStore) Get(_ context.Context, id string) (*Snippet, error) {
 
 	return snippet, nil
 }
+
+// maxJobs is the default number of jobs kept by MemoryJobStore.
+const maxJobs = 1000
+
+// MemoryJobStore keeps up to Max jobs, or maxJobs if Max is zero.
+// A new job evicts the oldest finished job, or the oldest job if none has finished.
+type MemoryJobStore struct {
+	Max int
+	m   map[string]*Job
+	sync.RWMutex
+}
+
+func (s *MemoryJobStore) Put(_ context.Context, job *Job) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.m == nil {
+		s.m = make(map[string]*Job)
+	}
+
+	if _, ok := s.m[job.ID]; !ok && len(s.m) >= cmp.Or(s.Max, maxJobs) {
+		s.evict()
+	}
+
+	copied := *job
+	s.m[job.ID] = &copied
+	return nil
+}
+
+func (s *MemoryJobStore) Get(_ context.Context, id string) (*Job, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	job, ok := s.m[id]
+	if !ok {
+		return nil, ErrNoSuchEntity
+	}
+
+	copied := *job
+	return &copied, nil
+}
+
+// List returns the most recently created jobs of the owner, up to limit.
+func (s *MemoryJobStore) List(_ context.Context, owner string, limit int) ([]*Job, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	jobs := make([]*Job, 0, len(s.m))
+	for _, job := range s.m {
+		if job.Owner != owner {
+			continue
+		}
+
+		copied := *job
+		jobs = append(jobs, &copied)
+	}
+
+	slices.SortFunc(jobs, func(a, b *Job) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return jobs[:min(limit, len(jobs))], nil
+}
+
+// evict removes the oldest finished job, or the oldest job if none has finished.
+func (s *MemoryJobStore) evict() {
+	var oldest *Job
+	for _, job := range s.m {
+		if oldest == nil ||
+			job.Status.Done() && !oldest.Status.Done() ||
+			job.Status.Done() == oldest.Status.Done() && job.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = job
+		}
+	}
+
+	if oldest != nil {
+		delete(s.m, oldest.ID)
+	}
+}
@@ -37,3 +37,88 @@ func ExampleMemoryStore_nohit() {
 	// Output:
 	// no such entity
 }
+
+func ExampleMemoryJobStore() {
+	s := &store.MemoryJobStore{}
+	now := time.Now()
+	for i, id := range []string{"foo", "bar", "baz", "qux"} {
+		owner := "owner"
+		if id == "qux" {
+			owner = "other"
+		}
+
+		if err := s.Put(context.TODO(), &store.Job{
+			ID:        id,
+			Owner:     owner,
+			Status:    store.JobQueued,
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			panic(err)
+		}
+	}
+
+	job, err := s.Get(context.TODO(), "foo")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(job.ID, job.Status)
+
+	jobs, err := s.List(context.TODO(), "owner", 2)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, job := range jobs {
+		fmt.Println(job.ID)
+	}
+
+	// Output:
+	// foo queued
+	// baz
+	// bar
+}
+
+func ExampleMemoryJobStore_evict() {
+	s := &store.MemoryJobStore{Max: 2}
+	now := time.Now()
+	for i, job := range []struct {
+		id     string
+		status store.JobStatus
+	}{
+		{"foo", store.JobRunning},
+		{"bar", store.JobSucceeded},
+		{"baz", store.JobQueued},
+		{"qux", store.JobQueued},
+	} {
+		if err := s.Put(context.TODO(), &store.Job{
+			ID:        job.id,
+			Status:    job.status,
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			panic(err)
+		}
+	}
+
+	jobs, err := s.List(context.TODO(), "", 10)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, job := range jobs {
+		fmt.Println(job.ID, job.Status)
+	}
+
+	// Output:
+	// qux queued
+	// baz queued
+}
+
+func ExampleMemoryJobStore_nohit() {
+	s := &store.MemoryJobStore{}
+	if _, err := s.Get(context.TODO(), "foo"); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// no such entity
+}
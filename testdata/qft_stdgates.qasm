@@ -0,0 +1,24 @@
+OPENQASM 3.0;
+
+include "stdgates.inc";
+gate cr(theta) c, t { ctrl @ U(0, 0, theta) c, t; }
+
+def qft(qubit[3] q) {
+    h q[0];
+    cr(pi/2) q[0], q[1];
+    cr(pi/4) q[0], q[2];
+
+    h q[1];
+    cr(pi/2) q[1], q[2];
+
+    h q[2];
+
+    cx q[0], q[2];
+    cx q[2], q[0];
+    cx q[0], q[2];
+}
+
+qubit[3] q;
+x q[2];
+qft(q);
+
@@ -0,0 +1,356 @@
+package transpile
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/quasar/circuit"
+)
+
+const eps = 1e-9
+
+var (
+	ErrUnsupportedGate = errors.New("unsupported basis gate")
+	ErrNotUniversal    = errors.New("basis is not universal")
+	ErrTooManyOps      = errors.New("too many operations")
+)
+
+// Gates are the supported basis gates and their definitions, as in stdgates.inc.
+var Gates = map[string]string{
+	"U":  "",
+	"u3": "gate u3(theta, phi, lambda) q { gphase(-(phi + lambda + theta) / 2); U(theta, phi, lambda) q; }",
+	"rz": "gate rz(lambda) q { gphase(-lambda / 2); U(0, 0, lambda) q; }",
+	"ry": "gate ry(theta) q { U(theta, 0, 0) q; }",
+	"rx": "gate rx(theta) q { U(theta, -pi / 2, pi / 2) q; }",
+	"sx": "gate sx q { gphase(pi / 4); U(pi / 2, -pi / 2, pi / 2) q; }",
+	"x":  "gate x q { U(pi, 0, pi) q; }",
+	"cx": "gate cx c, t { ctrl @ U(pi, 0, pi) c, t; }",
+	"cz": "gate cz c, t { ctrl @ U(0, 0, pi) c, t; }",
+}
+
+var (
+	x = [2][2]complex128{{0, 1}, {1, 0}}
+	z = [2][2]complex128{{1, 0}, {0, -1}}
+)
+
+type Transpiler struct {
+	basis  []string
+	single string // U, u3, rzsx, rzry or rzrx
+	maxOps int
+	ops    []circuit.Op
+}
+
+type Option func(*Transpiler)
+
+func WithMaxOps(n int) Option {
+	return func(t *Transpiler) {
+		t.maxOps = n
+	}
+}
+
+// Transpile returns a circuit with the same unitary as c up to global phase, made of the basis gates,
+// measurements, resets and barriers. The basis must have a two-qubit gate, cx or cz,
+// and a universal set of single-qubit gates, U, u3, {rz, sx}, {rz, ry} or {rz, rx}.
+// Multi-controlled gates are decomposed without ancillas.
+// The name and the parameters of each basis gate are in the Call of the operation.
+func Transpile(c *circuit.Circuit, basis []string, opts ...Option) (*circuit.Circuit, error) {
+	t, err := newTranspiler(basis, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range c.Ops {
+		switch op.Kind {
+		case circuit.Gate:
+			// negctrl @ g = x; ctrl @ g; x
+			for _, q := range op.NegControls {
+				t.unitary(x, q)
+			}
+
+			t.controlled(op.Matrix(), slices.Concat(op.Controls, op.NegControls), op.Target)
+
+			for _, q := range op.NegControls {
+				t.unitary(x, q)
+			}
+		case circuit.GlobalPhase:
+			// the global phase has no effect on the state
+			continue
+		default:
+			t.ops = append(t.ops, op)
+		}
+
+		if t.maxOps > 0 && len(t.ops) > t.maxOps {
+			return nil, fmt.Errorf("max=%d: %w", t.maxOps, ErrTooManyOps)
+		}
+	}
+
+	return &circuit.Circuit{
+		Qubits: c.Qubits,
+		Clbits: c.Clbits,
+		QRegs:  c.QRegs,
+		CRegs:  c.CRegs,
+		Ops:    t.ops,
+	}, nil
+}
+
+// QASM returns the transpiled circuit as an OpenQASM 3 program with the definitions of the basis gates.
+func QASM(c *circuit.Circuit) string {
+	var used []string
+	for _, op := range c.Ops {
+		if op.Call != nil && Gates[op.Call.Gate] != "" && !slices.Contains(used, op.Call.Gate) {
+			used = append(used, op.Call.Gate)
+		}
+	}
+
+	var defs []string
+	for _, name := range used {
+		defs = append(defs, Gates[name]+"\n")
+	}
+
+	return circuit.Format(c, strings.Join(defs, ""), func(op circuit.Op, operands string) string {
+		if op.Call == nil {
+			return ""
+		}
+
+		if len(op.Call.Params) == 0 {
+			return fmt.Sprintf("%s %s;", op.Call.Gate, operands)
+		}
+
+		params := make([]string, len(op.Call.Params))
+		for i, p := range op.Call.Params {
+			params[i] = circuit.Angle(p)
+		}
+
+		return fmt.Sprintf("%s(%s) %s;", op.Call.Gate, strings.Join(params, ", "), operands)
+	})
+}
+
+func newTranspiler(basis []string, opts ...Option) (*Transpiler, error) {
+	for _, g := range basis {
+		if _, ok := Gates[g]; !ok {
+			return nil, fmt.Errorf("%s: %w", g, ErrUnsupportedGate)
+		}
+	}
+
+	t := &Transpiler{basis: basis}
+	switch {
+	case t.has("U"):
+		t.single = "U"
+	case t.has("u3"):
+		t.single = "u3"
+	case t.has("rz") && t.has("sx"):
+		t.single = "rzsx"
+	case t.has("rz") && t.has("ry"):
+		t.single = "rzry"
+	case t.has("rz") && t.has("rx"):
+		t.single = "rzrx"
+	default:
+		return nil, fmt.Errorf("single-qubit gates=%v: %w", basis, ErrNotUniversal)
+	}
+
+	if !t.has("cx") && !t.has("cz") {
+		return nil, fmt.Errorf("two-qubit gates=%v: %w", basis, ErrNotUniversal)
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
+}
+
+func (t *Transpiler) has(name string) bool {
+	return slices.Contains(t.basis, name)
+}
+
+// controlled appends the decomposition of the 2x2 unitary m on target, conditioned on the controls being |1>.
+func (t *Transpiler) controlled(m [2][2]complex128, controls []int, target int) {
+	if t.maxOps > 0 && len(t.ops) > t.maxOps {
+		return
+	}
+
+	switch len(controls) {
+	case 0:
+		t.unitary(m, target)
+	case 1:
+		if equals(m, x) {
+			t.cx(controls[0], target)
+			return
+		}
+
+		if equals(m, z) && t.has("cz") {
+			t.append("cz", nil, 0, 0, math.Pi, 0, target, controls[0])
+			return
+		}
+
+		// m = exp(i*alpha) * A X B X C, where ABC = I
+		theta, phi, lambda, gamma := circuit.ZYZ(m)
+		alpha := gamma + (phi+lambda)/2
+		t.unitary(circuit.U(0, 0, (lambda-phi)/2, 0), target)
+		t.cx(controls[0], target)
+		t.unitary(circuit.U(-theta/2, 0, -(phi+lambda)/2, 0), target)
+		t.cx(controls[0], target)
+		t.unitary(circuit.U(theta/2, phi, 0, 0), target)
+
+		// the phase on the subspace selected by the control
+		t.unitary(circuit.U(0, 0, alpha, 0), controls[0])
+	default:
+		// Barenco et al., Lemma 7.5, where V^2 = m
+		v := circuit.Pow(m, 0.5)
+		last, rest := controls[len(controls)-1], controls[:len(controls)-1]
+		t.controlled(v, []int{last}, target)
+		t.controlled(x, rest, last)
+		t.controlled(dagger(v), []int{last}, target)
+		t.controlled(x, rest, last)
+		t.controlled(v, rest, target)
+	}
+}
+
+// cx appends a controlled X gate.
+func (t *Transpiler) cx(control, target int) {
+	if t.has("cx") {
+		t.append("cx", nil, math.Pi, 0, math.Pi, 0, target, control)
+		return
+	}
+
+	// cx = h; cz; h
+	h := circuit.U(math.Pi/2, 0, math.Pi, 0)
+	t.unitary(h, target)
+	t.append("cz", nil, 0, 0, math.Pi, 0, target, control)
+	t.unitary(h, target)
+}
+
+// unitary appends the single-qubit gates for the 2x2 unitary m up to global phase.
+func (t *Transpiler) unitary(m [2][2]complex128, q int) {
+	theta, phi, lambda, _ := circuit.ZYZ(m)
+	theta, phi, lambda = angle(theta), angle(phi), angle(lambda)
+	if theta == 0 && angle(phi+lambda) == 0 {
+		// identity
+		return
+	}
+
+	if t.has("x") && equals(m, x) {
+		t.append("x", nil, math.Pi, 0, math.Pi, 0, q)
+		return
+	}
+
+	switch t.single {
+	case "U":
+		t.append("U", []float64{theta, phi, lambda}, theta, phi, lambda, 0, q)
+	case "u3":
+		t.append("u3", []float64{theta, phi, lambda}, theta, phi, lambda, -(phi+lambda+theta)/2, q)
+	case "rzsx":
+		if theta == 0 {
+			t.rz(phi+lambda, q)
+			return
+		}
+
+		if theta == math.Pi/2 {
+			// sx = rz(-pi/2) ry(pi/2) rz(pi/2) up to global phase
+			t.rz(lambda-math.Pi/2, q)
+			t.append("sx", nil, math.Pi/2, -math.Pi/2, math.Pi/2, math.Pi/4, q)
+			t.rz(phi+math.Pi/2, q)
+			return
+		}
+
+		// U(theta, phi, lambda) = rz(phi+pi) sx rz(theta+pi) sx rz(lambda) up to global phase
+		t.rz(lambda, q)
+		t.append("sx", nil, math.Pi/2, -math.Pi/2, math.Pi/2, math.Pi/4, q)
+		t.rz(theta+math.Pi, q)
+		t.append("sx", nil, math.Pi/2, -math.Pi/2, math.Pi/2, math.Pi/4, q)
+		t.rz(phi+math.Pi, q)
+	case "rzry":
+		if theta == math.Pi {
+			// U(pi, phi, lambda) = U(pi, phi-lambda, 0) up to global phase
+			phi, lambda = phi-lambda, 0
+		}
+
+		t.rz(lambda, q)
+		if theta != 0 {
+			t.append("ry", []float64{theta}, theta, 0, 0, 0, q)
+		}
+
+		t.rz(phi, q)
+	case "rzrx":
+		if theta == 0 {
+			t.rz(phi+lambda, q)
+			return
+		}
+
+		if theta == math.Pi {
+			// U(pi, phi, lambda) = U(pi, phi-lambda+pi/2, pi/2) up to global phase
+			phi, lambda = phi-lambda+math.Pi/2, math.Pi/2
+		}
+
+		// ry(theta) = rz(pi/2) rx(theta) rz(-pi/2)
+		t.rz(lambda-math.Pi/2, q)
+		t.append("rx", []float64{theta}, theta, -math.Pi/2, math.Pi/2, 0, q)
+		t.rz(phi+math.Pi/2, q)
+	}
+}
+
+func (t *Transpiler) rz(lambda float64, q int) {
+	if lambda = angle(lambda); lambda == 0 {
+		return
+	}
+
+	t.append("rz", []float64{lambda}, 0, 0, lambda, -lambda/2, q)
+}
+
+func (t *Transpiler) append(name string, params []float64, theta, phi, lambda, phase float64, target int, controls ...int) {
+	t.ops = append(t.ops, circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Phase:    phase,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+		Call: &circuit.Call{
+			Name:     name,
+			Params:   params,
+			Qubits:   append(slices.Clone(controls), target),
+			Gate:     name,
+			Controls: controls,
+			Power:    1,
+		},
+	})
+}
+
+// angle returns the angle in (-pi, pi], rounded to a multiple of pi/16 if it is within the rounding error.
+func angle(v float64) float64 {
+	v = math.Remainder(v, 2*math.Pi)
+	if n := v * 16 / math.Pi; math.Abs(n-math.Round(n)) < eps {
+		v = math.Round(n) * math.Pi / 16
+	}
+
+	if v == -math.Pi {
+		return math.Pi
+	}
+
+	return v
+}
+
+func equals(a, b [2][2]complex128) bool {
+	for i := range 2 {
+		for j := range 2 {
+			if cmplx.Abs(a[i][j]-b[i][j]) > eps {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func dagger(m [2][2]complex128) [2][2]complex128 {
+	return [2][2]complex128{
+		{cmplx.Conj(m[0][0]), cmplx.Conj(m[1][0])},
+		{cmplx.Conj(m[0][1]), cmplx.Conj(m[1][1])},
+	}
+}
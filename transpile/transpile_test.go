@@ -0,0 +1,162 @@
+package transpile_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/itsubaki/quasar/circuit"
+	"github.com/itsubaki/quasar/transpile"
+)
+
+func gate(theta, phi, lambda float64, target int, controls ...int) circuit.Op {
+	return circuit.Op{
+		Kind:     circuit.Gate,
+		Theta:    theta,
+		Phi:      phi,
+		Lambda:   lambda,
+		Target:   target,
+		Controls: controls,
+		Clbit:    -1,
+	}
+}
+
+func ExampleTranspile() {
+	c := &circuit.Circuit{
+		Qubits: 2,
+		QRegs:  []circuit.Register{{Name: "q", Index: []int{0, 1}}},
+		Ops: []circuit.Op{
+			gate(math.Pi/2, 0, math.Pi, 0),
+			gate(math.Pi, 0, math.Pi, 1, 0),
+		},
+	}
+
+	got, err := transpile.Transpile(c, []string{"rz", "sx", "x", "cz"})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(transpile.QASM(got))
+
+	// Output:
+	// OPENQASM 3.0;
+	//
+	// gate rz(lambda) q { gphase(-lambda / 2); U(0, 0, lambda) q; }
+	// gate sx q { gphase(pi / 4); U(pi / 2, -pi / 2, pi / 2) q; }
+	// gate cz c, t { ctrl @ U(0, 0, pi) c, t; }
+	//
+	// qubit[2] q;
+	//
+	// rz(pi/2) q[0];
+	// sx q[0];
+	// rz(pi/2) q[0];
+	// rz(pi/2) q[1];
+	// sx q[1];
+	// rz(pi/2) q[1];
+	// cz q[0], q[1];
+	// rz(pi/2) q[1];
+	// sx q[1];
+	// rz(pi/2) q[1];
+}
+
+func TestTranspile(t *testing.T) {
+	bases := [][]string{
+		{"U", "cx"},
+		{"u3", "cz"},
+		{"rz", "sx", "x", "cx"},
+		{"rz", "sx", "cz"},
+		{"rz", "ry", "cx"},
+		{"rz", "rx", "cz"},
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	angles := []float64{0, math.Pi / 4, math.Pi / 2, math.Pi, -math.Pi / 2, 0.3}
+	angle := func() float64 { return angles[rng.IntN(len(angles))] }
+
+	for range 100 {
+		n := 4
+		c := &circuit.Circuit{Qubits: n}
+		for range 1 + rng.IntN(10) {
+			target := rng.IntN(n)
+			op := gate(angle(), angle(), angle(), target)
+			op.Phase = angle()
+			for q := range n {
+				if q == target || rng.IntN(2) != 0 {
+					continue
+				}
+
+				if rng.IntN(2) == 0 {
+					op.Controls = append(op.Controls, q)
+				} else {
+					op.NegControls = append(op.NegControls, q)
+				}
+			}
+
+			c.Ops = append(c.Ops, op)
+		}
+
+		for _, basis := range bases {
+			got, err := transpile.Transpile(c, basis)
+			if err != nil {
+				t.Fatalf("transpile: %v", err)
+			}
+
+			for _, op := range got.Ops {
+				if op.Call == nil || !slices.Contains(basis, op.Call.Gate) {
+					t.Fatalf("basis=%v, got=%v", basis, op)
+				}
+
+				if len(op.Controls) > 1 || len(op.NegControls) > 0 {
+					t.Fatalf("basis=%v, got=%v", basis, op)
+				}
+			}
+
+			fidelity, _, err := circuit.Equivalent(c, got)
+			if err != nil {
+				t.Fatalf("equivalent: %v", err)
+			}
+
+			if math.Abs(fidelity-1) > 1e-9 {
+				t.Fatalf("basis=%v, fidelity=%v, ops=%v", basis, fidelity, c.Ops)
+			}
+
+			// the basis gates are kept as they are
+			again, err := transpile.Transpile(got, basis)
+			if err != nil {
+				t.Fatalf("transpile: %v", err)
+			}
+
+			if len(again.Ops) != len(got.Ops) {
+				t.Fatalf("basis=%v, got=%d, want=%d", basis, len(again.Ops), len(got.Ops))
+			}
+		}
+	}
+}
+
+func TestTranspile_error(t *testing.T) {
+	c := &circuit.Circuit{
+		Qubits: 3,
+		Ops:    []circuit.Op{gate(math.Pi, 0, math.Pi, 2, 0, 1)},
+	}
+
+	cases := []struct {
+		basis []string
+		opts  []transpile.Option
+		want  error
+	}{
+		{[]string{"h", "cx"}, nil, transpile.ErrUnsupportedGate},
+		{[]string{"rz", "cx"}, nil, transpile.ErrNotUniversal},
+		{[]string{"sx", "x", "cx"}, nil, transpile.ErrNotUniversal},
+		{[]string{"U"}, nil, transpile.ErrNotUniversal},
+		{[]string{"U", "cx"}, []transpile.Option{transpile.WithMaxOps(3)}, transpile.ErrTooManyOps},
+	}
+
+	for _, c2 := range cases {
+		if _, err := transpile.Transpile(c, c2.basis, c2.opts...); !errors.Is(err, c2.want) {
+			t.Errorf("basis=%v, got=%v, want=%v", c2.basis, err, c2.want)
+		}
+	}
+}